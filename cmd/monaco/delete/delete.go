@@ -44,7 +44,7 @@ func Delete(ctx context.Context, environments manifest.EnvironmentDefinitionsByN
 			slog.WarnContext(ctx, "Delete file contains entries referencing platform configurations, but environment is missing platform credentials. These configurations won't be deleted.")
 		}
 
-		clientSet, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+		clientSet, err := client.CreateClientSetForEnvironment(ctx, env)
 		if err != nil {
 			return fmt.Errorf("failed to create API client for environment %q due to the following error: %w", env.Name, err)
 		}
@@ -29,19 +29,21 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/packaging"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 	monacoVersion "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
 )
 
 func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 	var dryRun, continueOnError bool
-	var manifestName string
-	var environment, project, groups []string
+	var manifestName, checksum string
+	var environment, project, groups, valuesFiles []string
 
 	deployCmd = &cobra.Command{
-		Use:               "deploy <manifest.yaml>",
+		Use:               "deploy <manifest.yaml|package.zip>",
 		Short:             "Deploy configurations to Dynatrace environments",
-		Example:           "monaco deploy manifest.yaml -v -e dev-environment",
+		Long:              "Deploy the configurations of the given manifest, or of a package created with 'monaco package'. Packages are verified against their checksum before deploying them, and can only be deployed with the monaco version and feature flags they were created with.",
+		Example:           "monaco deploy manifest.yaml -v -e dev-environment\nmonaco deploy package-1.2.0.zip -e prod-environment\nmonaco deploy manifest.yaml -e prod-environment --values prod-values.yaml",
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completion.DeployCompletion,
 		PreRun:            cmdutils.SilenceUsageCommand(),
@@ -50,13 +52,29 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 			ctx := createDeploymentContext(cmd.Context(), fs)
 			defer finishReport(ctx)
 
+			if packaging.IsArchive(manifestName) {
+				pkg, err := packaging.Open(fs, manifestName, checksum)
+				if err != nil {
+					report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+					return err
+				}
+				log.InfoContext(ctx, "Deploying version %s of package %q", pkg.Lock.Version, manifestName)
+				return deployConfigs(ctx, pkg.Fs, pkg.ManifestPath, groups, environment, project, valuesFiles, continueOnError, dryRun)
+			}
+
+			if checksum != "" {
+				err := fmt.Errorf("'--checksum' can only be used to deploy packages, but %s is not a package", manifestName)
+				report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
+				return err
+			}
+
 			if !files.IsYamlFileExtension(manifestName) {
 				err := fmt.Errorf("wrong format for manifest file! expected a .yaml file, but got %s", manifestName)
 				report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, err, "", nil)
 				return err
 			}
 
-			return deployConfigs(ctx, fs, manifestName, groups, environment, project, continueOnError, dryRun)
+			return deployConfigs(ctx, fs, manifestName, groups, environment, project, valuesFiles, continueOnError, dryRun)
 		},
 	}
 
@@ -70,6 +88,16 @@ func GetDeployCommand(fs afero.Fs) (deployCmd *cobra.Command) {
 			"If this flag is specified, all environments within this group will be used for deployment. "+
 			"This flag is mutually exclusive with '--environment'")
 	deployCmd.Flags().StringSliceVarP(&project, "project", "p", make([]string, 0), "Project configuration to deploy (also deploys any dependent configurations)")
+	deployCmd.Flags().StringArrayVar(&valuesFiles, "values", []string{},
+		"Values file setting parameters. Each top-level key maps parameter names to their values, and is either the name of a project, setting parameters of all its configs, "+
+			"or the coordinate of a single config in the form 'project:type:configId', e.g. 'my-project:builtin:alerting.profile:my-profile'. "+
+			"Coordinates are separated by colons, as types like 'builtin:alerting.profile' contain dots. "+
+			"Keys that do not match any project or config of the deployed projects are rejected. "+
+			"Values take precedence over the parameters defined in projects. "+
+			"To use multiple values files repeat this flag, later files take precedence over earlier ones.")
+	deployCmd.Flags().StringVar(&checksum, "checksum", "",
+		"The SHA-256 checksum a package must match. "+
+			"If not set, the package must match the checksum in the file next to it, named like the package with the extension '"+packaging.ChecksumFileExtension+"'.")
 	deployCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Validate the structure of your manifest, projects and configurations. Dry-run will resolve all configuration parameters and render JSON templates, but can not validate the content of JSON payloads. After a successful dry-run, deployments may still fail with Dynatrace API errors if the content of JSONs is not valid.")
 	deployCmd.Flags().BoolVarP(&continueOnError, "continue-on-error", "c", false, "Proceed deployment even if individual configuration deployments fail.")
 
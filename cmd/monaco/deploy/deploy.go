@@ -31,6 +31,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
@@ -38,7 +39,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/report"
 )
 
-func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, continueOnErr bool, dryRun bool) error {
+func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, environmentGroups []string, specificEnvironments []string, specificProjects []string, valuesFiles []string, continueOnErr bool, dryRun bool) error {
 	absManifestPath, err := absPath(manifestPath)
 	if err != nil {
 		formattedErr := fmt.Errorf("error while finding absolute path for `%s`: %w", manifestPath, err)
@@ -58,7 +59,14 @@ func deployConfigs(ctx context.Context, fs afero.Fs, manifestPath string, enviro
 		}
 	}
 
-	loadedProjects, err := loadProjects(ctx, fs, absManifestPath, loadedManifest, specificProjects)
+	values, err := loader.LoadValuesFiles(fs, valuesFiles)
+	if err != nil {
+		formattedErr := fmt.Errorf("failed to load values files: %w", err)
+		report.GetReporterFromContextOrDiscard(ctx).ReportLoading(report.StateError, formattedErr, "", nil)
+		return formattedErr
+	}
+
+	loadedProjects, err := loadProjects(ctx, fs, absManifestPath, loadedManifest, specificProjects, values)
 	if err != nil {
 		return err
 	}
@@ -119,12 +127,13 @@ func loadManifest(ctx context.Context, fs afero.Fs, manifestPath string, groups
 	return &m, nil
 }
 
-func loadProjects(ctx context.Context, fs afero.Fs, manifestPath string, man *manifest.Manifest, specificProjects []string) ([]project.Project, error) {
+func loadProjects(ctx context.Context, fs afero.Fs, manifestPath string, man *manifest.Manifest, specificProjects []string, values *loader.Values) ([]project.Project, error) {
 	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
 		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
 		WorkingDir:      filepath.Dir(manifestPath),
 		Manifest:        *man,
 		ParametersSerde: config.DefaultParameterParsers,
+		Values:          values,
 	}, specificProjects)
 
 	if errs != nil {
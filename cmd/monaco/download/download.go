@@ -27,6 +27,7 @@ import (
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
@@ -55,6 +56,7 @@ type downloadOptionsShared struct {
 	outputFolder           string
 	projectName            string
 	forceOverwriteManifest bool
+	templateFormat         template.Format
 }
 
 func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptionsShared, fs afero.Fs) error {
@@ -66,6 +68,7 @@ func writeConfigs(downloadedConfigs project.ConfigsPerType, opts downloadOptions
 		Auth:           opts.auth,
 		OutputFolder:   opts.outputFolder,
 		ForceOverwrite: opts.forceOverwriteManifest,
+		TemplateFormat: opts.templateFormat,
 	}
 	err := download.WriteToDisk(fs, downloadWriterContext)
 	if err != nil {
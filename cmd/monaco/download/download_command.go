@@ -25,6 +25,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
 
 type OnlyFlag = string
@@ -42,6 +43,7 @@ const (
 	ProjectFlag                   = "project"
 	OutputFolderFlag              = "output-folder"
 	ForceFlag                     = "force"
+	TemplateFormatFlag            = "template-format"
 	OnlyApisFlag         OnlyFlag = "only-apis"
 	OnlySettingsFlag     OnlyFlag = "only-settings"
 	OnlyAutomationFlag   OnlyFlag = "only-automation"
@@ -75,6 +77,10 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
   monaco download --%s url [--%s DT_TOKEN] [--%s CLIENT_ID --%s CLIENT_SECRET]%s ...`, ManifestFlag, EnvironmentFlag, UrlFlag, AccessTokenFlag, OAuthIdFlag, OAuthSecretFlag, platformTokenAddendum),
 
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateTemplateFormat(f.templateFormat); err != nil {
+				return err
+			}
+
 			if f.environmentURL != "" {
 				return preRunChecksForDirectDownload(f)
 			}
@@ -133,6 +139,7 @@ func GetDownloadCommand(fs afero.Fs, command Command) (cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&onlySegments, OnlySegmentsFlag, false, "Only download segment configurations")
 	cmd.Flags().BoolVar(&onlyOpenPipeline, OnlyOpenPipelineFlag, false, "Only download openpipeline configurations")
 	cmd.Flags().BoolVar(&onlySloV2, OnlySloV2Flag, false, fmt.Sprintf("Only download %s configurations", config.ServiceLevelObjectiveID))
+	cmd.Flags().StringVar(&f.templateFormat, TemplateFormatFlag, "", fmt.Sprintf("Format to write downloaded templates in. One of [%s, %s]. If not specified, templates are written as %s.", template.FormatJSON, template.FormatYAML, template.FormatJSON))
 
 	// combinations
 	cmd.MarkFlagsMutuallyExclusive(SettingsSchemaFlag, OnlySettingsFlag)
@@ -197,6 +204,15 @@ func preRunChecksForManifestDownload(f downloadCmdOptions) error {
 	return nil
 }
 
+func validateTemplateFormat(format string) error {
+	switch template.Format(format) {
+	case "", template.FormatJSON, template.FormatYAML:
+		return nil
+	default:
+		return fmt.Errorf("'--%s' must be one of [%s, %s], but was %q", TemplateFormatFlag, template.FormatJSON, template.FormatYAML, format)
+	}
+}
+
 func setupSharedFlags(cmd *cobra.Command, project, outputFolder *string, forceOverwrite *bool) {
 	// flags always available
 	cmd.Flags().StringVarP(project, ProjectFlag, "p", "project", "Project to create within the output-folder")
@@ -245,6 +245,27 @@ func TestGetDownloadCommand(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("Template format is passed on", func(t *testing.T) {
+		m := newMonaco(t)
+
+		expected := downloadCmdOptions{
+			manifestFile:            "manifest.yaml",
+			specificEnvironmentName: "my-environment",
+			projectName:             "project",
+			onlyOptions:             defaultOnlyOptions,
+			templateFormat:          "yaml",
+		}
+		m.EXPECT().DownloadConfigsBasedOnManifest(gomock.Any(), gomock.Any(), expected).Return(nil)
+
+		err := m.download("--environment my-environment --template-format yaml")
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unknown template format", func(t *testing.T) {
+		err := newMonaco(t).download("--environment my-environment --template-format xml")
+		assert.EqualError(t, err, `'--template-format' must be one of [json, yaml], but was "xml"`)
+	})
+
 	t.Run("Default project name is used if not set", func(t *testing.T) {
 		m := newMonaco(t)
 
@@ -28,6 +28,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	tmpl "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/dependency_resolution"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/download/id_extraction"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
@@ -55,6 +56,7 @@ type downloadCmdOptions struct {
 	specificAPIs            []string
 	specificSchemas         []string
 	onlyOptions             OnlyOptions
+	templateFormat          string
 }
 
 func (d downloadCmdOptions) toDownloadConfigsOptions(url manifest.URLDefinition, auth manifest.Auth) downloadConfigsOptions {
@@ -65,6 +67,7 @@ func (d downloadCmdOptions) toDownloadConfigsOptions(url manifest.URLDefinition,
 			outputFolder:           d.outputFolder,
 			projectName:            d.projectName,
 			forceOverwriteManifest: d.forceOverwrite,
+			templateFormat:         tmpl.Format(d.templateFormat),
 		},
 		specificAPIs:    d.specificAPIs,
 		specificSchemas: d.specificSchemas,
@@ -106,7 +109,7 @@ func (d DefaultCommand) DownloadConfigsBasedOnManifest(ctx context.Context, fs a
 		return err
 	}
 
-	clientSet, err := client.CreateClientSet(ctx, options.environmentURL.Value, options.auth)
+	clientSet, err := client.CreateClientSetForEnvironment(ctx, env)
 	if err != nil {
 		return err
 	}
@@ -37,6 +37,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/accesstoken"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/classicheartbeat"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/metadata"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 
 	"golang.org/x/oauth2/clientcredentials"
@@ -61,11 +62,15 @@ func VerifyEnvironmentAuthentication(ctx context.Context, env manifest.Environme
 		return ErrorMissingAuth
 	}
 
+	ctx, err := client.ContextWithConnection(ctx, env.Connection)
+	if err != nil {
+		return fmt.Errorf("failed to set up connection to environment '%s': %w", env.Name, err)
+	}
+
 	classicUrl := env.URL.Value
 
 	// check if the platform connection works and get the classicURL in order to check the access token authentication next if given
 	if env.HasPlatformCredentials() {
-		var err error
 		if classicUrl, err = getDynatraceClassicURL(ctx, env.URL.Value, env.Auth.OAuth, env.Auth.PlatformToken); err != nil {
 			return fmt.Errorf("could not authorize against environment '%s' (%s) using platform credentials: %w", env.Name, env.URL.Value, err)
 		}
@@ -81,7 +86,7 @@ func VerifyEnvironmentAuthentication(ctx context.Context, env manifest.Environme
 
 // checkClassicConnection checks if a classic connection (via access token) can be established. Scopes are not validated.
 func checkClassicConnection(ctx context.Context, classicURL string, accessToken string) error {
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
+	additionalHeaders := client.AdditionalHTTPHeaders(ctx)
 	factory := clients.Factory().
 		WithClassicURL(classicURL).
 		WithAccessToken(accessToken).
@@ -161,6 +166,15 @@ type (
 	EnvironmentInfo struct {
 		Name  string
 		Group string
+		// URL is the URL of the environment as defined in the manifest
+		URL string
+		// PlatformURL is the URL of the platform environment, or empty if the environment is no platform environment
+		PlatformURL string
+		// ClassicURL is the URL of the classic environment
+		ClassicURL string
+		// ConcurrentDeployments is the maximum number of configurations deployed concurrently to the environment.
+		// If 0, the limit defined via environment variable applies.
+		ConcurrentDeployments int
 	}
 	// EnvironmentClients is a collection of clients to use for specific environments
 	EnvironmentClients map[EnvironmentInfo]*client.ClientSet
@@ -180,21 +194,31 @@ func CreateEnvironmentClients(ctx context.Context, environments manifest.Environ
 	clients := make(EnvironmentClients, len(environments))
 	for _, env := range environments {
 		if dryRun {
+			// without a connection, the URLs can only be derived from the one defined in the manifest
+			urls := config.NewEnvironmentProperties(env.Name, env.Group, env.URL.Value)
 			clients[EnvironmentInfo{
-				Name:  env.Name,
-				Group: env.Group,
+				Name:                  env.Name,
+				Group:                 env.Group,
+				URL:                   env.URL.Value,
+				PlatformURL:           urls.PlatformURL,
+				ClassicURL:            urls.ClassicURL,
+				ConcurrentDeployments: env.Limits.ConcurrentDeployments,
 			}] = &client.DummyClientSet
 			continue
 		}
 
-		clientSet, err := client.CreateClientSet(ctx, env.URL.Value, env.Auth)
+		clientSet, err := client.CreateClientSetForEnvironment(ctx, env)
 		if err != nil {
 			return EnvironmentClients{}, err
 		}
 
 		clients[EnvironmentInfo{
-			Name:  env.Name,
-			Group: env.Group,
+			Name:                  env.Name,
+			Group:                 env.Group,
+			URL:                   env.URL.Value,
+			PlatformURL:           clientSet.PlatformURL,
+			ClassicURL:            clientSet.ClassicURL,
+			ConcurrentDeployments: env.Limits.ConcurrentDeployments,
 		}] = clientSet
 	}
 
@@ -209,7 +233,7 @@ func getDynatraceClassicURL(ctx context.Context, platformURL string, oauth *mani
 		}
 	}
 
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
+	additionalHeaders := client.AdditionalHTTPHeaders(ctx)
 	factory := clients.Factory().
 		WithPlatformURL(platformURL).
 		WithCustomHeaders(additionalHeaders)
@@ -239,7 +263,7 @@ func findSimpleClassicURL(ctx context.Context, platformURL string) (classicUrl s
 		return "", false
 	}
 
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
+	additionalHeaders := client.AdditionalHTTPHeaders(ctx)
 	classicUrl = strings.Replace(platformURL, ".apps.", ".live.", 1)
 
 	client, err := clients.Factory().
@@ -0,0 +1,56 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var check bool
+
+	cmd = &cobra.Command{
+		Use:   "fmt <manifest.yaml>",
+		Short: "Format the config files and JSON templates of all projects of the manifest",
+		Long: "Fmt rewrites the config files of all projects in the canonical order monaco writes them in on download, and indents JSON templates by two spaces, keeping all template actions. " +
+			"Comments in config files are not kept. Use --check to only list files that are not formatted.",
+		Example:           "monaco fmt manifest.yaml --check",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			return formatProjects(cmd.Context(), fs, manifestName, check)
+		},
+	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Do not change any file, but fail if any file is not formatted")
+
+	return cmd
+}
@@ -0,0 +1,168 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	accountloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/persistence/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/formatter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/loader"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+)
+
+// formatProjects formats the config files and JSON templates of all projects of the given manifest. If check is set,
+// no file is changed, but an error is returned if any file is not formatted.
+func formatProjects(ctx context.Context, fs afero.Fs, manifestPath string, check bool) error {
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts:         manifestloader.Options{DoNotResolveEnvVars: true},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	var unformatted []string
+	errs = nil
+	for _, name := range slices.Sorted(maps.Keys(m.Projects)) {
+		u, e := formatProject(ctx, fs, filepath.Join(filepath.Dir(manifestPath), m.Projects[name].Path), check)
+		unformatted = append(unformatted, u...)
+		errs = append(errs, e...)
+	}
+
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to format projects - %d errors occurred", len(errs))
+	}
+
+	if check && len(unformatted) > 0 {
+		return fmt.Errorf("%d files are not formatted - run 'monaco fmt' to format them", len(unformatted))
+	}
+
+	log.InfoContext(ctx, "Formatting finished")
+	return nil
+}
+
+func formatProject(ctx context.Context, fs afero.Fs, projectPath string, check bool) (unformatted []string, errs []error) {
+	configFiles, err := files.FindYamlFiles(fs, projectPath)
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to walk files of project %q: %w", projectPath, err)}
+	}
+
+	yamlTemplates := map[string]struct{}{}
+	for _, file := range configFiles {
+		for _, t := range loader.ReferencedYAMLTemplates(fs, file) {
+			yamlTemplates[filepath.Clean(t)] = struct{}{}
+		}
+	}
+
+	jsonTemplates := map[string]struct{}{}
+	for _, file := range configFiles {
+		if _, isTemplate := yamlTemplates[filepath.Clean(file)]; isTemplate || filepath.Base(file) == loader.DefaultsFileName {
+			continue
+		}
+		if isAccountResourceFile(fs, file) {
+			slog.WarnContext(ctx, "File appears to be an account resource file, skipping formatting", slog.String("file", file))
+			continue
+		}
+
+		changed, err := formatFile(fs, file, check, formatter.FormatConfigFile)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if changed {
+			unformatted = append(unformatted, file)
+		}
+
+		for _, t := range loader.ReferencedJSONTemplates(fs, file) {
+			jsonTemplates[t] = struct{}{}
+		}
+	}
+
+	for _, t := range slices.Sorted(maps.Keys(jsonTemplates)) {
+		changed, err := formatFile(fs, t, check, formatter.FormatJSONTemplate)
+		if err != nil {
+			// templates that can not be parsed as JSON are still valid templates, they are just not formatted
+			slog.WarnContext(ctx, "Skipping template that can not be formatted", slog.String("file", t), log.ErrorAttr(err))
+			continue
+		}
+		if changed {
+			unformatted = append(unformatted, t)
+		}
+	}
+
+	return unformatted, errs
+}
+
+// isAccountResourceFile returns whether the given file defines account resources. Like the config loader does, such
+// files are skipped. Files that can not be parsed are not considered account resource files.
+func isAccountResourceFile(fs afero.Fs, file string) bool {
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return false
+	}
+
+	var content map[string]any
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return false
+	}
+	return accountloader.HasAnyAccountKeyDefined(content)
+}
+
+// formatFile formats the given file. It returns whether the file was not formatted. If check is set, the file is
+// not changed.
+func formatFile(fs afero.Fs, file string, check bool, format func([]byte) ([]byte, error)) (bool, error) {
+	content, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %q: %w", file, err)
+	}
+
+	formatted, err := format(content)
+	if err != nil {
+		return false, fmt.Errorf("failed to format %q: %w", file, err)
+	}
+
+	if bytes.Equal(content, formatted) {
+		return false, nil
+	}
+
+	if check {
+		log.Warn("%s is not formatted", file)
+		return true, nil
+	}
+
+	if err := afero.WriteFile(fs, file, formatted, 0644); err != nil {
+		return false, fmt.Errorf("failed to write %q: %w", file, err)
+	}
+	log.Info("Formatted %s", file)
+	return true, nil
+}
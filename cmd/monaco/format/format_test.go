@@ -0,0 +1,120 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const manifestYAML = `
+manifestVersion: 1.0
+projects: [{name: project}]
+environmentGroups:
+- name: default
+  environments:
+  - {name: env, url: {value: https://example.com}, auth: {token: {name: TOKEN_NOT_SET}}}
+`
+
+const unformattedConfig = `configs:
+- {id: zone, type: {api: management-zone}, config: {name: Zone, template: zone.json}}
+`
+
+const formattedConfig = `configs:
+- id: zone
+  config:
+    name: Zone
+    template: zone.json
+  type:
+    api: management-zone
+`
+
+func newTestFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		"manifest.yaml":          manifestYAML,
+		"project/configs.yaml":   unformattedConfig,
+		"project/zone.json":      `{"name":"{{.name}}"}`,
+		"project/_defaults.yaml": "threshold: 5 # keep",
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+	return fs
+}
+
+func readFile(t *testing.T, fs afero.Fs, path string) string {
+	t.Helper()
+
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestFormat(t *testing.T) {
+	fs := newTestFs(t)
+
+	require.NoError(t, formatProjects(t.Context(), fs, "manifest.yaml", false))
+
+	assert.Equal(t, formattedConfig, readFile(t, fs, "project/configs.yaml"))
+	assert.Equal(t, "{\n  \"name\": \"{{.name}}\"\n}\n", readFile(t, fs, "project/zone.json"))
+	assert.Equal(t, "threshold: 5 # keep", readFile(t, fs, "project/_defaults.yaml"))
+
+	assert.NoError(t, formatProjects(t.Context(), fs, "manifest.yaml", true), "formatted files must pass the check")
+}
+
+func TestFormat_CheckDoesNotChangeFiles(t *testing.T) {
+	fs := newTestFs(t)
+
+	err := formatProjects(t.Context(), fs, "manifest.yaml", true)
+	assert.ErrorContains(t, err, "2 files are not formatted")
+
+	assert.Equal(t, unformattedConfig, readFile(t, fs, "project/configs.yaml"))
+	assert.Equal(t, `{"name":"{{.name}}"}`, readFile(t, fs, "project/zone.json"))
+}
+
+func TestFormat_TemplatesThatAreNotJSONAreSkipped(t *testing.T) {
+	fs := newTestFs(t)
+	template := `[{{range .items}}"{{.}}",{{end}}]`
+	require.NoError(t, afero.WriteFile(fs, "project/zone.json", []byte(template), 0644))
+
+	require.NoError(t, formatProjects(t.Context(), fs, "manifest.yaml", false))
+	assert.Equal(t, template, readFile(t, fs, "project/zone.json"))
+}
+
+func TestFormat_InvalidConfigFileFails(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "project/configs.yaml", []byte("configs:\n- id: zone\n  unknown: key\n"), 0644))
+
+	err := formatProjects(t.Context(), fs, "manifest.yaml", false)
+	assert.ErrorContains(t, err, "failed to format projects")
+}
+
+func TestFormat_AccountResourceFilesAreSkipped(t *testing.T) {
+	fs := newTestFs(t)
+	accounts := "users:\n- {email: monaco@example.com}\n"
+	require.NoError(t, afero.WriteFile(fs, "project/accounts.yaml", []byte(accounts), 0644))
+
+	require.NoError(t, formatProjects(t.Context(), fs, "manifest.yaml", false))
+	assert.Equal(t, accounts, readFile(t, fs, "project/accounts.yaml"))
+}
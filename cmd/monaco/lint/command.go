@@ -0,0 +1,102 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/lint"
+)
+
+const (
+	outputFormatText  = "text"
+	outputFormatJSON  = "json"
+	outputFormatSARIF = "sarif"
+)
+
+var outputFormats = []string{outputFormatText, outputFormatJSON, outputFormatSARIF}
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var projects, rules, disabledRules []string
+	var outputFormat, namingPattern string
+	var maxTemplateSize int
+
+	var ruleDescriptions []string
+	for _, r := range lint.Rules() {
+		ruleDescriptions = append(ruleDescriptions, fmt.Sprintf("  %s: %s", r.ID, r.Description))
+	}
+
+	cmd = &cobra.Command{
+		Use:   "lint <manifest.yaml>",
+		Short: "Check the projects of the manifest for issues that make them harder to maintain",
+		Long: "Lint loads the manifest and all projects without resolving any credentials, and reports issues that do not fail a deployment, " +
+			"but make projects harder to maintain or tie them to a single environment. Findings can be written as SARIF for code scanning tools. " +
+			"The available rules are:\n" + strings.Join(ruleDescriptions, "\n"),
+		Example:           "monaco lint manifest.yaml --disable-rule naming-convention --output-format sarif > monaco.sarif",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			if !slices.Contains(outputFormats, outputFormat) {
+				return fmt.Errorf("unknown output format %q - must be one of [%s]", outputFormat, strings.Join(outputFormats, ", "))
+			}
+
+			opts := lint.Options{Rules: rules, DisabledRules: disabledRules, MaxTemplateSize: maxTemplateSize}
+			if namingPattern != "" {
+				pattern, err := regexp.Compile(namingPattern)
+				if err != nil {
+					return fmt.Errorf("invalid naming pattern %q: %w", namingPattern, err)
+				}
+				opts.NamingPattern = pattern
+			}
+
+			return lintManifest(cmd.Context(), fs, cmd.OutOrStdout(), manifestName, projects, opts, outputFormat)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&projects, "project", "p", []string{}, "Project to lint (also lints any dependent projects)")
+	cmd.Flags().StringSliceVar(&rules, "rule", []string{}, "Only run the given rule(s). To set multiple rules either repeat this flag, or separate them using a comma (,). If not set, all rules are run.")
+	cmd.Flags().StringSliceVar(&disabledRules, "disable-rule", []string{}, "Do not run the given rule(s). To set multiple rules either repeat this flag, or separate them using a comma (,).")
+	cmd.Flags().IntVar(&maxTemplateSize, "max-template-size", lint.DefaultMaxTemplateSize, "Size in bytes above which templates are reported by the 'template-size' rule")
+	cmd.Flags().StringVar(&namingPattern, "naming-pattern", "", "Regular expression all config IDs must match. If not set, the IDs of each project are expected to follow the naming style used by most configs of the project.")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormatText, fmt.Sprintf("Format to report findings in. One of [%s]. JSON and SARIF are written to stdout.", strings.Join(outputFormats, ", ")))
+
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		slog.Error("Failed to set up CLI", log.ErrorAttr(err))
+		os.Exit(1)
+	}
+
+	return cmd
+}
@@ -0,0 +1,108 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/lint"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// Result is the JSON representation of the findings.
+type Result struct {
+	Findings []lint.Finding `json:"findings"`
+}
+
+func lintManifest(ctx context.Context, fs afero.Fs, out io.Writer, manifestPath string, specificProjects []string, opts lint.Options, outputFormat string) error {
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts: manifestloader.Options{
+			DoNotResolveEnvVars:      true,
+			RequireEnvironmentGroups: true,
+		},
+	})
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load manifest %q", manifestPath)
+	}
+
+	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+	}, specificProjects)
+	if len(errs) > 0 {
+		errutils.PrintErrors(errs)
+		return fmt.Errorf("failed to load projects - %d errors occurred", len(errs))
+	}
+
+	findings, err := lint.Lint(projects, opts)
+	if err != nil {
+		return err
+	}
+
+	// templates are loaded relative to the manifest, but findings are reported relative to the working directory
+	for i := range findings {
+		if findings[i].File != "" {
+			findings[i].File = filepath.Join(filepath.Dir(manifestPath), findings[i].File)
+		}
+	}
+
+	if err := writeFindings(out, findings, outputFormat); err != nil {
+		return fmt.Errorf("failed to write lint result: %w", err)
+	}
+
+	if len(findings) > 0 {
+		return fmt.Errorf("lint failed - %d issues found", len(findings))
+	}
+
+	log.InfoContext(ctx, "Lint finished without findings")
+	return nil
+}
+
+func writeFindings(out io.Writer, findings []lint.Finding, outputFormat string) error {
+	var result any
+	switch outputFormat {
+	case outputFormatJSON:
+		result = Result{Findings: append(make([]lint.Finding, 0, len(findings)), findings...)}
+	case outputFormatSARIF:
+		result = lint.ToSARIF(findings)
+	default:
+		for _, f := range findings {
+			log.Warn("%s [%s]: %s", f.Coordinate, f.Rule, f.Message)
+		}
+		return nil
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,125 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/lint"
+)
+
+const manifestYAML = `
+manifestVersion: 1.0
+projects: [{name: project}]
+environmentGroups:
+- name: default
+  environments:
+  - {name: env, url: {type: environment, value: URL_NOT_SET}, auth: {token: {name: TOKEN_NOT_SET}}}
+`
+
+const configYAML = `
+configs:
+- id: zone
+  type: {settings: {schema: builtin:management-zones, scope: environment}}
+  config:
+    name: zone
+    template: zone.json
+- id: profile
+  type: {api: alerting-profile}
+  config:
+    name: profile
+    parameters:
+      zoneId: {type: reference, configType: builtin:management-zones, configId: zone, property: id}
+      unused: value
+    template: profile.json
+`
+
+func newTestFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		"monaco/manifest.yaml":        manifestYAML,
+		"monaco/project/configs.yaml": configYAML,
+		"monaco/project/zone.json":    `{"name": "{{.name}}"}`,
+		"monaco/project/profile.json": `{"name": "{{.name}}", "managementZoneId": "{{.zoneId}}", "host": "HOST-0123456789ABCDEF"}`,
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+	return fs
+}
+
+func TestLint_ReportsFindings(t *testing.T) {
+	fs := newTestFs(t)
+
+	var out bytes.Buffer
+	err := lintManifest(t.Context(), fs, &out, "monaco/manifest.yaml", nil, lint.Options{}, outputFormatJSON)
+	assert.ErrorContains(t, err, "lint failed - 3 issues found")
+
+	var result Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+
+	profile := coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "profile"}
+	file := filepath.Join("monaco", "project", "profile.json")
+	assert.Equal(t, []lint.Finding{
+		{Rule: "deprecated-api", Message: `API "alerting-profile" is deprecated - migrate the config to "builtin:alerting.profile"`, Coordinate: profile, File: file},
+		{Rule: "hardcoded-id", Message: `template contains the hard-coded Dynatrace ID "HOST-0123456789ABCDEF" - use a reference or parameter instead`, Coordinate: profile, File: file},
+		{Rule: "unused-parameter", Message: `parameter "unused" is neither used by the template, nor referenced by any parameter`, Coordinate: profile, File: file},
+	}, result.Findings)
+}
+
+func TestLint_IgnoresUnusedProjectDefaults(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "monaco/project/_defaults.yaml", []byte("parameters:\n  team: platform\n  channel: general"), 0644))
+
+	var out bytes.Buffer
+	err := lintManifest(t.Context(), fs, &out, "monaco/manifest.yaml", nil, lint.Options{Rules: []string{"unused-parameter"}}, outputFormatJSON)
+	assert.ErrorContains(t, err, "lint failed - 1 issues found")
+
+	var result Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, `parameter "unused" is neither used by the template, nor referenced by any parameter`, result.Findings[0].Message)
+}
+
+func TestLint_SelectedRules(t *testing.T) {
+	fs := newTestFs(t)
+
+	var out bytes.Buffer
+	err := lintManifest(t.Context(), fs, &out, "monaco/manifest.yaml", nil, lint.Options{DisabledRules: []string{"deprecated-api", "hardcoded-id", "unused-parameter"}}, outputFormatSARIF)
+	require.NoError(t, err)
+
+	var report lint.SARIF
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	require.Len(t, report.Runs, 1)
+	assert.Empty(t, report.Runs[0].Results)
+}
+
+func TestLint_UnknownRule(t *testing.T) {
+	err := lintManifest(t.Context(), newTestFs(t), &bytes.Buffer{}, "monaco/manifest.yaml", nil, lint.Options{Rules: []string{"unknown"}}, outputFormatText)
+	assert.ErrorContains(t, err, `unknown rule "unknown"`)
+}
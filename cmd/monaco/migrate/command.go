@@ -0,0 +1,100 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/migrate"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var dryRun bool
+	var reportFile string
+
+	cmd = &cobra.Command{
+		Use:   "migrate <manifest.yaml>",
+		Short: "Migrate the manifest and its projects to the latest format",
+		Long: "Migrate rewrites the manifest and all of its projects to the latest format: " +
+			"the 'manifestVersion' is updated and shorthand type definitions of classic APIs are replaced by full ones. " +
+			"Configs of deprecated classic APIs are migrated to their Settings 2.0 equivalent only for APIs with an automatic conversion, which currently are: " + strings.Join(migrate.ConvertibleAPIs(), ", ") + ". " +
+			"References to migrated configs are updated in the names and parameters of all configs and their overrides, and in the settings 'scope' and 'insertAfter' of all configs. " +
+			"References in project defaults files are not updated. " +
+			"Configs of all other deprecated APIs, and everything else that could not be migrated automatically, are listed in the migration report together with what they have to be migrated to. " +
+			"No file is changed if the migration fails.",
+		Example:           "monaco migrate manifest.yaml --dry-run",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			report, err := migrate.Migrate(fs, manifestName, migrate.Options{DryRun: dryRun})
+			if err != nil {
+				return err
+			}
+
+			return writeReport(fs, reportFile, report)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Create the migration report without changing any file")
+	cmd.Flags().StringVar(&reportFile, "report", "migration-report.yaml", "The file the migration report is written to")
+
+	return cmd
+}
+
+func writeReport(fs afero.Fs, reportFile string, report migrate.Report) error {
+	for _, e := range report.Migrated {
+		log.Info("%s: %s", location(e), e.Message)
+	}
+	for _, e := range report.NotMigrated {
+		log.Warn("%s: %s", location(e), e.Message)
+	}
+
+	content, err := yaml.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal migration report: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, reportFile, content, 0644); err != nil {
+		return fmt.Errorf("failed to write migration report %q: %w", reportFile, err)
+	}
+
+	log.Info("Migrated %d items, %d items need to be migrated manually - see the migration report %q for details", len(report.Migrated), len(report.NotMigrated), reportFile)
+	return nil
+}
+
+func location(e migrate.Entry) string {
+	if e.Config != "" {
+		return fmt.Sprintf("%s (%s)", e.File, e.Config)
+	}
+	return e.File
+}
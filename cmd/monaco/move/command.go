@@ -0,0 +1,85 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package move
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/refactor"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var manifestName string
+	var keepPreviousID bool
+
+	cmd = &cobra.Command{
+		Use:   "mv <project>:<type>:<configId> <newId|newProject>",
+		Short: "Rename a config, or move it to another project, and update all references to it",
+		Long: "Mv renames the config with the given coordinate, or moves it to another project of the manifest if the second argument is the name of a project. " +
+			"All references to the config in all projects of the manifest are updated. " +
+			"Configs generated by 'forEach' are moved along with the config declaring it, and references to them are updated as well. " +
+			"Note that configs are identified in Dynatrace by their coordinate, so deploying a moved config creates a new object, " +
+			"unless its previous coordinate is declared in 'previousIds' or 'previousCoordinates' of the config. " +
+			"Use --keep-previous-id to declare it automatically.",
+		Example: "monaco mv infrastructure:builtin:alerting.profile:team-a team-a-alerts --manifest manifest.yaml",
+		Args:    cobra.ExactArgs(2),
+		PreRun:  cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			from, err := parseCoordinate(args[0])
+			if err != nil {
+				return err
+			}
+
+			to, err := refactor.Move(fs, manifestName, from, args[1], refactor.Options{KeepPreviousID: keepPreviousID})
+			if err != nil {
+				return err
+			}
+
+			log.Info("Moved %s to %s", from, to)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&manifestName, "manifest", "m", "manifest.yaml", "The manifest defining the projects. (default: 'manifest.yaml' in the current folder)")
+	cmd.Flags().BoolVar(&keepPreviousID, "keep-previous-id", false, "Add the coordinate the config is moved from to its 'previousIds' or 'previousCoordinates', so that deploying it updates the existing object")
+
+	return cmd
+}
+
+// parseCoordinate parses a coordinate in the format project:type:configId. As types like settings schemas contain
+// colons, the type is everything between the first and the last colon.
+func parseCoordinate(s string) (coordinate.Coordinate, error) {
+	first := strings.Index(s, ":")
+	last := strings.LastIndex(s, ":")
+	if first <= 0 || last == first || last == len(s)-1 || last == first+1 {
+		return coordinate.Coordinate{}, fmt.Errorf("invalid config coordinate %q - expected the format <project>:<type>:<configId>", s)
+	}
+
+	return coordinate.Coordinate{Project: s[:first], Type: s[first+1 : last], ConfigId: s[last+1:]}, nil
+}
@@ -0,0 +1,39 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package move
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+func Test_parseCoordinate(t *testing.T) {
+	c, err := parseCoordinate("project:builtin:alerting.profile:my-profile")
+	require.NoError(t, err)
+	assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "builtin:alerting.profile", ConfigId: "my-profile"}, c)
+
+	for _, invalid := range []string{"", "project", "project:id", ":type:id", "project::id", "project:type:"} {
+		_, err := parseCoordinate(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package packaging
+
+import (
+	"fmt"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/packaging"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var packageVersion, output string
+
+	cmd = &cobra.Command{
+		Use:   "package <manifest.yaml>",
+		Short: "Package the manifest and its projects into a versioned archive",
+		Long: "Package writes the manifest and all files of its projects into a zip archive, together with a lock file holding the version of the package, " +
+			"the monaco version and feature flags it was created with, and the checksum of each file. The SHA-256 checksum of the archive is written next to it. " +
+			"The archive can be deployed with 'monaco deploy <archive.zip>', which verifies it and requires the same monaco version and feature flags, " +
+			"so that the same package can be promoted through all stages.",
+		Example:           "monaco package manifest.yaml --version 1.2.0",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			if packageVersion == "" {
+				return fmt.Errorf("the version of the package must be set using '--version'")
+			}
+
+			if output == "" {
+				output = "package-" + packageVersion + packaging.ArchiveExtension
+			}
+			if !packaging.IsArchive(output) {
+				return fmt.Errorf("wrong format for archive file! Expected a %s file, but got %s", packaging.ArchiveExtension, output)
+			}
+
+			lock, err := packaging.Create(fs, manifestName, packageVersion, output)
+			if err != nil {
+				return err
+			}
+
+			log.InfoContext(cmd.Context(), "Packaged %d files as version %s into %q", len(lock.Files), lock.Version, output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&packageVersion, "version", "", "Version of the package (required)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "The file the archive is written to. Defaults to 'package-<version>.zip'")
+
+	return cmd
+}
@@ -0,0 +1,57 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package packaging
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte("manifestVersion: 1.0\nprojects: [{name: project}]\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "project/config.yaml", []byte("configs: []"), 0644))
+
+	t.Run("version is required", func(t *testing.T) {
+		cmd := Command(fs)
+		cmd.SetArgs([]string{"manifest.yaml"})
+		assert.ErrorContains(t, cmd.Execute(), "'--version'")
+	})
+
+	t.Run("archive is named after the version", func(t *testing.T) {
+		cmd := Command(fs)
+		cmd.SetArgs([]string{"manifest.yaml", "--version", "1.2.0"})
+		require.NoError(t, cmd.Execute())
+
+		for _, f := range []string{"package-1.2.0.zip", "package-1.2.0.zip.sha256"} {
+			exists, err := afero.Exists(fs, f)
+			require.NoError(t, err)
+			assert.True(t, exists, f)
+		}
+	})
+
+	t.Run("output must be an archive", func(t *testing.T) {
+		cmd := Command(fs)
+		cmd.SetArgs([]string{"manifest.yaml", "--version", "1.2.0", "-o", "package.tar"})
+		assert.ErrorContains(t, cmd.Execute(), "Expected a .zip file")
+	})
+}
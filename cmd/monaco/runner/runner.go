@@ -27,9 +27,15 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/delete"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/deploy"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/download"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/format"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/generate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/lint"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/migrate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/move"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/packaging"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/purge"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/supportarchive"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/validate"
 	versionCommand "github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/version"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
@@ -130,6 +136,12 @@ Examples:
 	rootCmd.AddCommand(delete.GetDeleteCommand(fs))
 	rootCmd.AddCommand(versionCommand.GetVersionCommand())
 	rootCmd.AddCommand(generate.Command(fs))
+	rootCmd.AddCommand(validate.Command(fs))
+	rootCmd.AddCommand(lint.Command(fs))
+	rootCmd.AddCommand(migrate.Command(fs))
+	rootCmd.AddCommand(format.Command(fs))
+	rootCmd.AddCommand(move.Command(fs))
+	rootCmd.AddCommand(packaging.Command(fs))
 
 	rootCmd.AddCommand(account.Command(fs))
 
@@ -0,0 +1,96 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/cmdutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/cmd/monaco/completion"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+func Command(fs afero.Fs) (cmd *cobra.Command) {
+	var environments, groups, projects, valuesFiles []string
+	var outputFormat string
+
+	cmd = &cobra.Command{
+		Use:   "validate <manifest.yaml>",
+		Short: "Validate the manifest, projects and configurations without connecting to any environment",
+		Long: "Validate loads the manifest and all projects without resolving any credentials, and runs all checks a deployment runs before sending the first request: " +
+			"duplicate configurations, dependency cycles, reference resolution, and rendering of all templates into valid JSON. " +
+			"References resolve to placeholder IDs, and environment variables that are not set resolve to placeholder values. " +
+			"Templates depending on such placeholders are not checked to be valid JSON.",
+		Example:           "monaco validate manifest.yaml --output-format json",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.SingleArgumentManifestFileCompletion,
+		PreRun:            cmdutils.SilenceUsageCommand(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifestName := args[0]
+
+			if !files.IsYamlFileExtension(manifestName) {
+				return fmt.Errorf("wrong format for manifest file! Expected a .yaml file, but got %s", manifestName)
+			}
+
+			if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+				return fmt.Errorf("unknown output format %q - must be one of [%s, %s]", outputFormat, outputFormatText, outputFormatJSON)
+			}
+
+			return validateManifest(cmd.Context(), fs, cmd.OutOrStdout(), manifestName, groups, environments, projects, valuesFiles, outputFormat)
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&environments, "environment", "e", []string{},
+		"Specify one (or multiple) environment(s) to validate for. "+
+			"To set multiple environments either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--group'. "+
+			"If neither --group nor --environment is present, all environments are used.")
+	cmd.Flags().StringSliceVarP(&groups, "group", "g", []string{},
+		"Specify one (or multiple) environmentGroup(s) to validate for. "+
+			"To set multiple groups either repeat this flag, or separate them using a comma (,). "+
+			"This flag is mutually exclusive with '--environment'.")
+	cmd.Flags().StringSliceVarP(&projects, "project", "p", []string{}, "Project configuration to validate (also validates any dependent configurations)")
+	cmd.Flags().StringArrayVar(&valuesFiles, "values", []string{},
+		"Values file setting parameters, as used by 'deploy --values', so that configurations are validated with the parameters they are deployed with. "+
+			"To use multiple values files repeat this flag, later files take precedence over earlier ones.")
+	cmd.Flags().StringVar(&outputFormat, "output-format", outputFormatText, fmt.Sprintf("Format to report errors in. One of [%s, %s]. JSON is written to stdout.", outputFormatText, outputFormatJSON))
+
+	if err := cmd.RegisterFlagCompletionFunc("environment", completion.EnvironmentByArg0); err != nil {
+		slog.Error("Failed to set up CLI", log.ErrorAttr(err))
+		os.Exit(1)
+	}
+
+	if err := cmd.RegisterFlagCompletionFunc("project", completion.ProjectsFromManifest); err != nil {
+		slog.Error("Failed to set up CLI", log.ErrorAttr(err))
+		os.Exit(1)
+	}
+
+	cmd.MarkFlagsMutuallyExclusive("environment", "group")
+
+	return cmd
+}
@@ -0,0 +1,139 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/errutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/offline"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// Result is the JSON representation of the errors found while validating.
+type Result struct {
+	Errors []Error `json:"errors"`
+}
+
+// Error is a single error found while validating.
+type Error struct {
+	Message string `json:"message"`
+	// Coordinate of the configuration the error belongs to - omitted if the error is not specific to a configuration
+	Coordinate *coordinate.Coordinate `json:"coordinate,omitempty"`
+	// Group of the environment the error belongs to - omitted if the error is not specific to an environment
+	Group string `json:"group,omitempty"`
+	// Environment the error belongs to - omitted if the error is not specific to an environment
+	Environment string `json:"environment,omitempty"`
+}
+
+func validateManifest(ctx context.Context, fs afero.Fs, out io.Writer, manifestPath string, groups []string, environments []string, specificProjects []string, valuesFiles []string, outputFormat string) error {
+	errs := loadAndValidate(ctx, fs, manifestPath, groups, environments, specificProjects, valuesFiles)
+
+	if outputFormat == outputFormatJSON {
+		if err := writeJSON(out, errs); err != nil {
+			return fmt.Errorf("failed to write validation result: %w", err)
+		}
+	} else {
+		errutils.PrintErrors(errs)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("validation failed - %d errors occurred", len(errs))
+	}
+
+	log.InfoContext(ctx, "Validation finished without errors")
+	return nil
+}
+
+// loadAndValidate loads the manifest and its projects, applying the given values files, without resolving any
+// credentials, and returns all errors found while loading or validating them.
+func loadAndValidate(ctx context.Context, fs afero.Fs, manifestPath string, groups []string, environments []string, specificProjects []string, valuesFiles []string) []error {
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Groups:       groups,
+		Environments: environments,
+		Opts: manifestloader.Options{
+			DoNotResolveEnvVars:      true,
+			RequireEnvironmentGroups: true,
+		},
+	})
+	if len(errs) > 0 {
+		return errs
+	}
+
+	values, err := loader.LoadValuesFiles(fs, valuesFiles)
+	if err != nil {
+		return []error{fmt.Errorf("failed to load values files: %w", err)}
+	}
+
+	projects, errs := project.LoadProjects(ctx, fs, project.ProjectLoaderContext{
+		KnownApis:       api.NewAPIs().Filter(api.RemoveDisabled).GetApiNameLookup(),
+		WorkingDir:      filepath.Dir(manifestPath),
+		Manifest:        m,
+		ParametersSerde: config.DefaultParameterParsers,
+		Values:          values,
+	}, specificProjects)
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return offline.Validate(ctx, projects, m.Environments.SelectedEnvironments)
+}
+
+func writeJSON(out io.Writer, errs []error) error {
+	result := Result{Errors: make([]Error, 0, len(errs))}
+	for _, err := range errs {
+		result.Errors = append(result.Errors, toError(err))
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+func toError(err error) Error {
+	result := Error{Message: errutils.ErrorString(err)}
+
+	var configErr configErrors.ConfigError
+	if errors.As(err, &configErr) {
+		c := configErr.Coordinates()
+		result.Coordinate = &c
+	}
+
+	var detailedErr configErrors.DetailedConfigError
+	if errors.As(err, &detailedErr) {
+		result.Group = detailedErr.LocationDetails().Group
+		result.Environment = detailedErr.LocationDetails().Environment
+	}
+
+	return result
+}
@@ -0,0 +1,256 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+const manifestYAML = `
+manifestVersion: 1.0
+projects: [{name: project}]
+environmentGroups:
+- name: default
+  environments:
+  - {name: env, url: {type: environment, value: URL_NOT_SET}, auth: {token: {name: TOKEN_NOT_SET}}}
+`
+
+func newTestFs(t *testing.T, configYAML string, templates map[string]string) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(manifestYAML), 0644))
+	require.NoError(t, afero.WriteFile(fs, "project/configs.yaml", []byte(configYAML), 0644))
+	for name, content := range templates {
+		require.NoError(t, afero.WriteFile(fs, "project/"+name, []byte(content), 0644))
+	}
+	return fs
+}
+
+func TestValidate_SucceedsWithoutCredentials(t *testing.T) {
+	fs := newTestFs(t, `
+configs:
+- id: zone
+  type: {api: management-zone}
+  config:
+    name: {type: environment, name: ZONE_NAME_NOT_SET}
+    template: zone.json
+- id: profile
+  type: {api: alerting-profile}
+  config:
+    name: profile
+    parameters:
+      zoneId: {type: reference, configType: management-zone, configId: zone, property: id}
+    template: profile.json
+`, map[string]string{
+		"zone.json":    `{"name": "{{.name}}"}`,
+		"profile.json": `{"name": "{{.name}}", "managementZoneId": "{{.zoneId}}"}`,
+	})
+
+	var out bytes.Buffer
+	err := validateManifest(t.Context(), fs, &out, "manifest.yaml", nil, nil, nil, nil, outputFormatJSON)
+	require.NoError(t, err)
+
+	var result Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Empty(t, result.Errors)
+}
+
+func TestValidate_ReportsAllErrors(t *testing.T) {
+	fs := newTestFs(t, `
+configs:
+- id: invalid-json
+  type: {api: management-zone}
+  config:
+    name: zone
+    template: invalid.json
+- id: depends-on-invalid
+  type: {api: alerting-profile}
+  config:
+    name: profile
+    parameters:
+      zoneId: {type: reference, configType: management-zone, configId: invalid-json, property: id}
+    template: profile.json
+- id: missing-property
+  type: {api: alerting-profile}
+  config:
+    name: other-profile
+    parameters:
+      zoneId: {type: reference, configType: management-zone, configId: invalid-json, property: doesNotExist}
+    template: profile.json
+    skip: true
+- id: unresolvable
+  type: {api: management-zone}
+  config:
+    name: other-zone
+    parameters:
+      other: {type: reference, configType: alerting-profile, configId: does-not-exist, property: id}
+    template: invalid.json
+`, map[string]string{
+		"invalid.json": `{"name": "{{.name}}",}`,
+		"profile.json": `{"name": "{{.name}}", "managementZoneId": "{{.zoneId}}"}`,
+	})
+
+	var out bytes.Buffer
+	err := validateManifest(t.Context(), fs, &out, "manifest.yaml", nil, nil, nil, nil, outputFormatJSON)
+	assert.ErrorContains(t, err, "validation failed - 2 errors occurred")
+
+	var result Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	require.Len(t, result.Errors, 2)
+
+	var coordinates []coordinate.Coordinate
+	for _, e := range result.Errors {
+		require.NotNil(t, e.Coordinate)
+		assert.Equal(t, "env", e.Environment)
+		assert.Equal(t, "default", e.Group)
+		coordinates = append(coordinates, *e.Coordinate)
+	}
+	assert.ElementsMatch(t, []coordinate.Coordinate{
+		{Project: "project", Type: "management-zone", ConfigId: "invalid-json"},
+		{Project: "project", Type: "management-zone", ConfigId: "unresolvable"},
+	}, coordinates)
+}
+
+func TestValidate_ReportsCycles(t *testing.T) {
+	fs := newTestFs(t, `
+configs:
+- id: a
+  type: {api: management-zone}
+  config:
+    name: {type: reference, configType: management-zone, configId: b, property: name}
+    template: zone.json
+- id: b
+  type: {api: management-zone}
+  config:
+    name: {type: reference, configType: management-zone, configId: a, property: name}
+    template: zone.json
+`, map[string]string{
+		"zone.json": `{"name": "{{.name}}"}`,
+	})
+
+	var out bytes.Buffer
+	err := validateManifest(t.Context(), fs, &out, "manifest.yaml", nil, nil, nil, nil, outputFormatText)
+	assert.ErrorContains(t, err, "validation failed - 1 errors occurred")
+	assert.Empty(t, out.String(), "text output is logged, not written to the output")
+
+	errs := loadAndValidate(t.Context(), fs, "manifest.yaml", nil, nil, nil, nil)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], `failed to sort configs for environment "env"`)
+}
+
+func TestValidate_ReportsLoadingErrors(t *testing.T) {
+	fs := newTestFs(t, `
+configs:
+- id: zone
+  type: {api: management-zone}
+  config:
+    name: zone
+    template: zone.json
+- id: zone
+  type: {api: management-zone}
+  config:
+    name: zone
+    template: zone.json
+`, map[string]string{
+		"zone.json": `{"name": "{{.name}}"}`,
+	})
+
+	errs := loadAndValidate(t.Context(), fs, "manifest.yaml", nil, nil, nil, nil)
+	require.NotEmpty(t, errs)
+	assert.ErrorContains(t, errs[0], "found duplicate `project:management-zone:zone`")
+}
+
+func TestValidate_DoesNotReportErrorsCausedByPlaceholders(t *testing.T) {
+	fs := newTestFs(t, `
+configs:
+- id: untyped
+  type: {api: management-zone}
+  config:
+    name: untyped
+    parameters:
+      threshold: {type: environment, name: THRESHOLD_NOT_SET}
+    template: threshold.json
+- id: typed
+  type: {api: management-zone}
+  parameterSchema:
+    threshold: {type: int, min: 5}
+    enabled: {type: bool}
+    severity: {type: enum, values: [low, high]}
+    region: {type: string, pattern: "^[a-z]{2}-[0-9]$"}
+  config:
+    name: typed
+    parameters:
+      threshold: {type: environment, name: THRESHOLD_NOT_SET}
+      enabled: {type: environment, name: ENABLED_NOT_SET}
+      severity: {type: environment, name: SEVERITY_NOT_SET}
+      region: {type: environment, name: REGION_NOT_SET}
+    template: typed.json
+`, map[string]string{
+		"threshold.json": `{"name": "{{.name}}", "threshold": {{.threshold}}}`,
+		"typed.json":     `{"name": "{{.name}}", "threshold": {{.threshold}}, "enabled": {{.enabled}}, "severity": "{{.severity}}", "region": "{{.region}}"}`,
+	})
+
+	errs := loadAndValidate(t.Context(), fs, "manifest.yaml", nil, nil, nil, nil)
+	assert.Empty(t, errs)
+
+	t.Run("JSON is checked if all variables are set", func(t *testing.T) {
+		t.Setenv("THRESHOLD_NOT_SET", "not a number")
+
+		errs := loadAndValidate(t.Context(), fs, "manifest.yaml", nil, nil, nil, nil)
+		require.Len(t, errs, 2)
+		assert.ErrorContains(t, errors.Join(errs...), `value "not a number" is not an int`)
+	})
+}
+
+func TestValidate_AppliesValuesFiles(t *testing.T) {
+	fs := newTestFs(t, `
+configs:
+- id: zone
+  type: {api: management-zone}
+  config:
+    name: zone
+    parameters:
+      rules: "[]"
+    template: zone.json
+`, map[string]string{
+		"zone.json": `{"name": "{{.name}}", "rules": {{.rules}}}`,
+	})
+	require.NoError(t, afero.WriteFile(fs, "values.yaml", []byte("project:management-zone:zone:\n  rules: not json"), 0644))
+
+	assert.Empty(t, loadAndValidate(t.Context(), fs, "manifest.yaml", nil, nil, nil, nil))
+
+	errs := loadAndValidate(t.Context(), fs, "manifest.yaml", nil, nil, nil, []string{"values.yaml"})
+	require.Len(t, errs, 1)
+	require.NotNil(t, toError(errs[0]).Coordinate)
+	assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "zone"}, *toError(errs[0]).Coordinate)
+
+	errs = loadAndValidate(t.Context(), fs, "manifest.yaml", nil, nil, nil, []string{"does-not-exist.yaml"})
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "failed to load values files")
+}
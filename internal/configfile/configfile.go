@@ -0,0 +1,180 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package configfile reads config files of projects as generic YAML, so that they can be rewritten key by key.
+// Rewritten files keep their structure, but lose their comments.
+package configfile
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/loader"
+)
+
+// File is a YAML file of a project, read as generic YAML.
+type File struct {
+	Path     string
+	Project  string
+	Document yaml.MapSlice
+	// Changed is set once the document has been modified and needs to be written
+	Changed bool
+}
+
+// Configs returns the definitions of all configs of the file.
+func (f *File) Configs() []yaml.MapSlice {
+	v, _ := Get(f.Document, "configs")
+	items, _ := v.([]any)
+
+	var result []yaml.MapSlice
+	for _, item := range items {
+		if c, ok := item.(yaml.MapSlice); ok {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Marshal returns the content of the file.
+func (f *File) Marshal() ([]byte, error) {
+	content, err := yaml.Marshal(f.Document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config file %q: %w", f.Path, err)
+	}
+	return content, nil
+}
+
+// ReadProject reads all config files of the project at the given path. Files that have already been read as part of
+// another project are skipped, as well as the defaults file of the project.
+func ReadProject(fs afero.Fs, path string, project string, seen map[string]struct{}) ([]*File, error) {
+	yamlFiles, err := files.FindYamlFiles(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project %q: %w", project, err)
+	}
+
+	var result []*File
+	for _, file := range yamlFiles {
+		if _, found := seen[file]; found || filepath.Base(file) == loader.DefaultsFileName {
+			continue
+		}
+		seen[file] = struct{}{}
+
+		content, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %q: %w", file, err)
+		}
+
+		var document yaml.MapSlice
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			log.Debug("Skipping file %q, as it is not a config file: %s", file, err)
+			continue
+		}
+
+		if configs, found := Get(document, "configs"); !found {
+			continue
+		} else if _, ok := configs.([]any); !ok {
+			return nil, fmt.Errorf("'configs' of config file %q is not a list", file)
+		}
+
+		result = append(result, &File{Path: file, Project: project, Document: document})
+	}
+	return result, nil
+}
+
+// ReadDefaults reads the defaults file of the project at the given path. If the project does not define defaults, nil
+// is returned.
+func ReadDefaults(fs afero.Fs, path string, project string) (*File, error) {
+	file := filepath.Join(path, loader.DefaultsFileName)
+	if exists, err := afero.Exists(fs, file); err != nil || !exists {
+		return nil, err
+	}
+
+	content, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", file, err)
+	}
+
+	var document yaml.MapSlice
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse defaults file %q: %w", file, err)
+	}
+	return &File{Path: file, Project: project, Document: document}, nil
+}
+
+// DefinitionsOf returns the config definition of the given config, and the definitions of all of its overrides.
+func DefinitionsOf(c yaml.MapSlice) []yaml.MapSlice {
+	var result []yaml.MapSlice
+	if d, ok := GetMapSlice(c, "config"); ok {
+		result = append(result, d)
+	}
+
+	for _, key := range []string{"groupOverrides", "environmentOverrides"} {
+		overrides, _ := Get(c, key)
+		items, _ := overrides.([]any)
+		for _, item := range items {
+			if o, ok := item.(yaml.MapSlice); ok {
+				if d, ok := GetMapSlice(o, "override"); ok {
+					result = append(result, d)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// TemplatesOf returns the distinct template paths of the given config and its overrides.
+func TemplatesOf(c yaml.MapSlice) []string {
+	var result []string
+	for _, d := range DefinitionsOf(c) {
+		if t, found := Get(d, "template"); found && !slices.Contains(result, fmt.Sprint(t)) {
+			result = append(result, fmt.Sprint(t))
+		}
+	}
+	return result
+}
+
+// Get returns the value of the given key.
+func Get(m yaml.MapSlice, key string) (any, bool) {
+	for _, item := range m {
+		if item.Key == key {
+			return item.Value, true
+		}
+	}
+	return nil, false
+}
+
+// GetMapSlice returns the value of the given key, if it is a map.
+func GetMapSlice(m yaml.MapSlice, key string) (yaml.MapSlice, bool) {
+	v, _ := Get(m, key)
+	result, ok := v.(yaml.MapSlice)
+	return result, ok
+}
+
+// Set replaces the value of an existing key.
+func Set(m yaml.MapSlice, key string, value any) {
+	for i := range m {
+		if m[i].Key == key {
+			m[i].Value = value
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configfile
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProject(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		"project/configs.yaml":   "configs:\n- id: a\n  config: {template: a.json}\n",
+		"project/_defaults.yaml": "parameters: {threshold: 5}\n",
+		"project/other.yaml":     "users: []\n",
+		"project/invalid.yaml":   "{",
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+
+	seen := map[string]struct{}{}
+	files, err := ReadProject(fs, "project", "project", seen)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "project/configs.yaml", files[0].Path)
+	assert.Equal(t, "project", files[0].Project)
+	require.Len(t, files[0].Configs(), 1)
+	assert.Equal(t, []string{"a.json"}, TemplatesOf(files[0].Configs()[0]))
+
+	again, err := ReadProject(fs, "project", "other", seen)
+	require.NoError(t, err)
+	assert.Empty(t, again, "files that have already been read are skipped")
+}
+
+func TestReadProject_ConfigsMustBeAList(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "project/configs.yaml", []byte("configs: {id: a}\n"), 0644))
+
+	_, err := ReadProject(fs, "project", "project", map[string]struct{}{})
+	assert.ErrorContains(t, err, "is not a list")
+}
@@ -0,0 +1,138 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package configfile
+
+import (
+	"fmt"
+	"slices"
+
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// referenceFields are the fields of a reference parameter, in the order they are written in.
+var referenceFields = []string{"type", "project", "configType", "configId", "property"}
+
+// ForEachReferenceParameter calls f for each parameter of the given config that may reference another config, with
+// the map holding the parameter and its key. These are the name and parameters of the config and its overrides, and
+// the scope and insertAfter of its type. Compound parameters only refer to other parameters of the same config, so they
+// are not part of them.
+func ForEachReferenceParameter(c yaml.MapSlice, f func(parent yaml.MapSlice, key string)) {
+	for _, d := range DefinitionsOf(c) {
+		f(d, "name")
+		if parameters, ok := GetMapSlice(d, "parameters"); ok {
+			for _, p := range parameters {
+				f(parameters, fmt.Sprint(p.Key))
+			}
+		}
+	}
+
+	if t, ok := GetMapSlice(c, "type"); ok {
+		for _, kind := range []string{"api", "settings"} {
+			if d, ok := GetMapSlice(t, kind); ok {
+				f(d, "scope")
+				f(d, "insertAfter")
+			}
+		}
+	}
+}
+
+// ReferencedCoordinate returns the coordinate referenced by the given parameter, if it is a reference. Fields that
+// are not set default to the coordinate of the config the reference is defined in.
+func ReferencedCoordinate(p any, owner coordinate.Coordinate) (coordinate.Coordinate, bool) {
+	ref := owner
+
+	switch v := p.(type) {
+	case yaml.MapSlice:
+		if t, _ := Get(v, "type"); t != "reference" {
+			return ref, false
+		}
+		if project, found := Get(v, "project"); found {
+			ref.Project = fmt.Sprint(project)
+		}
+		if configType, found := Get(v, "configType"); found {
+			ref.Type = fmt.Sprint(configType)
+		}
+		if configID, found := Get(v, "configId"); found {
+			ref.ConfigId = fmt.Sprint(configID)
+		}
+
+	case []any:
+		// short references are [property], [configId, property], [configType, configId, property] or
+		// [project, configType, configId, property]
+		if len(v) == 0 || len(v) > 4 {
+			return ref, false
+		}
+		fields := []*string{&ref.Project, &ref.Type, &ref.ConfigId}
+		for i, value := range v[:len(v)-1] {
+			*fields[4-len(v)+i] = fmt.Sprint(value)
+		}
+
+	default:
+		return ref, false
+	}
+
+	return ref, true
+}
+
+// RewriteReference returns the given reference parameter rewritten to reference ref from a config with the
+// coordinate owner. Fields that are already set are kept, missing fields are only added if they are needed.
+func RewriteReference(p any, ref coordinate.Coordinate, owner coordinate.Coordinate) any {
+	needsProject := ref.Project != owner.Project
+	needsType := needsProject || ref.Type != owner.Type
+	needsID := needsType || ref.ConfigId != owner.ConfigId
+
+	switch v := p.(type) {
+	case yaml.MapSlice:
+		values := map[string]string{"project": ref.Project, "configType": ref.Type, "configId": ref.ConfigId}
+		needed := map[string]bool{"project": needsProject, "configType": needsType, "configId": needsID}
+
+		var result yaml.MapSlice
+		for _, key := range referenceFields {
+			current, found := Get(v, key)
+			if value, isCoordinateField := values[key]; isCoordinateField {
+				if found || needed[key] {
+					result = append(result, yaml.MapItem{Key: key, Value: value})
+				}
+			} else if found {
+				result = append(result, yaml.MapItem{Key: key, Value: current})
+			}
+		}
+		for _, item := range v {
+			if !slices.Contains(referenceFields, fmt.Sprint(item.Key)) {
+				result = append(result, item)
+			}
+		}
+		return result
+
+	case []any:
+		length := len(v)
+		switch {
+		case needsProject:
+			length = 4
+		case needsType:
+			length = max(length, 3)
+		case needsID:
+			length = max(length, 2)
+		}
+		full := []any{ref.Project, ref.Type, ref.ConfigId, v[len(v)-1]}
+		return full[4-length:]
+	}
+
+	return p
+}
@@ -92,3 +92,12 @@ func TestFeatureFlagID_Enabled(t *testing.T) {
 		})
 	})
 }
+
+func TestStates(t *testing.T) {
+	t.Setenv(featureflags.DangerousCommands.EnvName(), "true")
+	t.Setenv(featureflags.SanitizeBucketNames.EnvName(), "false")
+
+	states := featureflags.States()
+	assert.True(t, states[featureflags.DangerousCommands.EnvName()])
+	assert.False(t, states[featureflags.SanitizeBucketNames.EnvName()])
+}
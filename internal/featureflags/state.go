@@ -70,3 +70,14 @@ func makeFeatureFlagTableString(featureFlags map[FeatureFlag]defaultValue) strin
 	}
 	return s.String()
 }
+
+// States returns the current value of all feature flags, keyed by the name of their environment variable.
+func States() map[string]bool {
+	result := make(map[string]bool, len(permanentDefaultValues)+len(temporaryDefaultValues))
+	for _, flags := range []map[FeatureFlag]defaultValue{permanentDefaultValues, temporaryDefaultValues} {
+		for ff := range flags {
+			result[ff.EnvName()] = ff.Enabled()
+		}
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package idutils
+
+import "regexp"
+
+// meIDRegexPattern matching a Dynatrace Monitored Entity ID which consists of a type containing characters and
+// underscores, a dash separator '-' and 16 hex numbers
+var meIDRegexPattern = regexp.MustCompile(`[a-zA-Z_]+-[A-Fa-f0-9]{16}`)
+
+var uuidRegexPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// invalidMeId finds ME IDs in the form of `nABC`, `rABC`, and `tABC`. They are most commonly mistakenly created by `\nABC`.
+var invalidMeId = regexp.MustCompile("[nrt][A-Z]+")
+
+// FindIDs returns all Dynatrace IDs - Monitored Entity IDs and UUIDs - found in the given content, in the order of
+// their kind and occurrence. IDs found several times are returned several times.
+func FindIDs(content string) []string {
+	ids := meIDRegexPattern.FindAllString(content, -1)
+
+	// Super simple, hacky, sanity check to not accidentally include matches for ME IDs in the form of `nABC-123` which result from `\nABC-123`.
+	// Alternatives to the check is validating the content of matches for `\`, but a `\` can also be escaped and would require recursive checking.
+	// To not do that, we simply check if the id starts with `n`, `r`, `t` (from `\n`, `\r`, `\t`), and then only uppercase characters. This is a good check for potential issues.
+	for i, id := range ids {
+		if invalidMeId.MatchString(id) {
+			ids[i] = id[1:]
+		}
+	}
+
+	ids = append(ids, uuidRegexPattern.FindAllString(content, -1)...)
+
+	return ids
+}
@@ -0,0 +1,81 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package idutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindIDs(t *testing.T) {
+
+	tc := []struct {
+		in          string
+		expectedIds []string
+	}{
+		{"", nil},
+		{
+			"HOST-0123456789ABCDEF",
+			[]string{"HOST-0123456789ABCDEF"},
+		},
+		{
+			"f1614cf1-4f6e-4187-b303-af4beb42268c",
+			[]string{"f1614cf1-4f6e-4187-b303-af4beb42268c"},
+		},
+		{
+			`{"HOST": "HOST-0123456789ABCDEF", "id": "f1614cf1-4f6e-4187-b303-af4beb42268c"}`,
+			[]string{"f1614cf1-4f6e-4187-b303-af4beb42268c", "HOST-0123456789ABCDEF"},
+		},
+		{
+			"HELLO-Imnotanentityidbutstilliwasdetectedassuch",
+			nil,
+		},
+		{
+			`\nABC-0000000000000000`,
+			[]string{"ABC-0000000000000000"},
+		},
+		{
+			`\rABC-0000000000000000`,
+			[]string{"ABC-0000000000000000"},
+		},
+		{
+			`\tABC-0000000000000000`,
+			[]string{"ABC-0000000000000000"},
+		},
+		{
+			`\\tABC-0000000000000000`,
+			[]string{"ABC-0000000000000000"},
+		},
+		{
+			`\nf1614cf1-4f6e-4187-b303-af4beb42268c`,
+			[]string{"f1614cf1-4f6e-4187-b303-af4beb42268c"},
+		},
+	}
+
+	for _, tt := range tc {
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+
+			foundIds := FindIDs(tt.in)
+			assert.ElementsMatch(t, tt.expectedIds, foundIds)
+		})
+	}
+
+}
@@ -0,0 +1,368 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Operations of a JSON Patch as defined in RFC 6902
+const (
+	PatchOperationAdd     = "add"
+	PatchOperationRemove  = "remove"
+	PatchOperationReplace = "replace"
+	PatchOperationMove    = "move"
+	PatchOperationCopy    = "copy"
+	PatchOperationTest    = "test"
+)
+
+// PatchOperations are all operations supported in a JSON Patch
+var PatchOperations = []string{PatchOperationAdd, PatchOperationRemove, PatchOperationReplace, PatchOperationMove, PatchOperationCopy, PatchOperationTest}
+
+// PatchOperation is a single operation of a JSON Patch (RFC 6902). Path and From are JSON Pointers (RFC 6901).
+type PatchOperation struct {
+	Op    string
+	Path  string
+	From  string
+	Value any
+}
+
+// Validate checks whether the operation is supported and its pointers are valid.
+func (o PatchOperation) Validate() error {
+	if !slices.Contains(PatchOperations, o.Op) {
+		return fmt.Errorf("unknown operation %q. Allowed operations: %v", o.Op, PatchOperations)
+	}
+
+	if _, err := parsePointer(o.Path); err != nil {
+		return err
+	}
+
+	if o.Op == PatchOperationMove || o.Op == PatchOperationCopy {
+		if _, err := parsePointer(o.From); err != nil {
+			return fmt.Errorf("invalid 'from': %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) to the given decoded JSON document and returns the result.
+// The document may be modified in place.
+func ApplyMergePatch(doc any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return deepCopy(patch)
+	}
+
+	docObject, ok := doc.(map[string]any)
+	if !ok {
+		docObject = make(map[string]any, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(docObject, key)
+			continue
+		}
+		docObject[key] = ApplyMergePatch(docObject[key], value)
+	}
+
+	return docObject
+}
+
+// ApplyJSONPatch applies the operations of a JSON Patch (RFC 6902) to the given decoded JSON document and returns the
+// result. The document may be modified in place. If any operation fails, an error is returned.
+func ApplyJSONPatch(doc any, operations []PatchOperation) (any, error) {
+	for i, o := range operations {
+		var err error
+		if doc, err = o.apply(doc); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %q) failed: %w", i, o.Op, o.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func (o PatchOperation) apply(doc any) (any, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case PatchOperationAdd:
+		return add(doc, path, deepCopy(o.Value))
+
+	case PatchOperationRemove:
+		doc, _, err = remove(doc, path)
+		return doc, err
+
+	case PatchOperationReplace:
+		if len(path) == 0 {
+			return deepCopy(o.Value), nil
+		}
+		if doc, _, err = remove(doc, path); err != nil {
+			return nil, err
+		}
+		return add(doc, path, deepCopy(o.Value))
+
+	case PatchOperationMove, PatchOperationCopy:
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'from': %w", err)
+		}
+
+		var value any
+		if o.Op == PatchOperationMove {
+			if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+				return nil, errors.New("cannot move a value into one of its children")
+			}
+			doc, value, err = remove(doc, from)
+		} else {
+			value, err = get(doc, from)
+			value = deepCopy(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+
+	case PatchOperationTest:
+		value, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, o.Value) {
+			return nil, fmt.Errorf("value is %v, not %v", value, o.Value)
+		}
+		return doc, nil
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", o.Op)
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must be empty or start with '/'", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	for _, token := range path {
+		switch c := doc.(type) {
+		case map[string]any:
+			value, found := c[token]
+			if !found {
+				return nil, fmt.Errorf("member %q does not exist", token)
+			}
+			doc = value
+		case []any:
+			i, err := arrayIndex(token, len(c)-1)
+			if err != nil {
+				return nil, err
+			}
+			doc = c[i]
+		default:
+			return nil, fmt.Errorf("cannot access %q of a value that is neither an object nor an array", token)
+		}
+	}
+	return doc, nil
+}
+
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	if len(path) > 1 {
+		return updateChild(doc, path[0], func(child any) (any, error) {
+			return add(child, path[1:], value)
+		})
+	}
+
+	switch c := doc.(type) {
+	case map[string]any:
+		c[path[0]] = value
+		return c, nil
+	case []any:
+		if path[0] == "-" {
+			return append(c, value), nil
+		}
+		i, err := arrayIndex(path[0], len(c))
+		if err != nil {
+			return nil, err
+		}
+		return slices.Insert(c, i, value), nil
+	default:
+		return nil, fmt.Errorf("cannot add %q to a value that is neither an object nor an array", path[0])
+	}
+}
+
+func remove(doc any, path []string) (result any, removed any, err error) {
+	if len(path) == 0 {
+		return nil, nil, errors.New("cannot remove the whole document")
+	}
+
+	if len(path) > 1 {
+		result, err = updateChild(doc, path[0], func(child any) (any, error) {
+			var childResult any
+			childResult, removed, err = remove(child, path[1:])
+			return childResult, err
+		})
+		return result, removed, err
+	}
+
+	switch c := doc.(type) {
+	case map[string]any:
+		value, found := c[path[0]]
+		if !found {
+			return nil, nil, fmt.Errorf("member %q does not exist", path[0])
+		}
+		delete(c, path[0])
+		return c, value, nil
+	case []any:
+		i, err := arrayIndex(path[0], len(c)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := c[i]
+		return slices.Delete(c, i, i+1), value, nil
+	default:
+		return nil, nil, fmt.Errorf("cannot remove %q from a value that is neither an object nor an array", path[0])
+	}
+}
+
+// updateChild replaces the child of the given object or array with the result of the update function.
+func updateChild(doc any, token string, update func(child any) (any, error)) (any, error) {
+	switch c := doc.(type) {
+	case map[string]any:
+		child, found := c[token]
+		if !found {
+			return nil, fmt.Errorf("member %q does not exist", token)
+		}
+		updated, err := update(child)
+		if err != nil {
+			return nil, err
+		}
+		c[token] = updated
+		return c, nil
+	case []any:
+		i, err := arrayIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := update(c[i])
+		if err != nil {
+			return nil, err
+		}
+		c[i] = updated
+		return c, nil
+	default:
+		return nil, fmt.Errorf("cannot access %q of a value that is neither an object nor an array", token)
+	}
+}
+
+// arrayIndex parses the given reference token as an index into an array, which must not be greater than maxIndex.
+func arrayIndex(token string, maxIndex int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+	if i > maxIndex {
+		return 0, fmt.Errorf("array index %d is out of bounds", i)
+	}
+	return i, nil
+}
+
+// equal returns whether the given decoded JSON values are equal. Numbers are compared by their value, independent of
+// whether they were decoded as json.Number or float64.
+func equal(a, b any) bool {
+	if aNum, ok := toFloat(a); ok {
+		bNum, ok := toFloat(b)
+		return ok && aNum == bNum
+	}
+
+	switch aVal := a.(type) {
+	case map[string]any:
+		bVal, ok := b.(map[string]any)
+		if !ok || len(aVal) != len(bVal) {
+			return false
+		}
+		for k, v := range aVal {
+			if w, found := bVal[k]; !found || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bVal, ok := b.([]any)
+		return ok && slices.EqualFunc(aVal, bVal, equal)
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// deepCopy copies the given decoded JSON value, so that patches applied to different documents do not share state.
+func deepCopy(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(val))
+		for k, e := range val {
+			result[k] = deepCopy(e)
+		}
+		return result
+	case []any:
+		result := make([]any, len(val))
+		for i, e := range val {
+			result[i] = deepCopy(e)
+		}
+		return result
+	default:
+		return val
+	}
+}
@@ -0,0 +1,141 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"adds and replaces members", `{"a": 1, "b": {"c": 2}}`, `{"a": 3, "b": {"d": 4}}`, `{"a": 3, "b": {"c": 2, "d": 4}}`},
+		{"removes members set to null", `{"a": 1, "b": 2}`, `{"a": null}`, `{"b": 2}`},
+		{"replaces arrays", `{"a": [1, 2]}`, `{"a": [3]}`, `{"a": [3]}`},
+		{"replaces non-objects", `{"a": "text"}`, `{"a": {"b": 1}}`, `{"a": {"b": 1}}`},
+		{"replaces the document with a non-object patch", `{"a": 1}`, `[1]`, `[1]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ApplyMergePatch(decode(t, tt.doc), decode(t, tt.patch))
+			assert.Equal(t, decode(t, tt.want), got)
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		doc        string
+		operations []PatchOperation
+		want       string
+		wantErr    string
+	}{
+		{
+			name:       "adds to object and array",
+			doc:        `{"tiles": [{"id": 1}, {"id": 3}]}`,
+			operations: []PatchOperation{{Op: "add", Path: "/tiles/1", Value: map[string]any{"id": 2}}, {Op: "add", Path: "/tiles/-", Value: map[string]any{"id": 4}}, {Op: "add", Path: "/name", Value: "board"}},
+			want:       `{"name": "board", "tiles": [{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}]}`,
+		},
+		{
+			name:       "removes and replaces",
+			doc:        `{"rules": ["a", "b", "c"], "enabled": true}`,
+			operations: []PatchOperation{{Op: "remove", Path: "/rules/1"}, {Op: "replace", Path: "/enabled", Value: false}},
+			want:       `{"rules": ["a", "c"], "enabled": false}`,
+		},
+		{
+			name:       "moves and copies",
+			doc:        `{"a": {"b": 1}, "c": []}`,
+			operations: []PatchOperation{{Op: "copy", From: "/a/b", Path: "/c/0"}, {Op: "move", From: "/a", Path: "/d"}},
+			want:       `{"c": [1], "d": {"b": 1}}`,
+		},
+		{
+			name:       "unescapes pointers",
+			doc:        `{"a/b": {"~c": 1}}`,
+			operations: []PatchOperation{{Op: "replace", Path: "/a~1b/~0c", Value: 2}},
+			want:       `{"a/b": {"~c": 2}}`,
+		},
+		{
+			name:       "passes tests",
+			doc:        `{"a": [1, {"b": "c"}]}`,
+			operations: []PatchOperation{{Op: "test", Path: "/a", Value: []any{1, map[string]any{"b": "c"}}}},
+			want:       `{"a": [1, {"b": "c"}]}`,
+		},
+		{
+			name:       "fails failing tests",
+			doc:        `{"a": 1}`,
+			operations: []PatchOperation{{Op: "test", Path: "/a", Value: 2}},
+			wantErr:    `operation 0 (test "/a") failed: value is 1, not 2`,
+		},
+		{
+			name:       "fails to remove missing member",
+			doc:        `{"a": 1}`,
+			operations: []PatchOperation{{Op: "add", Path: "/b", Value: 2}, {Op: "remove", Path: "/c"}},
+			wantErr:    `operation 1 (remove "/c") failed: member "c" does not exist`,
+		},
+		{
+			name:       "fails on out of bounds index",
+			doc:        `{"a": [1]}`,
+			operations: []PatchOperation{{Op: "replace", Path: "/a/1", Value: 2}},
+			wantErr:    "array index 1 is out of bounds",
+		},
+		{
+			name:       "fails to move into own child",
+			doc:        `{"a": {"b": 1}}`,
+			operations: []PatchOperation{{Op: "move", From: "/a", Path: "/a/c"}},
+			wantErr:    "cannot move a value into one of its children",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyJSONPatch(decode(t, tt.doc), tt.operations)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, equal(decode(t, tt.want), got), "got %v", got)
+		})
+	}
+}
+
+func TestPatchOperation_Validate(t *testing.T) {
+	assert.NoError(t, PatchOperation{Op: "add", Path: "/a"}.Validate())
+	assert.NoError(t, PatchOperation{Op: "move", Path: "", From: "/a"}.Validate())
+	assert.ErrorContains(t, PatchOperation{Op: "merge", Path: "/a"}.Validate(), `unknown operation "merge"`)
+	assert.ErrorContains(t, PatchOperation{Op: "add", Path: "a"}.Validate(), "must be empty or start with '/'")
+	assert.ErrorContains(t, PatchOperation{Op: "copy", Path: "/a", From: "b"}.Validate(), "invalid 'from'")
+}
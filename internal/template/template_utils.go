@@ -19,9 +19,11 @@ package template
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"reflect"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/maps"
 )
 
 func escapeSpecialCharactersInMap(properties map[string]any, escapeFunc StringEscapeFunction) (map[string]any, error) {
@@ -52,12 +54,30 @@ func EscapeSpecialCharactersInValue(value any, escapeFunc StringEscapeFunction)
 		return escapeCharactersForStringMap(field, escapeFunc)
 	case map[string]any:
 		return escapeSpecialCharactersInMap(field, escapeFunc)
+	case map[any]any:
+		return escapeSpecialCharactersInMap(maps.ToStringMap(field), escapeFunc)
+	case []any:
+		return escapeSpecialCharactersInSlice(field, escapeFunc)
 	default:
 		log.Debug("tried to string escape value of unsupported type %v, returning unchanged", reflect.TypeOf(value))
 		return value, nil
 	}
 }
 
+func escapeSpecialCharactersInSlice(values []any, escapeFunc StringEscapeFunction) ([]any, error) {
+	escapedValues := make([]any, len(values))
+
+	for i, value := range values {
+		escaped, err := EscapeSpecialCharactersInValue(value, escapeFunc)
+		if err != nil {
+			return nil, err
+		}
+		escapedValues[i] = escaped
+	}
+
+	return escapedValues, nil
+}
+
 func escapeCharactersForStringMap(properties map[string]string, escapeFunc StringEscapeFunction) (map[string]string, error) {
 	escapedProperties := make(map[string]string, len(properties))
 
@@ -93,6 +113,16 @@ func escapeCharactersForJson(rawString string) (string, error) {
 	return s, nil
 }
 
+// UnescapeJSONString reverses the escaping of FullStringEscapeFunction, returning the raw string an escaped string
+// represents.
+func UnescapeJSONString(escaped string) (string, error) {
+	var raw string
+	if err := json.Unmarshal([]byte(`"`+escaped+`"`), &raw); err != nil {
+		return "", fmt.Errorf("value %q is not escaped for use in a JSON string: %w", escaped, err)
+	}
+	return raw, nil
+}
+
 // marshalWithoutEscapeHTML works the same way as json.Marshal, with the exception that HTML entities (<, >, &) are
 // NOT escaped.
 func marshalWithoutEscapeHTML(v any) ([]byte, error) {
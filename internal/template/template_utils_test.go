@@ -87,3 +87,39 @@ func Test_escapeCharactersForJson(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeSpecialCharactersInValue_EscapesNestedValues(t *testing.T) {
+	given := map[string]any{
+		"text": `a "quoted" word`,
+		"list": []any{
+			"line\nbreak",
+			map[any]any{"nested": `back\slash`},
+			42,
+		},
+	}
+
+	got, err := EscapeSpecialCharactersInValue(given, FullStringEscapeFunction)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"text": `a \"quoted\" word`,
+		"list": []any{
+			`line\nbreak`,
+			map[string]any{"nested": `back\\slash`},
+			42,
+		},
+	}, got)
+}
+
+func TestUnescapeJSONString(t *testing.T) {
+	for _, raw := range []string{"", "plain", `say "hi"`, `C:\temp`, "line\nbreak\ttab", "<html> & ü"} {
+		escaped, err := FullStringEscapeFunction(raw)
+		assert.NoError(t, err)
+
+		got, err := UnescapeJSONString(escaped)
+		assert.NoError(t, err)
+		assert.Equal(t, raw, got)
+	}
+
+	_, err := UnescapeJSONString(`unescaped "quote"`)
+	assert.Error(t, err)
+}
@@ -18,11 +18,14 @@ package zip
 
 import (
 	"archive/zip"
+	"bytes"
 	"fmt"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/multierror"
 	"github.com/spf13/afero"
 	"io"
+	"maps"
 	"path/filepath"
+	"slices"
 )
 
 func Create(fs afero.Fs, zipFileName string, files []string, preservePath bool) error {
@@ -79,3 +82,66 @@ func addFileToZip(fs afero.Fs, zipWriter *zip.Writer, file string, preservePath
 
 	return nil
 }
+
+// CreateReproducible creates a zip archive holding the given files, keyed by their name within the archive. Entries are
+// written in the order of their names and without modification times, so that the same files always result in the
+// same archive.
+func CreateReproducible(fs afero.Fs, zipFileName string, files map[string][]byte) error {
+	zipFile, err := fs.Create(zipFileName)
+	if err != nil {
+		return err
+	}
+	defer zipFile.Close()
+
+	zipWriter := zip.NewWriter(zipFile)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		w, err := zipWriter.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if err != nil {
+			return fmt.Errorf("unable to add %s file to archive %s: %w", name, zipFileName, err)
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return fmt.Errorf("unable to add %s file to archive %s: %w", name, zipFileName, err)
+		}
+	}
+	return zipWriter.Close()
+}
+
+// ReadAll returns the content of all files of the given zip archive, keyed by their name within the archive.
+// Archives containing entries that would be located outside a directory the archive is extracted to are rejected.
+func ReadAll(fs afero.Fs, zipFileName string) (map[string][]byte, error) {
+	content, err := afero.ReadFile(fs, zipFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", zipFileName, err)
+	}
+
+	result := make(map[string][]byte, len(zipReader.File))
+	for _, f := range zipReader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if !filepath.IsLocal(filepath.FromSlash(f.Name)) {
+			return nil, fmt.Errorf("archive %s contains file %q outside of the archive", zipFileName, f.Name)
+		}
+
+		data, err := readFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from archive %s: %w", f.Name, zipFileName, err)
+		}
+		result[f.Name] = data
+	}
+	return result, nil
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
@@ -98,3 +98,29 @@ func TestCreatePartial(t *testing.T) {
 	assert.True(t, foundFiles["exists.txt"], "Expected file '%s' in zip archive", "exists.txt")
 	assert.False(t, foundFiles["does-not-exist.txt"], "Expected file '%s' not to be in zip archive", "does-not-exist.txt")
 }
+
+func TestCreateReproducible(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	files := map[string][]byte{"b/file.txt": []byte("b"), "a.txt": []byte("a")}
+
+	assert.NoError(t, CreateReproducible(fs, "first.zip", files))
+	assert.NoError(t, CreateReproducible(fs, "second.zip", files))
+
+	first, err := afero.ReadFile(fs, "first.zip")
+	assert.NoError(t, err)
+	second, err := afero.ReadFile(fs, "second.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, first, second, "Expected identical archives for identical files")
+
+	content, err := ReadAll(fs, "first.zip")
+	assert.NoError(t, err)
+	assert.Equal(t, files, content)
+}
+
+func TestReadAll_RejectsFilesOutsideOfArchive(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, CreateReproducible(fs, "test.zip", map[string][]byte{"../escape.txt": []byte("x")}))
+
+	_, err := ReadAll(fs, "test.zip")
+	assert.ErrorContains(t, err, `contains file "../escape.txt" outside of the archive`)
+}
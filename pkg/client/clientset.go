@@ -201,6 +201,11 @@ var DefaultRetryOptions = rest.RetryOptions{MaxRetries: 10, DelayAfterRetry: tim
 // Each field may be nil, if the ClientSet is partially initialized - e.g. no autClient will be part of a ClientSet
 // created for a 'classic' Dynatrace environment, as Automations are a Platform feature
 type ClientSet struct {
+	// PlatformURL is the URL of the platform environment, or empty if no platform credentials are given
+	PlatformURL string
+	// ClassicURL is the URL the classic APIs are accessed at, which is queried from platform environments
+	ClassicURL string
+
 	ConfigClient                ConfigClient
 	SettingsClient              SettingsClient
 	AutClient                   AutomationClient
@@ -214,6 +219,8 @@ type ClientSet struct {
 type ClientOptions struct {
 	CustomUserAgent string
 	CachingDisabled bool
+	// ConcurrentRequestLimit overrides the limit of concurrent requests defined via environment variable, if greater than 0
+	ConcurrentRequestLimit int
 }
 
 func (o ClientOptions) getUserAgentString() string {
@@ -248,8 +255,19 @@ func CreateClientSet(ctx context.Context, url string, auth manifest.Auth) (*Clie
 	return CreateClientSetWithOptions(ctx, url, auth, ClientOptions{})
 }
 
+// CreateClientSetForEnvironment creates the clients for the given environment, using its connection settings and limits.
+func CreateClientSetForEnvironment(ctx context.Context, env manifest.EnvironmentDefinition) (*ClientSet, error) {
+	ctx, err := ContextWithConnection(ctx, env.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up connection to environment %q: %w", env.Name, err)
+	}
+	ctx = contextWithRequestsPerSecond(ctx, env.Limits.RequestsPerSecond)
+
+	return CreateClientSetWithOptions(ctx, env.URL.Value, env.Auth, ClientOptions{ConcurrentRequestLimit: env.Limits.ConcurrentRequests})
+}
+
 // CreateClientSetWithOptions creates different kinds of clients depending on the set auth.
-// Note: The HTTP client used behind is set/overwritten in main.go
+// Note: The HTTP client used behind is set/overwritten in main.go, or per environment using ContextWithConnection
 func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.Auth, opts ClientOptions) (*ClientSet, error) {
 	var (
 		configClient                ConfigClient
@@ -266,8 +284,11 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		return nil, err
 	}
 
-	concurrentReqLimit := environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
-	additionalHeaders := environment.GetAdditionalHTTPHeadersFromEnv()
+	concurrentReqLimit := opts.ConcurrentRequestLimit
+	if concurrentReqLimit <= 0 {
+		concurrentReqLimit = environment.GetEnvValueIntLog(environment.ConcurrentRequestsEnvKey)
+	}
+	additionalHeaders := AdditionalHTTPHeaders(ctx)
 	cFactory := clients.Factory().
 		WithConcurrentRequestLimit(concurrentReqLimit).
 		WithUserAgent(opts.getUserAgentString()).
@@ -365,7 +386,14 @@ func CreateClientSetWithOptions(ctx context.Context, url string, auth manifest.A
 		}
 	}
 
+	var platformURL string
+	if platformCredentialsGiven {
+		platformURL = url
+	}
+
 	return &ClientSet{
+		PlatformURL:                 platformURL,
+		ClassicURL:                  classicURL,
 		ConfigClient:                configClient,
 		SettingsClient:              settingsClient,
 		AutClient:                   autClient,
@@ -223,6 +223,9 @@ type (
 		Content []byte
 		// OriginObjectId is the object id of the Settings object when it was downloaded from an environment
 		OriginObjectId string
+		// PreviousCoordinates are coordinates the object was known by before. Objects with external IDs generated
+		// for these coordinates are updated instead of creating new ones.
+		PreviousCoordinates []coordinate.Coordinate
 	}
 
 	Schema struct {
@@ -435,7 +438,8 @@ func (d *SettingsClient) GetSchema(ctx context.Context, schemaID string) (constr
 // Upsert creates or updates remote settings objects.
 // The logic to find the correct object to update is as follows:
 //  1. We try to match the unique-constrains of the object
-//  2. We try to find the correct object by checking the legacy-external-id, the external-id, as well as the given originObjectId
+//  2. We try to find the correct object by checking the legacy-external-id, the external-ids of previous coordinates,
+//     the external-id, as well as the given originObjectId
 //
 // If we find an object, we update it. If we don't, a new one will be created.
 func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertOptions UpsertSettingsOptions) (result DynatraceEntity, err error) {
@@ -475,6 +479,12 @@ func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertO
 		remoteObjectId = settingsWithExternalID[0].ObjectId
 	}
 
+	if previousObjectId, found, err := d.findObjectOfPreviousCoordinates(ctx, obj); err != nil {
+		return DynatraceEntity{}, err
+	} else if found {
+		remoteObjectId = previousObjectId
+	}
+
 	externalID, err := d.generateExternalID(obj.Coordinate)
 	if err != nil {
 		return DynatraceEntity{}, fmt.Errorf("unable to generate external id: %w", err)
@@ -565,6 +575,30 @@ func (d *SettingsClient) Upsert(ctx context.Context, obj SettingsObject, upsertO
 	return entity, nil
 }
 
+// findObjectOfPreviousCoordinates returns the ID of the first object with an external ID generated for one of the
+// previous coordinates of the given object.
+func (d *SettingsClient) findObjectOfPreviousCoordinates(ctx context.Context, obj SettingsObject) (string, bool, error) {
+	for _, previous := range obj.PreviousCoordinates {
+		previousExternalID, err := d.generateExternalID(previous)
+		if err != nil {
+			return "", false, fmt.Errorf("unable to generate external id: %w", err)
+		}
+
+		settings, err := d.List(ctx, obj.SchemaId, ListSettingsOptions{
+			Filter: func(object DownloadSettingsObject) bool { return object.ExternalId == previousExternalID },
+		})
+		if err != nil {
+			return "", false, err
+		}
+
+		if len(settings) > 0 {
+			log.DebugContext(ctx, "Updating existing object %q of previous coordinate %s", settings[0].ObjectId, previous)
+			return settings[0].ObjectId, true, nil
+		}
+	}
+	return "", false, nil
+}
+
 // modifyPermission creates, updates or deletes the all-user permission of a given settings object
 func (d *SettingsClient) modifyPermission(ctx context.Context, objectID string, allUserPermission config.AllUserPermissionKind) error {
 	permissions := getPermissionsFromConfig(allUserPermission)
@@ -1350,6 +1350,60 @@ func TestUpsertSettingsFromCache(t *testing.T) {
 	assert.Equal(t, 2, numAPIPostCalls)
 }
 
+func TestUpsertSettings_AdoptsObjectOfPreviousCoordinate(t *testing.T) {
+	previous := coordinate.Coordinate{Project: "old-project", Type: "some:schema", ConfigId: "old-id"}
+	previousExternalID, err := idutils.GenerateExternalIDForSettingsObject(previous)
+	require.NoError(t, err)
+
+	current := coordinate.Coordinate{Project: "project", Type: "some:schema", ConfigId: "id"}
+	externalID, err := idutils.GenerateExternalIDForSettingsObject(current)
+	require.NoError(t, err)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == settingsSchemaAPIPathClassic+"/some:schema" {
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte("{}"))
+			return
+		}
+		if req.Method == http.MethodGet {
+			rw.WriteHeader(http.StatusOK)
+			rw.Write([]byte(fmt.Sprintf(`{"items":[{"externalId":"%s","objectId":"previous-object","scope":"environment"}]}`, previousExternalID)))
+			return
+		}
+
+		var obj []settingsRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&obj))
+		require.Len(t, obj, 1)
+		assert.Equal(t, "previous-object", obj[0].ObjectId, "the object of the previous coordinate is updated")
+		assert.Equal(t, externalID, obj[0].ExternalId, "the external ID is updated to the current coordinate")
+
+		rw.WriteHeader(http.StatusOK)
+		rw.Write([]byte(`[{"objectId": "previous-object"}]`))
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	restClient := corerest.NewClient(serverURL, server.Client(), corerest.WithRateLimiter(), corerest.WithConcurrentRequestLimit(5))
+
+	client, err := NewClassicSettingsClient(restClient,
+		WithRetrySettings(testRetrySettings),
+		WithExternalIDGenerator(idutils.GenerateExternalIDForSettingsObject))
+	require.NoError(t, err)
+
+	entity, err := client.Upsert(t.Context(), SettingsObject{
+		Coordinate:          current,
+		SchemaId:            "some:schema",
+		Scope:               "environment",
+		Content:             []byte("{}"),
+		PreviousCoordinates: []coordinate.Coordinate{previous},
+	}, UpsertSettingsOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "previous-object", entity.Id)
+}
+
 func TestUpsertSettingsFromCache_CacheInvalidated(t *testing.T) {
 	numGetAPICalls := 0
 	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -18,14 +18,17 @@ package client_test
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
 
 func TestSetCustomHTTPClientInContext(t *testing.T) {
@@ -46,3 +49,44 @@ func TestSetCustomHTTPClientInContext(t *testing.T) {
 		assert.Nil(t, val)
 	})
 }
+
+func TestContextWithConnection(t *testing.T) {
+	t.Run("Returns the context unchanged if no connection is defined", func(t *testing.T) {
+		ctx, err := client.ContextWithConnection(t.Context(), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, t.Context(), ctx)
+	})
+
+	t.Run("Sets a custom client using the proxy of the connection", func(t *testing.T) {
+		ctx, err := client.ContextWithConnection(t.Context(), &manifest.Connection{Proxy: "http://proxy.internal:3128"})
+		require.NoError(t, err)
+
+		httpClient, ok := ctx.Value(oauth2.HTTPClient).(*http.Client)
+		require.True(t, ok)
+
+		transport, ok := httpClient.Transport.(*http.Transport)
+		require.True(t, ok)
+
+		proxy, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "abc.live.dynatrace.com"}})
+		require.NoError(t, err)
+		assert.Equal(t, "http://proxy.internal:3128", proxy.String())
+	})
+
+	t.Run("Fails for an invalid CA bundle", func(t *testing.T) {
+		_, err := client.ContextWithConnection(t.Context(), &manifest.Connection{CABundle: &manifest.PEMFile{Path: "ca.pem", Content: "no certificate"}})
+
+		assert.ErrorContains(t, err, `CA bundle "ca.pem" does not contain any PEM encoded certificate`)
+	})
+}
+
+func TestAdditionalHTTPHeaders(t *testing.T) {
+	t.Setenv(environment.AdditionalHTTPHeaders, "X-Env: env\nX-Shared: env")
+
+	assert.Equal(t, map[string]string{"X-Env": "env", "X-Shared": "env"}, client.AdditionalHTTPHeaders(t.Context()))
+
+	ctx, err := client.ContextWithConnection(t.Context(), &manifest.Connection{Headers: map[string]string{"X-Shared": "connection", "X-Connection": "connection"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"X-Env": "env", "X-Shared": "connection", "X-Connection": "connection"}, client.AdditionalHTTPHeaders(ctx))
+}
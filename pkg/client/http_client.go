@@ -19,11 +19,17 @@ package client
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
 
 	"golang.org/x/oauth2"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
 
 // SetCustomHTTPClientInContext sets a custom HTTP client for the oauth2 lib without SSL certificate checks, if the SkipCertificateVerification FF is set
@@ -41,3 +47,87 @@ func SetCustomHTTPClientInContext(ctx context.Context) context.Context {
 		},
 	})
 }
+
+type ctxConnectionHeadersKey struct{}
+
+// ContextWithConnection returns a context holding a custom HTTP client for the oauth2 lib, which uses the CA bundle,
+// client certificate and proxy of the given connection settings of an environment. The headers of the connection are
+// returned by AdditionalHTTPHeaders. If connection is nil, ctx is returned unchanged.
+func ContextWithConnection(ctx context.Context, connection *manifest.Connection) (context.Context, error) {
+	if connection == nil {
+		return ctx, nil
+	}
+
+	transport, err := newConnectionTransport(connection)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: transport})
+	return context.WithValue(ctx, ctxConnectionHeadersKey{}, connection.Headers), nil
+}
+
+// AdditionalHTTPHeaders returns the headers to send with every request: the headers defined via environment
+// variable, overridden by the headers of the connection stored in ctx by ContextWithConnection.
+func AdditionalHTTPHeaders(ctx context.Context) map[string]string {
+	headers := environment.GetAdditionalHTTPHeadersFromEnv()
+	if connectionHeaders, ok := ctx.Value(ctxConnectionHeadersKey{}).(map[string]string); ok {
+		maps.Copy(headers, connectionHeaders)
+	}
+	return headers
+}
+
+func newConnectionTransport(connection *manifest.Connection) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: featureflags.SkipCertificateVerification.Enabled(), //nolint:gosec
+	}
+
+	if connection.CABundle != nil {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(connection.CABundle.Content.Value())) {
+			return nil, fmt.Errorf("CA bundle %q does not contain any PEM encoded certificate", connection.CABundle.Path)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+
+	if connection.ClientCertificate != nil && connection.ClientKey != nil {
+		cert, err := tls.X509KeyPair([]byte(connection.ClientCertificate.Content.Value()), []byte(connection.ClientKey.Content.Value()))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate %q: %w", connection.ClientCertificate.Path, err)
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if connection.Proxy != "" {
+		proxyURL, err := url.Parse(connection.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", connection.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	return transport, nil
+}
+
+// contextWithRequestsPerSecond returns a context holding a custom HTTP client for the oauth2 lib, which starts at most
+// requestsPerSecond requests per second. The client wraps the transport of the HTTP client already held by ctx.
+// If requestsPerSecond is not greater than 0, ctx is returned unchanged.
+func contextWithRequestsPerSecond(ctx context.Context, requestsPerSecond float64) context.Context {
+	if requestsPerSecond <= 0 {
+		return ctx
+	}
+
+	base := http.DefaultTransport
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && c.Transport != nil {
+		base = c.Transport
+	}
+
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{
+		Transport: newRateLimitedTransport(base, requestsPerSecond),
+	})
+}
@@ -0,0 +1,60 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimitedTransport delays requests, so that they are started at most once per interval.
+type rateLimitedTransport struct {
+	base     http.RoundTripper
+	interval time.Duration
+
+	mutex sync.Mutex
+	next  time.Time
+}
+
+func newRateLimitedTransport(base http.RoundTripper, requestsPerSecond float64) *rateLimitedTransport {
+	return &rateLimitedTransport{base: base, interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mutex.Lock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	wait := t.next.Sub(now)
+	t.next = t.next.Add(t.interval)
+	t.mutex.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
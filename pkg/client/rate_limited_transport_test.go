@@ -0,0 +1,74 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestRateLimitedTransport_DelaysRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: newRateLimitedTransport(http.DefaultTransport, 20)}
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		resp, err := httpClient.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// the first request is started immediately, each following one 50ms after the previous one
+	assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
+func TestRateLimitedTransport_StopsWaitingIfRequestIsCanceled(t *testing.T) {
+	transport := newRateLimitedTransport(http.DefaultTransport, 0.001)
+	transport.next = time.Now().Add(time.Hour)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://localhost", nil)
+	require.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestContextWithRequestsPerSecond(t *testing.T) {
+	assert.Equal(t, t.Context(), contextWithRequestsPerSecond(t.Context(), 0), "no client must be set without limit")
+
+	base := &http.Transport{}
+	ctx := context.WithValue(t.Context(), oauth2.HTTPClient, &http.Client{Transport: base})
+
+	httpClient, ok := contextWithRequestsPerSecond(ctx, 10).Value(oauth2.HTTPClient).(*http.Client)
+	require.True(t, ok)
+
+	transport, ok := httpClient.Transport.(*rateLimitedTransport)
+	require.True(t, ok)
+	assert.Same(t, base, transport.base, "the transport of the existing client must be wrapped")
+	assert.Equal(t, 100*time.Millisecond, transport.interval)
+}
@@ -0,0 +1,107 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+const (
+	// EnvironmentProperty is a built-in property describing the environment a config is deployed to.
+	// It is a map holding the keys 'name', 'group', 'url', 'platformUrl', 'classicUrl' and 'variables' and can be used
+	// like any other parameter, e.g. as {{ .environment.classicUrl }} or {{ .environment.variables.region }} in
+	// templates or referenced by compound parameters.
+	EnvironmentProperty = "environment"
+
+	// ProjectProperty is a built-in property holding the name of the project a config belongs to.
+	ProjectProperty = "project"
+)
+
+// EnvironmentProperties describes the environment a config is deployed to.
+type EnvironmentProperties struct {
+	Name  string
+	Group string
+	// URL is the URL of the environment as defined in the manifest
+	URL string
+	// PlatformURL is the URL of the platform environment, or empty if the environment is no platform environment
+	PlatformURL string
+	// ClassicURL is the URL of the classic environment, which is used for the classic APIs
+	ClassicURL string
+}
+
+// NewEnvironmentProperties returns the properties of an environment with the given URL as defined in the manifest.
+// Without a connection to the environment, the classic URL of a platform environment is derived from its platform URL
+// the same way monaco does before querying it from the environment, e.g. https://abc.live.dynatrace.com for
+// https://abc.apps.dynatrace.com.
+func NewEnvironmentProperties(name, group, url string) EnvironmentProperties {
+	env := EnvironmentProperties{Name: name, Group: group, URL: url, ClassicURL: url}
+	if strings.Contains(url, ".apps.") {
+		env.PlatformURL = url
+		env.ClassicURL = strings.Replace(url, ".apps.", ".live.", 1)
+	}
+	return env
+}
+
+// builtinProperties returns the read-only properties available to every template and parameter of the config.
+// Parameters defined by the config itself take precedence over built-in properties with the same name.
+func builtinProperties(c *Config, env EnvironmentProperties) (parameter.Properties, error) {
+	environment, err := template.EscapeSpecialCharactersInValue(map[string]any{
+		"name":        env.Name,
+		"group":       env.Group,
+		"url":         env.URL,
+		"platformUrl": env.PlatformURL,
+		"classicUrl":  env.ClassicURL,
+		"variables":   manifestVariables(c.ManifestVariables),
+	}, template.FullStringEscapeFunction)
+	if err != nil {
+		return nil, err
+	}
+
+	project, err := template.EscapeSpecialCharactersInValue(c.Coordinate.Project, template.FullStringEscapeFunction)
+	if err != nil {
+		return nil, err
+	}
+
+	return parameter.Properties{
+		EnvironmentProperty: environment,
+		ProjectProperty:     project,
+	}, nil
+}
+
+// manifestVariables returns the variables as map[string]any, so that templates fail on undefined variables.
+func manifestVariables(variables map[string]string) map[string]any {
+	result := make(map[string]any, len(variables))
+	for name, value := range variables {
+		result[name] = value
+	}
+	return result
+}
+
+// withBuiltins returns a copy of properties that additionally contains all builtins not defined in properties.
+func withBuiltins(properties map[string]any, builtins parameter.Properties) map[string]any {
+	result := make(map[string]any, len(properties)+len(builtins))
+	for name, value := range builtins {
+		result[name] = value
+	}
+	for name, value := range properties {
+		result[name] = value
+	}
+	return result
+}
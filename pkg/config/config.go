@@ -30,6 +30,7 @@ import (
 	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
 	refParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	variableParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
 
@@ -88,24 +89,80 @@ type Config struct {
 	// in the template
 	Parameters Parameters
 
+	// DeclaredParameters are the names of the Parameters declared by the config's own definition and its group and
+	// environment overrides. Parameters set by project defaults or values files are not part of it.
+	DeclaredParameters []string
+
 	// Skip flag indicates if the deployment of this configuration should be skipped. It is resolved during project loading.
 	Skip bool
 
 	// OriginObjectId is the DT object ID of the object when it was downloaded from an environment
 	OriginObjectId string
+
+	// PreviousCoordinates are the coordinates this configuration was known by before. Objects deployed under these
+	// coordinates are updated instead of creating new ones.
+	PreviousCoordinates []coordinate.Coordinate
+
+	// ParameterSchemas declare the types and constraints of parameters. Resolved values are checked against them and
+	// converted to the declared type.
+	ParameterSchemas ParameterSchemas
+
+	// RenderMode defines how parameter values are written into the Template. If empty, template.RenderModeText is used.
+	RenderMode template.RenderMode
+
+	// Patches are applied in order to the rendered Template
+	Patches []Patch
+
+	// EnvironmentURL is the URL the manifest defines for the environment of this configuration.
+	EnvironmentURL string
+
+	// ManifestVariables are the variables the manifest defines for the environment of this configuration.
+	// They are available as the built-in property 'environment.variables'.
+	ManifestVariables map[string]string
 }
 
+// Render renders the template of the config using the given properties.
+// The built-in properties describing the environment are derived from the config itself, see EnvironmentProperties.
+// Use RenderForEnvironment if a connection to the environment exists.
 func (c *Config) Render(properties map[string]any) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+
+	return c.RenderForEnvironment(properties, c.EnvironmentProperties())
+}
+
+// EnvironmentProperties returns the properties of the environment of the config as defined in the manifest.
+func (c *Config) EnvironmentProperties() EnvironmentProperties {
+	return NewEnvironmentProperties(c.Environment, c.Group, c.EnvironmentURL)
+}
+
+// RenderForEnvironment works like Render, but exposes the given environment as the built-in property EnvironmentProperty.
+func (c *Config) RenderForEnvironment(properties map[string]any, env EnvironmentProperties) (string, error) {
 	if c == nil || c.Template == nil {
 		return "", nil
 	}
 
+	builtins, err := builtinProperties(c, env)
+	if err != nil {
+		return "", err
+	}
+	properties = withBuiltins(properties, builtins)
+
 	var templatePath string // include path in errors if we know it
 	if t, ok := c.Template.(*template.FileBasedTemplate); ok {
 		templatePath = t.FilePath()
 	}
 
-	renderedConfig, err := template.Render(c.Template, properties)
+	renderMode := c.RenderMode
+	if renderMode == "" {
+		renderMode = template.RenderModeText
+	}
+
+	renderedConfig, err := template.RenderWithMode(c.Template, properties, renderMode)
+	if err == nil && template.IsYAML(c.Template) {
+		renderedConfig, err = template.YAMLToJSON(renderedConfig)
+	}
 	if err != nil {
 		return "", configErrors.InvalidJsonError{
 			Location: c.Coordinate,
@@ -137,17 +194,24 @@ func (c *Config) Render(properties map[string]any) (string, error) {
 		}
 	}
 
+	if len(c.Patches) > 0 {
+		if renderedConfig, err = applyPatches(renderedConfig, c.Patches); err != nil {
+			return "", newPatchErr(c.Coordinate, c.Group, c.Environment, templatePath, err)
+		}
+	}
+
 	return renderedConfig, nil
 }
 
 // DefaultParameterParsers map defining a set of default parsers which can be used to load configurations
 var DefaultParameterParsers = map[string]parameter.ParameterSerDe{
-	refParam.ReferenceParameterType:           refParam.ReferenceParameterSerde,
-	valueParam.ValueParameterType:             valueParam.ValueParameterSerde,
-	envParam.EnvironmentVariableParameterType: envParam.EnvironmentVariableParameterSerde,
-	compoundParam.CompoundParameterType:       compoundParam.CompoundParameterSerde,
-	listParam.ListParameterType:               listParam.ListParameterSerde,
-	fileParam.FileParameterType:               fileParam.FileParameterSerde,
+	refParam.ReferenceParameterType:             refParam.ReferenceParameterSerde,
+	valueParam.ValueParameterType:               valueParam.ValueParameterSerde,
+	envParam.EnvironmentVariableParameterType:   envParam.EnvironmentVariableParameterSerde,
+	compoundParam.CompoundParameterType:         compoundParam.CompoundParameterSerde,
+	listParam.ListParameterType:                 listParam.ListParameterSerde,
+	fileParam.FileParameterType:                 fileParam.FileParameterSerde,
+	variableParam.ManifestVariableParameterType: variableParam.ManifestVariableParameterSerde,
 }
 
 func (c *Config) References() []coordinate.Coordinate {
@@ -197,17 +261,36 @@ type EntityLookup interface {
 // of the caller of ResolveParameterValues.
 //
 // ResolveParameterValues will return a slice of errors for any failures during sorting or resolving parameters.
+//
+// Built-in properties, like the one describing the environment, are available to all parameters, but are not part of the
+// returned properties. They are derived from the config itself, see EnvironmentProperties.
+// Use ResolveParameterValuesForEnvironment if a connection to the environment exists.
 func (c *Config) ResolveParameterValues(entities EntityLookup) (parameter.Properties, []error) {
 	if c == nil {
 		return nil, nil
 	}
 
+	return c.ResolveParameterValuesForEnvironment(entities, c.EnvironmentProperties())
+}
+
+// ResolveParameterValuesForEnvironment works like ResolveParameterValues, but exposes the given environment as the
+// built-in property EnvironmentProperty.
+func (c *Config) ResolveParameterValuesForEnvironment(entities EntityLookup, env EnvironmentProperties) (parameter.Properties, []error) {
+	if c == nil {
+		return nil, nil
+	}
+
 	var errors []error
 
 	parameters, sortErrs := getSortedParameters(c)
 	errors = append(errors, sortErrs...)
 
-	properties, errs := resolveValues(c, entities, parameters)
+	builtins, err := builtinProperties(c, env)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	properties, errs := resolveValues(c, entities, parameters, builtins)
 	errors = append(errors, errs...)
 
 	if len(errors) > 0 {
@@ -26,11 +26,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
 	configErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/errors"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	compoundParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 )
 
@@ -367,6 +370,276 @@ func TestConfig_Render_ErrorsOnInvalidJson(t *testing.T) {
 	assert.ErrorAs(t, err, &configErrors.InvalidJsonError{})
 }
 
+func TestResolveParameterValuesForEnvironment_ExposesBuiltinProperties(t *testing.T) {
+	coord := coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"}
+	title, err := compoundParam.New("title", "{{ .environment.name }} ({{ .project }})", []parameter.ParameterReference{
+		{Config: coord, Property: EnvironmentProperty},
+		{Config: coord, Property: ProjectProperty},
+	})
+	require.NoError(t, err)
+
+	c := Config{
+		Template:    generateDummyTemplate(t),
+		Coordinate:  coord,
+		Environment: "development",
+		Parameters:  Parameters{"title": title},
+	}
+
+	values, errs := c.ResolveParameterValuesForEnvironment(entityLookup{}, EnvironmentProperties{Name: "development", Group: "dev", URL: "https://dev.example.com"})
+
+	assert.Empty(t, errs)
+	assert.Equal(t, parameter.Properties{"title": "development (project1)"}, values, "built-in properties must not be returned")
+}
+
+func TestResolveParameterValues_ParametersTakePrecedenceOverBuiltinProperties(t *testing.T) {
+	c := Config{
+		Template:   generateDummyTemplate(t),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		Parameters: Parameters{ProjectProperty: &parameter.DummyParameter{Value: "my-project"}},
+	}
+
+	values, errs := c.ResolveParameterValues(entityLookup{})
+
+	assert.Empty(t, errs)
+	assert.Equal(t, "my-project", values[ProjectProperty])
+}
+
+func TestConfig_RenderForEnvironment_ExposesBuiltinProperties(t *testing.T) {
+	c := Config{
+		Template:    template.NewInMemoryTemplate("test", `{"env": "{{ .environment.name }}", "group": "{{ .environment.group }}", "url": "{{ .environment.url }}", "project": "{{ .project }}", "name": "{{ .name }}"}`),
+		Coordinate:  coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		Environment: "development",
+		Group:       "dev",
+	}
+
+	got, err := c.RenderForEnvironment(map[string]any{"name": "board"}, EnvironmentProperties{Name: "development", Group: "dev", URL: "https://dev.example.com"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"env": "development", "group": "dev", "url": "https://dev.example.com", "project": "project1", "name": "board"}`, got)
+
+	got, err = c.Render(map[string]any{"name": "board"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"env": "development", "group": "dev", "url": "", "project": "project1", "name": "board"}`, got)
+
+	c.EnvironmentURL = "https://abc.apps.dynatrace.com"
+	got, err = c.Render(map[string]any{"name": "board"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"env": "development", "group": "dev", "url": "https://abc.apps.dynatrace.com", "project": "project1", "name": "board"}`, got)
+}
+
+func TestConfig_Render_ExposesPlatformAndClassicURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		expected string
+	}{
+		{
+			name:     "platform environment",
+			url:      "https://abc.apps.dynatrace.com",
+			expected: `{"platform": "https://abc.apps.dynatrace.com", "classic": "https://abc.live.dynatrace.com"}`,
+		},
+		{
+			name:     "classic environment",
+			url:      "https://abc.live.dynatrace.com",
+			expected: `{"platform": "", "classic": "https://abc.live.dynatrace.com"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{
+				Template:       template.NewInMemoryTemplate("test", `{"platform": "{{ .environment.platformUrl }}", "classic": "{{ .environment.classicUrl }}"}`),
+				Coordinate:     coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+				Environment:    "development",
+				EnvironmentURL: tt.url,
+			}
+
+			got, err := c.Render(map[string]any{})
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, got)
+		})
+	}
+
+	t.Run("URLs of the connected environment take precedence", func(t *testing.T) {
+		c := Config{
+			Template:   template.NewInMemoryTemplate("test", `{"platform": "{{ .environment.platformUrl }}", "classic": "{{ .environment.classicUrl }}"}`),
+			Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		}
+
+		got, err := c.RenderForEnvironment(map[string]any{}, EnvironmentProperties{PlatformURL: "https://abc.apps.dynatrace.com", ClassicURL: "https://abc.dynatrace.com"})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"platform": "https://abc.apps.dynatrace.com", "classic": "https://abc.dynatrace.com"}`, got)
+	})
+}
+
+func TestConfig_Render_UsesRenderMode(t *testing.T) {
+	c := Config{
+		Template:   template.NewInMemoryTemplate("test", `{"description": "{{ .description }}", "tags": {{ .tags }}}`),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		RenderMode: template.RenderModeJSON,
+	}
+
+	got, err := c.Render(map[string]any{"description": `a \"quoted\" description`, "tags": []any{"a", "b"}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"description": "a \"quoted\" description", "tags": ["a", "b"]}`, got)
+
+	c.RenderMode = ""
+	_, err = c.Render(map[string]any{"description": `a \"quoted\" description`, "tags": []any{"a", "b"}})
+	assert.ErrorAs(t, err, &configErrors.InvalidJsonError{})
+}
+
+func TestConfig_Render_TemplateFunctionsUseResolvedParameters(t *testing.T) {
+	c := Config{
+		Template: template.NewInMemoryTemplate("test", `{
+  "quoted": {{ quote .description }},
+  "escaped": "{{ escape .description }}",
+  "encoded": "{{ b64enc .description }}",
+  "indented": "{{ indent 2 .script }}",
+  "upper": "{{ upper .script }}",
+  "optional": "{{ .optional | default "fallback" }}"
+}`),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		Parameters: Parameters{
+			NameParameter: valueParam.New("board"),
+			"description": valueParam.New(`a "quoted" C:\path`),
+			"script":      valueParam.New("line 1\nline 2"),
+		},
+	}
+
+	properties, errs := c.ResolveParameterValues(entityLookup{})
+	require.Empty(t, errs)
+
+	got, err := c.Render(properties)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "quoted": "a \"quoted\" C:\\path",
+  "escaped": "a \"quoted\" C:\\path",
+  "encoded": "YSAicXVvdGVkIiBDOlxwYXRo",
+  "indented": "  line 1\n  line 2",
+  "upper": "LINE 1\nLINE 2",
+  "optional": "fallback"
+}`, got)
+
+	c.Template = template.NewInMemoryTemplate("test", `{"id": "{{ required "the profile ID must be set" .profileId }}"}`)
+	_, err = c.Render(properties)
+	assert.ErrorContains(t, err, "required value is missing: the profile ID must be set")
+}
+
+func TestConfig_Render_AppliesPatches(t *testing.T) {
+	c := Config{
+		Template:   template.NewInMemoryTemplate("test", `{"name": "{{ .name }}", "settings": {"refresh": 30, "legacy": true}, "tiles": [{"name": "first"}]}`),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		Patches: []Patch{
+			{MergePatch: map[string]any{"settings": map[string]any{"refresh": 60, "legacy": nil}}},
+			{JSONPatch: []json.PatchOperation{{Op: "add", Path: "/tiles/-", Value: map[string]any{"name": "second"}}}},
+		},
+	}
+
+	got, err := c.Render(map[string]any{"name": "board"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name": "board", "settings": {"refresh": 60}, "tiles": [{"name": "first"}, {"name": "second"}]}`, got)
+
+	c.Patches = []Patch{{JSONPatch: []json.PatchOperation{{Op: "remove", Path: "/unknown"}}}}
+	_, err = c.Render(map[string]any{"name": "board"})
+	assert.ErrorAs(t, err, &PatchErr{})
+}
+
+func TestResolveParameterValues_AppliesParameterSchemas(t *testing.T) {
+	minThreshold := 1
+	c := Config{
+		Template:   generateDummyTemplate(t),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		Parameters: Parameters{
+			"threshold": &parameter.DummyParameter{Value: "42"},
+			"enabled":   &parameter.DummyParameter{Value: "false"},
+		},
+		ParameterSchemas: ParameterSchemas{
+			"threshold": {Type: IntParameterSchemaType, Min: &minThreshold},
+			"enabled":   {Type: BoolParameterSchemaType},
+		},
+	}
+
+	values, errs := c.ResolveParameterValues(entityLookup{})
+
+	assert.Empty(t, errs)
+	assert.Equal(t, parameter.Properties{"threshold": 42, "enabled": false}, values, "values must be converted to the declared type")
+
+	c.Parameters["enabled"] = &parameter.DummyParameter{Value: "fals"}
+
+	_, errs = c.ResolveParameterValues(entityLookup{})
+
+	require.Len(t, errs, 1)
+	var schemaErr ParamsSchemaErr
+	require.ErrorAs(t, errs[0], &schemaErr)
+	assert.Equal(t, "enabled", schemaErr.ParameterName)
+}
+
+func TestResolveParameterValues_AppliesParameterSchemasToUnescapedValues(t *testing.T) {
+	c := Config{
+		Template:   generateDummyTemplate(t),
+		Coordinate: coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		Parameters: Parameters{
+			"query": valueParam.New(`host = "a\b"`),
+			"level": valueParam.New(`"high"`),
+		},
+		ParameterSchemas: ParameterSchemas{
+			"query": {Type: StringParameterSchemaType, Pattern: `^host = "[a-z\\]+"$`},
+			"level": {Type: EnumParameterSchemaType, Values: []string{`"low"`, `"high"`}},
+		},
+	}
+
+	values, errs := c.ResolveParameterValues(entityLookup{})
+
+	assert.Empty(t, errs)
+	assert.Equal(t, parameter.Properties{"query": `host = \"a\\b\"`, "level": `\"high\"`}, values, "values must stay escaped for rendering")
+}
+
+func TestParameterSchema_Apply(t *testing.T) {
+	minValue, maxValue := 1, 10
+	tests := []struct {
+		name    string
+		schema  ParameterSchema
+		value   any
+		want    any
+		wantErr bool
+	}{
+		{"int", ParameterSchema{Type: IntParameterSchemaType, Min: &minValue, Max: &maxValue}, 5, 5, false},
+		{"int from string", ParameterSchema{Type: IntParameterSchemaType}, "5", 5, false},
+		{"int below min", ParameterSchema{Type: IntParameterSchemaType, Min: &minValue}, 0, nil, true},
+		{"int above max", ParameterSchema{Type: IntParameterSchemaType, Max: &maxValue}, 11, nil, true},
+		{"int from float", ParameterSchema{Type: IntParameterSchemaType}, 1.5, nil, true},
+		{"bool", ParameterSchema{Type: BoolParameterSchemaType}, true, true, false},
+		{"bool from string", ParameterSchema{Type: BoolParameterSchemaType}, "false", false, false},
+		{"bool typo", ParameterSchema{Type: BoolParameterSchemaType}, "fals", nil, true},
+		{"string from int", ParameterSchema{Type: StringParameterSchemaType}, 42, "42", false},
+		{"string not matching pattern", ParameterSchema{Type: StringParameterSchemaType, Pattern: "^[a-z]+$"}, "ABC", nil, true},
+		{"string from map", ParameterSchema{Type: StringParameterSchemaType}, map[string]any{}, nil, true},
+		{"enum", ParameterSchema{Type: EnumParameterSchemaType, Values: []string{"low", "high"}}, "low", "low", false},
+		{"enum unknown value", ParameterSchema{Type: EnumParameterSchemaType, Values: []string{"low", "high"}}, "medium", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.schema.Apply(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParameterSchema_Validate(t *testing.T) {
+	minValue, maxValue := 10, 1
+	assert.NoError(t, ParameterSchema{Type: StringParameterSchemaType, Pattern: "^a"}.Validate())
+	assert.Error(t, ParameterSchema{Type: "number"}.Validate())
+	assert.Error(t, ParameterSchema{Type: EnumParameterSchemaType}.Validate())
+	assert.Error(t, ParameterSchema{Type: IntParameterSchemaType, Min: &minValue, Max: &maxValue}.Validate())
+	assert.Error(t, ParameterSchema{Type: BoolParameterSchemaType, Min: &minValue}.Validate())
+	assert.Error(t, ParameterSchema{Type: StringParameterSchemaType, Pattern: "("}.Validate())
+}
+
 func toParameterMap(params []parameter.NamedParameter) map[string]parameter.Parameter {
 	result := make(map[string]parameter.Parameter)
 
@@ -423,3 +696,19 @@ func TestConfigMethodsAreNilSafe(t *testing.T) {
 		})
 	})
 }
+
+func TestConfig_Render_ExposesManifestVariables(t *testing.T) {
+	c := Config{
+		Template:          template.NewInMemoryTemplate("test", `{"region": "{{ .environment.variables.region }}"}`),
+		Coordinate:        coordinate.Coordinate{Project: "project1", Type: "dashboard", ConfigId: "dashboard-1"},
+		ManifestVariables: map[string]string{"region": "eu-west"},
+	}
+
+	got, err := c.Render(map[string]any{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"region": "eu-west"}`, got)
+
+	c.Template = template.NewInMemoryTemplate("test", `{"channel": "{{ .environment.variables.slackChannel }}"}`)
+	_, err = c.Render(map[string]any{})
+	assert.Error(t, err, "undefined manifest variables must not render")
+}
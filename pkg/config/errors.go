@@ -61,6 +61,78 @@ func (e ParamsRefErr) Error() string {
 		e.ParameterName, e.Reference, e.Reason)
 }
 
+var _ configErrors.DetailedConfigError = (*ParamsSchemaErr)(nil)
+
+// ParamsSchemaErr is returned if the value of a parameter does not match the ParameterSchema declared for it.
+type ParamsSchemaErr struct {
+	Location           coordinate.Coordinate           `json:"location"`
+	EnvironmentDetails configErrors.EnvironmentDetails `json:"environmentDetails"`
+	ParameterName      string                          `json:"parameterName"`
+	Reason             string                          `json:"reason"`
+}
+
+func newParamsSchemaErr(coord coordinate.Coordinate, group string, env string, param string, err error) ParamsSchemaErr {
+	return ParamsSchemaErr{
+		Location: coord,
+		EnvironmentDetails: configErrors.EnvironmentDetails{
+			Group:       group,
+			Environment: env,
+		},
+		ParameterName: param,
+		Reason:        err.Error(),
+	}
+}
+
+func (e ParamsSchemaErr) Coordinates() coordinate.Coordinate {
+	return e.Location
+}
+
+func (e ParamsSchemaErr) LocationDetails() configErrors.EnvironmentDetails {
+	return e.EnvironmentDetails
+}
+
+func (e ParamsSchemaErr) Error() string {
+	return fmt.Sprintf("parameter `%s` does not match its schema: %s", e.ParameterName, e.Reason)
+}
+
+var _ configErrors.DetailedConfigError = (*PatchErr)(nil)
+
+// PatchErr is returned if the patches of a config can not be applied to its rendered template.
+type PatchErr struct {
+	Location           coordinate.Coordinate           `json:"location"`
+	EnvironmentDetails configErrors.EnvironmentDetails `json:"environmentDetails"`
+	TemplateFilePath   string                          `json:"templateFilePath"`
+	Err                error                           `json:"error"`
+}
+
+func newPatchErr(coord coordinate.Coordinate, group string, env string, templatePath string, err error) PatchErr {
+	return PatchErr{
+		Location: coord,
+		EnvironmentDetails: configErrors.EnvironmentDetails{
+			Group:       group,
+			Environment: env,
+		},
+		TemplateFilePath: templatePath,
+		Err:              err,
+	}
+}
+
+func (e PatchErr) Coordinates() coordinate.Coordinate {
+	return e.Location
+}
+
+func (e PatchErr) LocationDetails() configErrors.EnvironmentDetails {
+	return e.EnvironmentDetails
+}
+
+func (e PatchErr) Unwrap() error {
+	return e.Err
+}
+
+func (e PatchErr) Error() string {
+	return fmt.Sprintf("failed to apply patches to rendered template %q: %s", e.TemplateFilePath, e.Err)
+}
+
 var (
 	_ error                            = (*CircularDependencyParameterSortError)(nil)
 	_ configErrors.DetailedConfigError = (*CircularDependencyParameterSortError)(nil)
@@ -0,0 +1,139 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package formatter formats config files and JSON templates in the canonical format monaco writes them in.
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+)
+
+// FormatConfigFile returns the given config file serialized in the same canonical order the config writer uses:
+// configs are sorted by their ID, and the keys of each config follow the order of the config definition.
+// Comments are not kept. Files that contain unknown keys are not formatted, as their content would be lost.
+func FormatConfigFile(content []byte) ([]byte, error) {
+	var definition persistence.TopLevelDefinition
+	if err := yaml.UnmarshalStrict(content, &definition); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	slices.SortStableFunc(definition.Configs, func(a, b persistence.TopLevelConfigDefinition) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	formatted, err := yaml.Marshal(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize config file: %w", err)
+	}
+
+	return formatted, nil
+}
+
+const placeholderPrefix = "__monaco_template_action_"
+
+// FormatJSONTemplate returns the given JSON template indented by two spaces per level. Template actions, like
+// {{.name}}, are kept as they are, both within JSON strings and in place of JSON values.
+// Templates that are not valid JSON when replacing each action outside of strings with a single value, like templates
+// generating several array items in a loop, can not be formatted.
+func FormatJSONTemplate(content []byte) ([]byte, error) {
+	if bytes.Contains(content, []byte(placeholderPrefix)) {
+		return nil, fmt.Errorf("template must not contain %q", placeholderPrefix)
+	}
+
+	masked, actions, err := maskTemplateActions(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(masked), "", "  "); err != nil {
+		return nil, fmt.Errorf("template is not valid JSON: %w", err)
+	}
+
+	formatted := buf.String()
+	for i, a := range actions {
+		name := placeholder(i)
+		if a.inString {
+			formatted = strings.Replace(formatted, name, a.text, 1)
+		} else {
+			formatted = strings.Replace(formatted, `"`+name+`"`, a.text, 1)
+		}
+	}
+
+	return []byte(formatted + "\n"), nil
+}
+
+type templateAction struct {
+	text     string
+	inString bool
+}
+
+func placeholder(i int) string {
+	return fmt.Sprintf("%s%d__", placeholderPrefix, i)
+}
+
+// maskTemplateActions replaces all template actions with placeholders. Actions within JSON strings are replaced by
+// the plain placeholder, all others by the placeholder as JSON string, so that the result can be parsed as JSON.
+func maskTemplateActions(content []byte) ([]byte, []templateAction, error) {
+	var result []byte
+	var actions []templateAction
+	inString := false
+
+	for i := 0; i < len(content); {
+		if bytes.HasPrefix(content[i:], []byte("{{")) {
+			end := bytes.Index(content[i+2:], []byte("}}"))
+			if end < 0 {
+				return nil, nil, errors.New("template contains an unterminated action")
+			}
+
+			text := string(content[i : i+2+end+2])
+			name := placeholder(len(actions))
+			actions = append(actions, templateAction{text: text, inString: inString})
+			if inString {
+				result = append(result, name...)
+			} else {
+				result = append(result, `"`+name+`"`...)
+			}
+
+			i += len(text)
+			continue
+		}
+
+		c := content[i]
+		if inString && c == '\\' && i+1 < len(content) {
+			result = append(result, c, content[i+1])
+			i += 2
+			continue
+		}
+		if c == '"' {
+			inString = !inString
+		}
+
+		result = append(result, c)
+		i++
+	}
+
+	return result, actions, nil
+}
@@ -0,0 +1,149 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package formatter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatConfigFile(t *testing.T) {
+	content := `configs:
+- config:
+    template: b.json
+    name: B
+  type:
+    api: dashboard
+  id: b
+- id: a
+  type: {api: dashboard}
+  config: {name: A, template: a.json, parameters: {threshold: 5}}
+`
+
+	formatted, err := FormatConfigFile([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+- id: a
+  config:
+    name: A
+    parameters:
+      threshold: 5
+    template: a.json
+  type:
+    api: dashboard
+- id: b
+  config:
+    name: B
+    template: b.json
+  type:
+    api: dashboard
+`, string(formatted))
+
+	again, err := FormatConfigFile(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, string(formatted), string(again), "formatting must be idempotent")
+}
+
+func TestFormatConfigFile_ConfigsWithoutType(t *testing.T) {
+	content := `configs:
+- id: b
+  extends: a
+  config: {name: B}
+- id: a
+  type: {api: dashboard}
+  config: {name: A, template: a.json}
+`
+
+	formatted, err := FormatConfigFile([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+- id: a
+  config:
+    name: A
+    template: a.json
+  type:
+    api: dashboard
+- id: b
+  extends: a
+  config:
+    name: B
+`, string(formatted))
+}
+
+func TestFormatConfigFile_UnknownKeysAreNotFormatted(t *testing.T) {
+	_, err := FormatConfigFile([]byte("configs:\n- id: a\n  unknown: true\n"))
+	assert.ErrorContains(t, err, "failed to parse config file")
+}
+
+func TestFormatJSONTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    string
+		expected string
+	}{
+		{
+			name:     "plain JSON",
+			given:    `{"a":1,"b":[true,null]}`,
+			expected: "{\n  \"a\": 1,\n  \"b\": [\n    true,\n    null\n  ]\n}\n",
+		},
+		{
+			name:     "actions in strings",
+			given:    `{"name":"{{.name}}","description":"say \"{{ .greeting }}\""}`,
+			expected: "{\n  \"name\": \"{{.name}}\",\n  \"description\": \"say \\\"{{ .greeting }}\\\"\"\n}\n",
+		},
+		{
+			name:     "actions as values",
+			given:    `{"enabled":{{.enabled}},"ids":[{{ .id }}]}`,
+			expected: "{\n  \"enabled\": {{.enabled}},\n  \"ids\": [\n    {{ .id }}\n  ]\n}\n",
+		},
+		{
+			name:     "already formatted",
+			given:    "{\n  \"a\": 1\n}\n",
+			expected: "{\n  \"a\": 1\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted, err := FormatJSONTemplate([]byte(tt.given))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(formatted))
+		})
+	}
+}
+
+func TestFormatJSONTemplate_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		given string
+	}{
+		{"invalid JSON", `{"a":}`},
+		{"unterminated action", `{"a": {{.a}`},
+		{"action generating several values", `[{{range .items}}"{{.}}",{{end}}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FormatJSONTemplate([]byte(tt.given))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -21,9 +21,16 @@ type GroupOverride struct {
 	Override ConfigDefinition `yaml:"override" json:"override"`
 }
 
+// EnvironmentOverride applies to the environments it selects. Exactly one of Environment, EnvironmentRegex and Labels
+// must be set.
 type EnvironmentOverride struct {
-	Environment string           `yaml:"environment" json:"environment"`
-	Override    ConfigDefinition `yaml:"override" json:"override"`
+	// Environment is either the name of an environment, or a glob pattern matching environment names
+	Environment string `yaml:"environment,omitempty" json:"environment,omitempty"`
+	// EnvironmentRegex is a regular expression matching environment names
+	EnvironmentRegex string `yaml:"environmentRegex,omitempty" json:"environmentRegex,omitempty"`
+	// Labels selects all environments that define all the given labels in the manifest
+	Labels   map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Override ConfigDefinition  `yaml:"override" json:"override"`
 }
 
 type ConfigDefinition struct {
@@ -32,20 +39,75 @@ type ConfigDefinition struct {
 	Template       string                     `yaml:"template,omitempty" json:"template,omitempty"`
 	Skip           ConfigParameter            `yaml:"skip,omitempty" json:"skip,omitempty"`
 	OriginObjectId string                     `yaml:"originObjectId,omitempty" json:"originObjectId,omitempty"`
+	// RenderMode defines how parameter values are written into the template, either `text` (default) or `json`
+	RenderMode string `yaml:"renderMode,omitempty" json:"renderMode,omitempty"`
+	// MergePatch is a JSON Merge Patch (RFC 7396) applied to the rendered template
+	MergePatch ConfigParameter `yaml:"mergePatch,omitempty" json:"mergePatch,omitempty"`
+	// JSONPatch is a JSON Patch (RFC 6902) applied to the rendered template
+	JSONPatch []JSONPatchOperation `yaml:"jsonPatch,omitempty" json:"jsonPatch,omitempty"`
+}
+
+// JSONPatchOperation is a single operation of a JSON Patch (RFC 6902)
+type JSONPatchOperation struct {
+	Op    string          `yaml:"op" json:"op"`
+	Path  string          `yaml:"path" json:"path"`
+	From  string          `yaml:"from,omitempty" json:"from,omitempty"`
+	Value ConfigParameter `yaml:"value,omitempty" json:"value,omitempty"`
 }
 
 type TopLevelConfigDefinition struct {
-	Id     string           `yaml:"id" json:"id"`
-	Config ConfigDefinition `yaml:"config" json:"config"`
-	Type   TypeDefinition   `yaml:"type" json:"type"`
+	Id string `yaml:"id" json:"id"`
+	// Extends references a config whose definition this config inherits. It is either the ID of a config in the same
+	// project, or a coordinate in the form `project:type:configId`.
+	Extends string `yaml:"extends,omitempty" json:"extends,omitempty"`
+	// ForEach expands the definition into one config per item. It is either an inline list or a list parameter.
+	ForEach ConfigParameter  `yaml:"forEach,omitempty" json:"forEach,omitempty"`
+	Config  ConfigDefinition `yaml:"config" json:"config"`
+	Type    TypeDefinition   `yaml:"type,omitempty" json:"type"`
+	// PreviousIds are IDs the config was known by in the same project before. Objects deployed under these IDs are
+	// updated instead of creating new ones.
+	PreviousIds []string `yaml:"previousIds,omitempty" json:"previousIds,omitempty"`
+	// PreviousCoordinates are coordinates the config was known by before, e.g. in another project. Objects deployed
+	// under these coordinates are updated instead of creating new ones.
+	PreviousCoordinates []PreviousCoordinateDefinition `yaml:"previousCoordinates,omitempty" json:"previousCoordinates,omitempty"`
+	// ParameterSchema declares the types and constraints of the config's parameters
+	ParameterSchema map[string]ParameterSchemaDefinition `yaml:"parameterSchema,omitempty" json:"parameterSchema,omitempty"`
 	// GroupOverrides overwrite specific parts of the Config when deploying it to any environment in a given group
 	GroupOverrides []GroupOverride `yaml:"groupOverrides,omitempty" json:"groupOverrides,omitempty"`
 	// EnvironmentOverrides overwrite specific parts of the Config when deploying it to a given environment
 	EnvironmentOverrides []EnvironmentOverride `yaml:"environmentOverrides,omitempty" json:"environmentOverrides,omitempty"`
 }
 
+// PreviousCoordinateDefinition is a coordinate a config was known by before. The project and type default to the ones
+// of the config.
+type PreviousCoordinateDefinition struct {
+	Project    string `yaml:"project,omitempty" json:"project,omitempty"`
+	ConfigType string `yaml:"configType,omitempty" json:"configType,omitempty"`
+	ConfigId   string `yaml:"configId" json:"configId"`
+}
+
 type TopLevelDefinition struct {
 	Configs []TopLevelConfigDefinition `yaml:"configs" json:"configs"`
 }
 
 type ConfigParameter any
+
+// ParameterSchemaDefinition declares the type and constraints of a parameter's value.
+type ParameterSchemaDefinition struct {
+	Type     string `yaml:"type" json:"type"`
+	Min      *int   `yaml:"min,omitempty" json:"min,omitempty"`
+	Max      *int   `yaml:"max,omitempty" json:"max,omitempty"`
+	Pattern  string `yaml:"pattern,omitempty" json:"pattern,omitempty"`
+	Values   []any  `yaml:"values,omitempty" json:"values,omitempty"`
+	Required bool   `yaml:"required,omitempty" json:"required,omitempty"`
+}
+
+// DefaultsDefinition defines parameters shared by all configs of a project.
+// Overrides may only define parameters.
+type DefaultsDefinition struct {
+	// RenderMode is the render mode of all configs of the project that do not define their own
+	RenderMode           string                     `yaml:"renderMode,omitempty" json:"renderMode,omitempty"`
+	Parameters           map[string]ConfigParameter `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	GroupOverrides       []GroupOverride            `yaml:"groupOverrides,omitempty" json:"groupOverrides,omitempty"`
+	EnvironmentOverrides []EnvironmentOverride      `yaml:"environmentOverrides,omitempty" json:"environmentOverrides,omitempty"`
+}
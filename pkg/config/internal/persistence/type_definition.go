@@ -39,6 +39,11 @@ type TypeDefinition struct {
 	InsertAfter ConfigParameter
 }
 
+// IsZero reports whether no type is defined, as is the case for configs that inherit their type via `extends`.
+func (c TypeDefinition) IsZero() bool {
+	return c.Type == nil && c.Scope == nil && c.InsertAfter == nil
+}
+
 type ComplexApiDefinition struct {
 	Name  string          `yaml:"name" json:"name" mapstructure:"name"`
 	Scope ConfigParameter `yaml:"scope,omitempty" json:"scope"  mapstructure:"scope"`
@@ -26,6 +26,7 @@ import (
 
 	"github.com/spf13/afero"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
@@ -44,6 +45,39 @@ func parseConfigEntry(
 	definition persistence.TopLevelConfigDefinition,
 ) ([]config.Config, []error) {
 
+	definition, err := resolveExtends(loaderContext, definition)
+	if err != nil {
+		return nil, []error{newDefinitionParserError(configId, &singleConfigEntryLoadContext{configFileLoaderContext: loaderContext}, err.Error())}
+	}
+
+	definitions, err := expandForEach(definition)
+	if err != nil {
+		return nil, []error{newDefinitionParserError(configId, &singleConfigEntryLoadContext{configFileLoaderContext: loaderContext, Type: definition.Type.GetApiType()}, err.Error())}
+	}
+
+	var results []config.Config
+	var errs []error
+	for _, d := range definitions {
+		result, definitionErrors := parseConfigDefinition(fs, loaderContext, d.Id, d)
+		results = append(results, result...)
+		errs = append(errs, definitionErrors...)
+	}
+
+	if len(errs) != 0 {
+		return results, errs
+	}
+
+	return results, nil
+}
+
+// parseConfigDefinition parses a single config definition, for which extends and forEach are already resolved
+func parseConfigDefinition(
+	fs afero.Fs,
+	loaderContext *configFileLoaderContext,
+	configId string,
+	definition persistence.TopLevelConfigDefinition,
+) ([]config.Config, []error) {
+
 	if definition.Type == (persistence.TypeDefinition{}) {
 		return nil, []error{errors.New("missing type definition")}
 	}
@@ -61,19 +95,33 @@ func parseConfigEntry(
 	groupOverrideMap := toGroupOverrideMap(definition.GroupOverrides)
 
 	warnForUndefinedEnvironments(loaderContext, definition.EnvironmentOverrides)
-	environmentOverrideMap := toEnvironmentOverrideMap(definition.EnvironmentOverrides)
+	environmentOverrides, err := newEnvironmentOverrides(definition.EnvironmentOverrides)
+	if err != nil {
+		return nil, []error{newDefinitionParserError(configId, singleConfigContext, err.Error())}
+	}
+
+	schemas, err := parseParameterSchemas(definition.ParameterSchema)
+	if err != nil {
+		return nil, []error{newDefinitionParserError(configId, singleConfigContext, err.Error())}
+	}
+
+	previous, err := previousCoordinates(coordinate.Coordinate{Project: loaderContext.ProjectId, Type: singleConfigContext.Type, ConfigId: configId}, definition)
+	if err != nil {
+		return nil, []error{newDefinitionParserError(configId, singleConfigContext, err.Error())}
+	}
 
 	var results []config.Config
 	var errs []error
 	for _, env := range loaderContext.Environments.SelectedEnvironments {
 
-		result, definitionErrors := parseDefinitionForEnvironment(fs, singleConfigContext, configId, env, definition, groupOverrideMap, environmentOverrideMap)
+		result, definitionErrors := parseDefinitionForEnvironment(fs, singleConfigContext, configId, env, definition, groupOverrideMap, environmentOverrides, schemas)
 
 		if definitionErrors != nil {
 			errs = append(errs, definitionErrors...)
 			continue
 		}
 
+		result.PreviousCoordinates = previous
 		results = append(results, result)
 	}
 
@@ -94,20 +142,16 @@ func warnForUndefinedGroups(loaderContext *configFileLoaderContext, groupOverrid
 
 func warnForUndefinedEnvironments(loaderContext *configFileLoaderContext, environmentOverrides []persistence.EnvironmentOverride) {
 	for _, environmentOverride := range environmentOverrides {
-		if _, exists := loaderContext.Environments.AllEnvironmentNames[environmentOverride.Environment]; !exists {
-			log.Warn("environment override references unknown environment '%s' which is not defined in the manifest", environmentOverride.Environment)
+		if matchesAny(environmentOverride, loaderContext.Environments.AllEnvironmentNames) {
+			continue
 		}
-	}
-}
-
-func toEnvironmentOverrideMap(environments []persistence.EnvironmentOverride) map[string]persistence.EnvironmentOverride {
-	result := make(map[string]persistence.EnvironmentOverride)
 
-	for _, env := range environments {
-		result[env.Environment] = env
+		if environmentOverride.EnvironmentRegex != "" || isEnvironmentPattern(environmentOverride.Environment) {
+			log.Warn("environment override '%s' does not match any environment defined in the manifest", environmentSelectorKey(environmentOverride))
+		} else {
+			log.Warn("environment override references unknown environment '%s' which is not defined in the manifest", environmentOverride.Environment)
+		}
 	}
-
-	return result
 }
 
 func toGroupOverrideMap(groups []persistence.GroupOverride) map[string]persistence.GroupOverride {
@@ -127,7 +171,8 @@ func parseDefinitionForEnvironment(
 	environment manifest.EnvironmentDefinition,
 	definition persistence.TopLevelConfigDefinition,
 	groupOverrides map[string]persistence.GroupOverride,
-	environmentOverride map[string]persistence.EnvironmentOverride,
+	environmentOverrides environmentOverrides,
+	schemas config.ParameterSchemas,
 ) (config.Config, []error) {
 
 	configDefinition := persistence.ConfigDefinition{
@@ -135,22 +180,60 @@ func parseDefinitionForEnvironment(
 		OriginObjectId: definition.Config.OriginObjectId,
 	}
 
-	applyOverrides(&configDefinition, definition.Config)
+	// defaults of the project are applied first, so that the config's own parameters take precedence
+	overrides := context.Defaults.forEnvironment(environment)
+	overrides = append(overrides, context.Values.forProject(context.ProjectId)...)
+	overrides = append(overrides, definition.Config)
 
+	declared := []persistence.ConfigDefinition{definition.Config}
 	if override, found := groupOverrides[environment.Group]; found {
-		applyOverrides(&configDefinition, override.Override)
+		declared = append(declared, override.Override)
 	}
+	declared = append(declared, environmentOverrides.forEnvironment(environment)...)
+	overrides = append(overrides, declared[1:]...)
+	// values files are applied last, so that they take precedence over anything defined in the project
+	overrides = append(overrides, context.Values.forConfig(coordinate.Coordinate{Project: context.ProjectId, Type: context.Type, ConfigId: configId})...)
+
+	// patches are not replaced by later overrides, but applied in the order of the overrides
+	var patches []config.Patch
+	for _, override := range overrides {
+		if err := applyOverrides(&configDefinition, override); err != nil {
+			return config.Config{}, []error{newDetailedDefinitionParserError(configId, context, environment, err.Error())}
+		}
 
-	if override, found := environmentOverride[environment.Name]; found {
-		applyOverrides(&configDefinition, override.Override)
+		patch, err := parsePatch(override)
+		if err != nil {
+			return config.Config{}, []error{newDetailedDefinitionParserError(configId, context, environment, err.Error())}
+		}
+		if patch != nil {
+			patches = append(patches, *patch)
+		}
 	}
 
 	configDefinition.Template = filepath.FromSlash(configDefinition.Template)
 
-	return getConfigFromDefinition(fs, context, configId, environment, configDefinition, definition.Type)
+	c, errs := getConfigFromDefinition(fs, context, configId, environment, configDefinition, definition.Type, schemas)
+	if errs != nil {
+		return config.Config{}, errs
+	}
+
+	c.Patches = patches
+	c.DeclaredParameters = declaredParameters(declared)
+	return c, nil
 }
 
-func applyOverrides(base *persistence.ConfigDefinition, override persistence.ConfigDefinition) {
+// declaredParameters returns the sorted names of the parameters set by any of the given definitions.
+func declaredParameters(definitions []persistence.ConfigDefinition) []string {
+	names := map[string]struct{}{}
+	for _, d := range definitions {
+		for name := range d.Parameters {
+			names[name] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(names))
+}
+
+func applyOverrides(base *persistence.ConfigDefinition, override persistence.ConfigDefinition) error {
 	if override.Name != nil {
 		base.Name = override.Name
 	}
@@ -167,8 +250,27 @@ func applyOverrides(base *persistence.ConfigDefinition, override persistence.Con
 		base.OriginObjectId = override.OriginObjectId
 	}
 
-	maps.Copy(base.Parameters, override.Parameters)
+	if override.RenderMode != "" {
+		base.RenderMode = override.RenderMode
+	}
+
+	if override.MergePatch != nil {
+		base.MergePatch = override.MergePatch
+	}
 
+	if override.JSONPatch != nil {
+		base.JSONPatch = override.JSONPatch
+	}
+
+	for name, param := range override.Parameters {
+		merged, err := mergeParameter(base.Parameters[name], param)
+		if err != nil {
+			return fmt.Errorf("failed to apply override of parameter `%s`: %w", name, err)
+		}
+		base.Parameters[name] = merged
+	}
+
+	return nil
 }
 
 func getConfigFromDefinition(
@@ -178,6 +280,7 @@ func getConfigFromDefinition(
 	environment manifest.EnvironmentDefinition,
 	definition persistence.ConfigDefinition,
 	configType persistence.TypeDefinition,
+	schemas config.ParameterSchemas,
 ) (config.Config, []error) {
 
 	if definition.Template == "" {
@@ -186,7 +289,8 @@ func getConfigFromDefinition(
 		}
 	}
 
-	tmpl, err := template.NewFileTemplate(fs, filepath.Join(context.Folder, definition.Template))
+	partials := template.NewPartials(fs, filepath.Join(context.LoaderContext.Path, template.PartialsDirectory))
+	tmpl, err := template.NewFileTemplateWithPartials(fs, filepath.Join(context.Folder, definition.Template), partials)
 
 	var errs []error
 
@@ -194,6 +298,16 @@ func getConfigFromDefinition(
 		errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("error while loading template: `%s`", err)))
 	}
 
+	var renderMode template.RenderMode
+	if definition.RenderMode != "" {
+		var renderModeErr error
+		if renderMode, renderModeErr = template.ParseRenderMode(definition.RenderMode); renderModeErr != nil {
+			errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, renderModeErr.Error()))
+		} else if renderMode == template.RenderModeJSON && files.IsYamlFileExtension(definition.Template) {
+			errs = append(errs, newDetailedDefinitionParserError(configId, context, environment, fmt.Sprintf("render mode `%s` is not supported for YAML templates", renderMode)))
+		}
+	}
+
 	parameters, parameterErrors := parseParametersAndReferences(fs, context, environment, configId,
 		definition.Parameters)
 
@@ -258,6 +372,10 @@ func getConfigFromDefinition(
 		parameters[config.InsertAfterParameter] = insertAfterParam
 	}
 
+	if errs := applyParameterSchemas(context, configId, environment, schemas, parameters); errs != nil {
+		return config.Config{}, errs
+	}
+
 	return config.Config{
 		Template: tmpl,
 		Coordinate: coordinate.Coordinate{
@@ -265,12 +383,16 @@ func getConfigFromDefinition(
 			Type:     context.Type,
 			ConfigId: configId,
 		},
-		Type:           configType.Type,
-		Group:          environment.Group,
-		Environment:    environment.Name,
-		Parameters:     parameters,
-		Skip:           skipConfig,
-		OriginObjectId: definition.OriginObjectId,
+		Type:              configType.Type,
+		Group:             environment.Group,
+		Environment:       environment.Name,
+		Parameters:        parameters,
+		Skip:              skipConfig,
+		OriginObjectId:    definition.OriginObjectId,
+		ParameterSchemas:  schemas,
+		RenderMode:        renderMode,
+		EnvironmentURL:    environment.URL.Value,
+		ManifestVariables: environment.Variables,
 	}, nil
 }
 
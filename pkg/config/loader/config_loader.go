@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/afero"
 	"go.yaml.in/yaml/v2"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/account/persistence/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
@@ -41,6 +42,13 @@ type LoaderContext struct {
 	Environments    manifest.Environments
 	KnownApis       map[string]struct{}
 	ParametersSerDe map[string]parameter.ParameterSerDe
+	// ConfigDefinitions are used to resolve the configs that loaded configs extend.
+	// If nil, only the configs of the loaded file can be extended.
+	ConfigDefinitions *ConfigDefinitions
+	// Defaults are the default parameters of the project. If nil, the project does not define defaults.
+	Defaults *ProjectDefaults
+	// Values are the parameter values defined in values files. If nil, no values files are used.
+	Values *Values
 }
 
 // configFileLoaderContext is a context for each config-file
@@ -93,6 +101,13 @@ func LoadConfigFile(ctx context.Context, fs afero.Fs, context *LoaderContext, fi
 		return nil, []error{newLoadError(filePath, err)}
 	}
 
+	if context.ConfigDefinitions == nil {
+		fileContext := *context
+		fileContext.ConfigDefinitions = NewConfigDefinitions()
+		fileContext.ConfigDefinitions.add(context.ProjectId, filePath, loadedConfigEntries)
+		context = &fileContext
+	}
+
 	configLoaderContext := &configFileLoaderContext{
 		LoaderContext: context,
 		Folder:        filepath.Dir(filePath),
@@ -120,6 +135,52 @@ func LoadConfigFile(ctx context.Context, fs afero.Fs, context *LoaderContext, fi
 	return configs, nil
 }
 
+// ReferencedYAMLTemplates returns the paths of all YAML templates referenced by the configs defined in the given file.
+// As YAML templates share their file extension with config files, they need to be excluded when loading config files.
+// A file that can not be parsed as config file does not reference any templates.
+func ReferencedYAMLTemplates(fs afero.Fs, filePath string) []string {
+	return referencedTemplates(fs, filePath, files.IsYamlFileExtension)
+}
+
+// ReferencedJSONTemplates returns the paths of all JSON templates referenced by the configs defined in the given file.
+// A file that can not be parsed as config file does not reference any templates.
+func ReferencedJSONTemplates(fs afero.Fs, filePath string) []string {
+	return referencedTemplates(fs, filePath, func(templatePath string) bool {
+		return filepath.Ext(templatePath) == ".json"
+	})
+}
+
+func referencedTemplates(fs afero.Fs, filePath string, include func(templatePath string) bool) []string {
+	data, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil
+	}
+
+	definition := persistence.TopLevelDefinition{}
+	if err := yaml.Unmarshal(data, &definition); err != nil {
+		return nil
+	}
+
+	var templates []string
+	add := func(templatePath string) {
+		if include(templatePath) {
+			templates = append(templates, filepath.Join(filepath.Dir(filePath), filepath.FromSlash(templatePath)))
+		}
+	}
+
+	for _, c := range definition.Configs {
+		add(c.Config.Template)
+		for _, o := range c.GroupOverrides {
+			add(o.Override.Template)
+		}
+		for _, o := range c.EnvironmentOverrides {
+			add(o.Override.Template)
+		}
+	}
+
+	return templates
+}
+
 func loadConfigDefinitions(data []byte) ([]persistence.TopLevelConfigDefinition, error) {
 
 	definition := persistence.TopLevelDefinition{}
@@ -19,6 +19,7 @@
 package loader
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -26,16 +27,20 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/compound"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
 	ref "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/variable"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 )
@@ -200,9 +205,10 @@ configs:
 					Parameters: config.Parameters{
 						"name": &value.ValueParameter{Value: "Star Trek Service"},
 					},
-					Skip:        true,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           true,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
@@ -235,9 +241,10 @@ configs:
 					Parameters: config.Parameters{
 						"name": &value.ValueParameter{Value: "Star Trek Service"},
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
@@ -271,9 +278,10 @@ configs:
 					Parameters: config.Parameters{
 						"name": &value.ValueParameter{Value: "Star Trek Service"},
 					},
-					Skip:        true,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           true,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
@@ -306,9 +314,10 @@ configs:
 					Parameters: config.Parameters{
 						"name": &value.ValueParameter{Value: "Star Trek Service"},
 					},
-					Skip:        true,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           true,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
@@ -406,6 +415,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -447,6 +457,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -490,6 +501,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -535,6 +547,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -576,6 +589,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -620,6 +634,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -682,6 +697,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -721,6 +737,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -763,6 +780,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -825,6 +843,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -868,6 +887,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -957,6 +977,7 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "origin-object-id",
 				},
@@ -989,9 +1010,10 @@ configs:
 					Parameters: config.Parameters{
 						config.NameParameter: &value.ValueParameter{Value: "Star Trek > Star Wars"},
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
@@ -1022,9 +1044,10 @@ configs:
 					Parameters: config.Parameters{
 						config.NameParameter: &value.ValueParameter{Value: "Star Trek > Star Wars"},
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
@@ -1055,9 +1078,10 @@ configs:
 					Parameters: config.Parameters{
 						config.NameParameter: &value.ValueParameter{Value: "Star Trek > Star Wars"},
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
@@ -1249,777 +1273,2085 @@ configs:
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
 					OriginObjectId: "better-origin-object-id",
 				},
 			},
 		},
 		{
-			name:             "reports error if some-api API is missing name",
+			name:             "deep merges structured value parameter overrides",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
 - id: profile-id
   config:
+    name: 'Star Trek Service'
     template: 'profile.json'
+    parameters:
+      rule:
+        type: value
+        value:
+          enabled: true
+          condition:
+            threshold: 10
+            operator: ABOVE
+          tags: [a, b]
   type: some-api
-`,
-			wantErrorsContain: []string{"missing parameter `name`"},
-		},
-		{
-			name:             "dashboard-share-settings do not require a name",
-			filePathArgument: "test-file.yaml",
-			filePathOnDisk:   "test-file.yaml",
-			fileContentOnDisk: `
-configs:
-- id: profile-id
-  config:
-    template: 'profile.json'
-  type:
-    api:
-      name: dashboard-share-settings
-      scope:
-        configId: 12345678-1234-1234-1234-123456789012
-        configType: dashboard
-        property: id
-        type: reference`,
+  groupOverrides:
+    - group: default
+      override:
+        parameters:
+          rule:
+            type: value
+            merge: deep
+            value:
+              condition:
+                threshold: 20
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        parameters:
+          rule:
+            type: value
+            merge: deep
+            value:
+              tags: [c]`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "dashboard-share-settings",
+						Type:     "some-api",
 						ConfigId: "profile-id",
 					},
-					Type: config.ClassicApiType{
-						Api: "dashboard-share-settings",
-					},
+					Type:     config.ClassicApiType{Api: "some-api"},
 					Template: template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						config.ScopeParameter: ref.New("project", "dashboard", "12345678-1234-1234-1234-123456789012", "id")},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+						"name": &value.ValueParameter{Value: "Star Trek Service"},
+						"rule": &value.ValueParameter{Value: map[string]any{
+							"enabled": true,
+							"condition": map[string]any{
+								"threshold": 20,
+								"operator":  "ABOVE",
+							},
+							"tags": []any{"c"},
+						}},
+					},
+					DeclaredParameters: []string{"rule"},
+					Skip:               false,
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
 				},
 			},
 		},
 		{
-			name:             "Settings do not require a name",
+			name:             "reports error for unknown merge strategy",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
 - id: profile-id
   config:
+    name: 'Star Trek Service'
     template: 'profile.json'
-  type:
-    settings:
-      schema: 'builtin:profile.test'
-      scope: 'environment'
-`,
-			wantConfigs: []config.Config{
-				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "builtin:profile.test",
-						ConfigId: "profile-id",
-					},
-					Type: config.SettingsType{
-						SchemaId: "builtin:profile.test",
-					},
-					Template: template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters: config.Parameters{
-						config.ScopeParameter: &value.ValueParameter{Value: "environment"},
-					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
-				},
-			},
+    parameters:
+      rule:
+        type: value
+        value:
+          enabled: true
+  type: some-api
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        parameters:
+          rule:
+            type: value
+            merge: shallow
+            value:
+              enabled: false`,
+			wantErrorsContain: []string{"unknown merge strategy `shallow`"},
 		},
 		{
-			name:             "Automations do not require a name",
+			name:             "reports error for deep merge of non-value parameter",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
 - id: profile-id
   config:
+    name: 'Star Trek Service'
     template: 'profile.json'
-  type:
-    automation:
-      resource: workflow
-`,
+  type: some-api
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        parameters:
+          rule:
+            type: environment
+            merge: deep
+            name: SOME_VAR`,
+			wantErrorsContain: []string{"only supported for parameters of type `value`"},
+		},
+		{
+			name:             "extends config in the same file",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: base
+  config:
+    name: 'Base'
+    template: 'profile.json'
+    originObjectId: 'base-object'
+    parameters:
+      threshold: 10
+      severity: ERROR
+  type: some-api
+- id: derived
+  extends: base
+  config:
+    name: 'Derived'
+    parameters:
+      threshold: 20`,
 			wantConfigs: []config.Config{
 				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "workflow",
-						ConfigId: "profile-id",
-					},
-					Type: config.AutomationType{
-						Resource: config.Workflow,
-					},
-					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters:  config.Parameters{},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Coordinate:     coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "base"},
+					Type:           config.ClassicApiType{Api: "some-api"},
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					OriginObjectId: "base-object",
+					Parameters: config.Parameters{
+						"name":      &value.ValueParameter{Value: "Base"},
+						"threshold": &value.ValueParameter{Value: 10},
+						"severity":  &value.ValueParameter{Value: "ERROR"},
+					},
+					DeclaredParameters: []string{"severity", "threshold"},
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
+				},
+				{
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "derived"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":      &value.ValueParameter{Value: "Derived"},
+						"threshold": &value.ValueParameter{Value: 20},
+						"severity":  &value.ValueParameter{Value: "ERROR"},
+					},
+					DeclaredParameters: []string{"severity", "threshold"},
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
 				},
 			},
 		},
 		{
-			name:             "Bucket config",
+			name:             "extends inherits and extends overrides of the base config",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: base
   config:
+    name: 'Base'
     template: 'profile.json'
-  type: bucket
-`,
+    skip: true
+  type: some-api
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        skip: false
+        parameters:
+          threshold: 10
+- id: derived
+  extends: base
+  config:
+    name: 'Derived'
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        parameters:
+          severity: ERROR`,
 			wantConfigs: []config.Config{
 				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "bucket",
-						ConfigId: "profile-id",
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "base"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":      &value.ValueParameter{Value: "Base"},
+						"threshold": &value.ValueParameter{Value: 10},
 					},
-					Type:        config.BucketType{},
-					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters:  config.Parameters{},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					DeclaredParameters: []string{"threshold"},
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
+				},
+				{
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "derived"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name":      &value.ValueParameter{Value: "Derived"},
+						"threshold": &value.ValueParameter{Value: 10},
+						"severity":  &value.ValueParameter{Value: "ERROR"},
+					},
+					DeclaredParameters: []string{"severity", "threshold"},
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
 				},
 			},
 		},
 		{
-			name:             "Bucket written as api config",
+			name:             "reports error if extended config does not exist",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: derived
+  extends: base
   config:
-    template: 'profile.json'
-  type:
-    api: bucket
-`,
-			wantErrorsContain: []string{"unknown API: bucket"},
+    name: 'Derived'
+  type: some-api`,
+			wantErrorsContain: []string{"config `base` does not exist"},
 		},
 		{
-			name:             "segment config",
+			name:             "reports error for extends cycle",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: a
+  extends: b
   config:
+    name: 'A'
     template: 'profile.json'
-  type: segment
-`,
-			wantConfigs: []config.Config{
-				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "segment",
-						ConfigId: "profile-id",
-					},
-					Type:        config.Segment{},
-					Template:    template.NewInMemoryTemplate("segment.json", "{}"),
-					Parameters:  config.Parameters{},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
-				},
+  type: some-api
+- id: b
+  extends: a
+  config:
+    name: 'B'
+  type: some-api`,
+			wantErrorsContain: []string{
+				"extends cycle detected: project:a -> project:b -> project:a",
+				"extends cycle detected: project:b -> project:a -> project:b",
 			},
 		},
 		{
-			name:             "segment written as api config",
+			name:             "reports error if extended coordinate has a different type",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: base
   config:
+    name: 'Base'
     template: 'profile.json'
-  type:
-    api: segment
-`,
-			wantErrorsContain: []string{"unknown API: segment"},
+  type: some-api
+- id: derived
+  extends: project:other-api:base
+  config:
+    name: 'Derived'
+  type: some-api`,
+			wantErrorsContain: []string{"config `project:other-api:base` does not exist: config `base` of project `project` is not of type `other-api`"},
 		},
 		{
-			name:             "SLO config",
+			name:             "expands forEach with inline list of maps",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: slo-config-id
+- id: monitor
+  forEach:
+    - key: checkout
+      host: checkout.example.com
+    - key: cart
+      host: cart.example.com
   config:
+    name: 'Monitor'
     template: 'profile.json'
-  type: slo-v2
-`,
+  type: some-api`,
 			wantConfigs: []config.Config{
 				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "slo-v2",
-						ConfigId: "slo-config-id",
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "monitor-checkout"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name": &value.ValueParameter{Value: "Monitor"},
+						"item": &value.ValueParameter{Value: map[string]any{"key": "checkout", "host": "checkout.example.com"}},
+					},
+					DeclaredParameters: []string{"item"},
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
+				},
+				{
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "monitor-cart"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name": &value.ValueParameter{Value: "Monitor"},
+						"item": &value.ValueParameter{Value: map[string]any{"key": "cart", "host": "cart.example.com"}},
 					},
-					Type:        config.ServiceLevelObjective{},
-					Template:    template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters:  config.Parameters{},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					DeclaredParameters: []string{"item"},
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
 				},
 			},
 		},
 		{
-			name:             "SLO written as api config",
-			filePathArgument: "test-file.yaml",
-			filePathOnDisk:   "test-file.yaml",
-			fileContentOnDisk: `
-configs:
-- id: slo-config-id
-  config:
-    template: 'profile.json'
-  type:
-    api: slo-v2
-`,
-			wantErrorsContain: []string{"unknown API: slo"},
-		},
-		{
-			name:             "API without scope",
+			name:             "expands forEach with list parameter",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: slo
+  forEach:
+    type: list
+    values: [checkout]
   config:
-    name: 'Star Trek > Star Wars'
+    name: 'SLO'
     template: 'profile.json'
-  type:
-    api:
-      name: 'some-api'
-`,
+  type: some-api`,
 			wantConfigs: []config.Config{
 				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "some-api",
-						ConfigId: "profile-id",
-					},
-					Type: config.ClassicApiType{
-						Api: "some-api",
-					},
-					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "slo-checkout"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						"name": &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						"name": &value.ValueParameter{Value: "SLO"},
+						"item": &value.ValueParameter{Value: "checkout"},
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					DeclaredParameters: []string{"item"},
+					Environment:        "env name",
+					EnvironmentURL:     "env url",
+					Group:              "default",
 				},
 			},
 		},
 		{
-			name:             "API with invalid structure",
+			name:             "reports error for forEach map item without key",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: monitor
+  forEach:
+    - host: checkout.example.com
   config:
-    name: 'Star Trek > Star Wars'
+    name: 'Monitor'
     template: 'profile.json'
-  type:
-    api:
-    - name: 'some-api'
-`,
-			wantErrorsContain: []string{"failed to unmarshal api-type"},
+  type: some-api`,
+			wantErrorsContain: []string{"invalid `forEach` item 0: map items require a `key` property"},
 		},
 		{
-			name:             "loads complex api config with a full shorthand reference as scope",
+			name:             "reports error for duplicate forEach keys",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: monitor
+  forEach: [a, b, a]
   config:
-    name: 'Star Trek > Star Wars'
+    name: 'Monitor'
     template: 'profile.json'
-    originObjectId: origin-object-id
-  type:
-    api:
-      name: some-api
-      scope: ["proj2", "something", "configId", "id"]`,
-			wantConfigs: []config.Config{
-				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "some-api",
-						ConfigId: "profile-id",
-					},
-					Type: config.ClassicApiType{
-						Api: "some-api",
-					},
-					Template: template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters: config.Parameters{
-						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
-						config.ScopeParameter: ref.New("proj2", "something", "configId", "id"),
-					},
-					Skip:           false,
-					Environment:    "env name",
-					Group:          "default",
-					OriginObjectId: "origin-object-id",
-				},
-			},
+  type: some-api`,
+			wantErrorsContain: []string{"invalid `forEach` item 2: duplicate key `a`"},
 		},
 		{
-			name:             "loads complex api config with the reference parameter",
+			name:             "reports error for forEach keys that can not be part of a config ID",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: profile-id
+- id: monitor
+  forEach: [ok, "with space", "with:colon"]
   config:
-    name: 'Star Trek > Star Wars'
+    name: 'Monitor'
     template: 'profile.json'
-    originObjectId: origin-object-id
-  type:
-    api:
-      name: some-api
-      scope:
-        type: reference
-        configId: configId
-        property: id
-        configType: something`,
+  type: some-api`,
+			wantErrorsContain: []string{"invalid `forEach` item 1: key `with space` can not be part of a config ID"},
+		},
+		{
+			name:             "reports error for forEach item parameter",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: monitor
+  forEach: [a]
+  config:
+    name: 'Monitor'
+    template: 'profile.json'
+    parameters:
+      item: b
+  type: some-api`,
+			wantErrorsContain: []string{"parameter `item` is reserved for the items of `forEach`"},
+		},
+		{
+			name:             "reports error for forEach item parameter in group overrides",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: monitor
+  forEach: [a]
+  config:
+    name: 'Monitor'
+    template: 'profile.json'
+  type: some-api
+  groupOverrides:
+    - group: default
+      override:
+        parameters:
+          item: b`,
+			wantErrorsContain: []string{"parameter `item` is reserved for the items of `forEach`"},
+		},
+		{
+			name:             "reports error for forEach item parameter in environment overrides",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: monitor
+  forEach: [a]
+  config:
+    name: 'Monitor'
+    template: 'profile.json'
+  type: some-api
+  environmentOverrides:
+    - environment: "env name"
+      override:
+        parameters:
+          item: b`,
+			wantErrorsContain: []string{"parameter `item` is reserved for the items of `forEach`"},
+		},
+		{
+			name:             "loads previous IDs and coordinates",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  previousIds: [old-profile]
+  previousCoordinates:
+  - {project: other, configId: profile}
+  - {project: other, configType: other-api, configId: profile}
+  config:
+    name: 'Profile'
+    template: 'profile.json'
+  type: some-api`,
 			wantConfigs: []config.Config{
 				{
-					Coordinate: coordinate.Coordinate{
-						Project:  "project",
-						Type:     "some-api",
-						ConfigId: "profile-id",
-					},
-					Type: config.ClassicApiType{
-						Api: "some-api",
-					},
-					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "profile"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
-						config.ScopeParameter: ref.New("project", "something", "configId", "id"),
+						"name": &value.ValueParameter{Value: "Profile"},
+					},
+					PreviousCoordinates: []coordinate.Coordinate{
+						{Project: "project", Type: "some-api", ConfigId: "old-profile"},
+						{Project: "other", Type: "some-api", ConfigId: "profile"},
+						{Project: "other", Type: "other-api", ConfigId: "profile"},
 					},
-					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
-					OriginObjectId: "origin-object-id",
 				},
 			},
 		},
 		{
-			name:             "loads complex api config environment parameter",
+			name:             "expands previous IDs of forEach",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: slo
+  previousIds: [old-slo]
+  forEach: [checkout]
+  config:
+    name: 'SLO'
+    template: 'profile.json'
+  type: some-api`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "slo-checkout"},
+					Type:       config.ClassicApiType{Api: "some-api"},
+					Template:   template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						"name": &value.ValueParameter{Value: "SLO"},
+						"item": &value.ValueParameter{Value: "checkout"},
+					},
+					DeclaredParameters:  []string{"item"},
+					PreviousCoordinates: []coordinate.Coordinate{{Project: "project", Type: "some-api", ConfigId: "old-slo-checkout"}},
+					Environment:         "env name",
+					EnvironmentURL:      "env url",
+					Group:               "default",
+				},
+			},
+		},
+		{
+			name:             "reports error for previous ID equal to the config ID",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile
+  previousIds: [profile]
+  config:
+    name: 'Profile'
+    template: 'profile.json'
+  type: some-api`,
+			wantErrorsContain: []string{"previous coordinate project:some-api:profile must differ from the coordinate of the config"},
+		},
+		{
+			name:             "reports error if forEach is not a list",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: monitor
+  forEach:
+    type: value
+    value: a
+  config:
+    name: 'Monitor'
+    template: 'profile.json'
+  type: some-api`,
+			wantErrorsContain: []string{"`forEach` must be a list or a parameter of type `list`, not `value`"},
+		},
+		{
+			name:             "reports error for environment override with multiple selectors",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek Service'
+    template: 'profile.json'
+  type: some-api
+  environmentOverrides:
+    - environment: "env name"
+      labels:
+        region: eu
+      override:
+        skip: true`,
+			wantErrorsContain: []string{"environment override must define exactly one of `environment`, `environmentRegex` or `labels`"},
+		},
+		{
+			name:             "reports error for invalid environment regex",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek Service'
+    template: 'profile.json'
+  type: some-api
+  environmentOverrides:
+    - environmentRegex: "env("
+      override:
+        skip: true`,
+			wantErrorsContain: []string{"invalid `environmentRegex` \"env(\""},
+		},
+		{
+			name:             "reports error if some-api API is missing name",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+  type: some-api
+`,
+			wantErrorsContain: []string{"missing parameter `name`"},
+		},
+		{
+			name:             "dashboard-share-settings do not require a name",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
 - id: profile-id
   config:
-    name: 'Star Trek > Star Wars'
     template: 'profile.json'
-    originObjectId: origin-object-id
   type:
     api:
-      name: some-api
+      name: dashboard-share-settings
       scope:
-        type: environment
-        name: TEST`,
+        configId: 12345678-1234-1234-1234-123456789012
+        configType: dashboard
+        property: id
+        type: reference`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "some-api",
+						Type:     "dashboard-share-settings",
 						ConfigId: "profile-id",
 					},
 					Type: config.ClassicApiType{
-						Api: "some-api",
+						Api: "dashboard-share-settings",
 					},
 					Template: template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
-						config.ScopeParameter: &environment.EnvironmentVariableParameter{Name: "TEST"},
-					},
+						config.ScopeParameter: ref.New("project", "dashboard", "12345678-1234-1234-1234-123456789012", "id")},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
-					OriginObjectId: "origin-object-id",
 				},
 			},
 		},
 		{
-			name:             "loads complex api config with a value scope",
+			name:             "Settings do not require a name",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
 - id: profile-id
   config:
-    name: 'Star Trek > Star Wars'
     template: 'profile.json'
-    originObjectId: origin-object-id
   type:
-    api:
-      name: some-api
-      scope:
-        type: value
-        value: var`,
+    settings:
+      schema: 'builtin:profile.test'
+      scope: 'environment'
+`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "some-api",
+						Type:     "builtin:profile.test",
 						ConfigId: "profile-id",
 					},
-					Type: config.ClassicApiType{
-						Api: "some-api",
+					Type: config.SettingsType{
+						SchemaId: "builtin:profile.test",
 					},
 					Template: template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
-						config.ScopeParameter: &value.ValueParameter{Value: "var"},
+						config.ScopeParameter: &value.ValueParameter{Value: "environment"},
 					},
 					Skip:           false,
 					Environment:    "env name",
+					EnvironmentURL: "env url",
 					Group:          "default",
-					OriginObjectId: "origin-object-id",
 				},
 			},
 		},
 		{
-			name:             "loads complex api config with an invalid value scope",
+			name:             "Automations do not require a name",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
 - id: profile-id
   config:
-    name: 'Star Trek > Star Wars'
-    template: 'profile.json'
-    originObjectId: origin-object-id
-  type:
-    api:
-      name: some-api
-      scope:
-        type: value
-        wrong-field: var`,
-			wantErrorsContain: []string{"missing property"},
-		},
-		{
-			name:             "Document dashboard config",
-			filePathArgument: "test-file.yaml",
-			filePathOnDisk:   "test-file.yaml",
-			fileContentOnDisk: `
-configs:
-- id: dashboard-id
-  config:
-    name: Test dashboard
-    originObjectId: ext-ID-123
     template: 'profile.json'
   type:
-    document:
-      kind: dashboard`,
+    automation:
+      resource: workflow
+`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "document",
-						ConfigId: "dashboard-id",
+						Type:     "workflow",
+						ConfigId: "profile-id",
 					},
-					OriginObjectId: "ext-ID-123",
-					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false},
-					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters: config.Parameters{
-						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+					Type: config.AutomationType{
+						Resource: config.Workflow,
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters:     config.Parameters{},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
 		{
-			name:             "Document dashboard config with custom ID",
+			name:             "Bucket config",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: dashboard-id
+- id: profile-id
   config:
-    name: Test dashboard
-    originObjectId: custom-id
     template: 'profile.json'
-  type:
-    document:
-      id: "custom-id"
-      kind: dashboard`,
+  type: bucket
+`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "document",
-						ConfigId: "dashboard-id",
+						Type:     "bucket",
+						ConfigId: "profile-id",
 					},
-					OriginObjectId: "custom-id",
-					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false, CustomID: "custom-id"},
+					Type:           config.BucketType{},
 					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters: config.Parameters{
-						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
-					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Parameters:     config.Parameters{},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
 		{
-			name:             "Document dashboard config with labels",
+			name:             "Bucket written as api config",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: dashboard-id
+- id: profile-id
   config:
-    name: Test dashboard
-    originObjectId: ext-ID-123
     template: 'profile.json'
   type:
-    document:
-      kind: dashboard
-      labels:
-        - team:foo
-        - env:prod`,
+    api: bucket
+`,
+			wantErrorsContain: []string{"unknown API: bucket"},
+		},
+		{
+			name:             "segment config",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    template: 'profile.json'
+  type: segment
+`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "document",
-						ConfigId: "dashboard-id",
-					},
-					OriginObjectId: "ext-ID-123",
-					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false, Labels: []string{"team:foo", "env:prod"}},
-					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters: config.Parameters{
-						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+						Type:     "segment",
+						ConfigId: "profile-id",
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Type:           config.Segment{},
+					Template:       template.NewInMemoryTemplate("segment.json", "{}"),
+					Parameters:     config.Parameters{},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
 		{
-			name:             "Document dashboard config with description",
+			name:             "segment written as api config",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: dashboard-id
+- id: profile-id
   config:
-    name: Test dashboard
-    originObjectId: ext-ID-123
     template: 'profile.json'
   type:
-    document:
-      kind: dashboard
-      description: my description`,
+    api: segment
+`,
+			wantErrorsContain: []string{"unknown API: segment"},
+		},
+		{
+			name:             "SLO config",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: slo-config-id
+  config:
+    template: 'profile.json'
+  type: slo-v2
+`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "document",
-						ConfigId: "dashboard-id",
+						Type:     "slo-v2",
+						ConfigId: "slo-config-id",
 					},
-					OriginObjectId: "ext-ID-123",
-					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false, Description: new("my description")},
+					Type:           config.ServiceLevelObjective{},
 					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
-					Parameters: config.Parameters{
-						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
-					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Parameters:     config.Parameters{},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
 		{
-			name:             "Document private dashboard config",
+			name:             "SLO written as api config",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: dashboard-id
+- id: slo-config-id
   config:
-    name: Test dashboard
-    originObjectId: ext-ID-123
     template: 'profile.json'
   type:
-    document:
-      kind: dashboard
-      private: true`,
+    api: slo-v2
+`,
+			wantErrorsContain: []string{"unknown API: slo"},
+		},
+		{
+			name:             "API without scope",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+  type:
+    api:
+      name: 'some-api'
+`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "document",
-						ConfigId: "dashboard-id",
+						Type:     "some-api",
+						ConfigId: "profile-id",
 					},
-					OriginObjectId: "ext-ID-123",
-					Type:           config.DocumentType{Kind: config.DashboardKind, Private: true},
-					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+						"name": &value.ValueParameter{Value: "Star Trek > Star Wars"},
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
 				},
 			},
 		},
 		{
-			name:             "Document notebook config",
+			name:             "API with invalid structure",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: notebook-id
+- id: profile-id
   config:
-    name: Test notebook
-    originObjectId: ext-ID-123
+    name: 'Star Trek > Star Wars'
     template: 'profile.json'
   type:
-    document:
-      kind: notebook`,
+    api:
+    - name: 'some-api'
+`,
+			wantErrorsContain: []string{"failed to unmarshal api-type"},
+		},
+		{
+			name:             "loads complex api config with a full shorthand reference as scope",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    originObjectId: origin-object-id
+  type:
+    api:
+      name: some-api
+      scope: ["proj2", "something", "configId", "id"]`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "document",
-						ConfigId: "notebook-id",
+						Type:     "some-api",
+						ConfigId: "profile-id",
 					},
-					OriginObjectId: "ext-ID-123",
-					Type:           config.DocumentType{Kind: config.NotebookKind},
-					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						config.NameParameter: &value.ValueParameter{Value: "Test notebook"},
+						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: ref.New("proj2", "something", "configId", "id"),
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+					OriginObjectId: "origin-object-id",
 				},
 			},
 		},
 		{
-			name:             "Document config with invalid type",
+			name:             "loads complex api config with the reference parameter",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: dashboard-id
+- id: profile-id
   config:
-    name: Test document
-    originObjectId: ext-ID-123
+    name: 'Star Trek > Star Wars'
     template: 'profile.json'
+    originObjectId: origin-object-id
   type:
-    document:
-      kind: other`,
-			wantErrorsContain: []string{
-				"unknown document kind \"other\"",
+    api:
+      name: some-api
+      scope:
+        type: reference
+        configId: configId
+        property: id
+        configType: something`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "some-api",
+						ConfigId: "profile-id",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: ref.New("project", "something", "configId", "id"),
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+					OriginObjectId: "origin-object-id",
+				},
 			},
 		},
 		{
-			name:             "OpenPipeline config",
+			name:             "loads complex api config environment parameter",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: bizevents-openpipeline-id
+- id: profile-id
   config:
-    name: Test Bizevents OpenPipeline
+    name: 'Star Trek > Star Wars'
     template: 'profile.json'
+    originObjectId: origin-object-id
   type:
-    openpipeline:
-      kind: bizevents`,
+    api:
+      name: some-api
+      scope:
+        type: environment
+        name: TEST`,
 			wantConfigs: []config.Config{
 				{
 					Coordinate: coordinate.Coordinate{
 						Project:  "project",
-						Type:     "openpipeline",
-						ConfigId: "bizevents-openpipeline-id",
+						Type:     "some-api",
+						ConfigId: "profile-id",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
 					},
-					Type:     config.OpenPipelineType{Kind: "bizevents"},
 					Template: template.NewInMemoryTemplate("profile.json", "{}"),
 					Parameters: config.Parameters{
-						config.NameParameter: &value.ValueParameter{Value: "Test Bizevents OpenPipeline"},
+						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &environment.EnvironmentVariableParameter{Name: "TEST"},
 					},
-					Skip:        false,
-					Environment: "env name",
-					Group:       "default",
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+					OriginObjectId: "origin-object-id",
 				},
 			},
 		},
 		{
-			name:             "OpenPipeline config with missing kind",
+			name:             "loads complex api config with a value scope",
 			filePathArgument: "test-file.yaml",
 			filePathOnDisk:   "test-file.yaml",
 			fileContentOnDisk: `
 configs:
-- id: bizevents-openpipeline-id
+- id: profile-id
   config:
-    name: Test Bizevents OpenPipeline
+    name: 'Star Trek > Star Wars'
     template: 'profile.json'
+    originObjectId: origin-object-id
   type:
-    openpipeline:`,
-			wantErrorsContain: []string{
-				"missing openpipeline kind property",
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-
-			for k, v := range tt.envVars {
-				t.Setenv(k, v)
-			}
-
-			testFs := afero.NewMemMapFs()
-			_ = afero.WriteFile(testFs, tt.filePathOnDisk, []byte(tt.fileContentOnDisk), 0644)
-			_ = afero.WriteFile(testFs, "profile.json", []byte("{}"), 0644)
-
-			gotConfigs, gotErrs := LoadConfigFile(t.Context(), testFs, testLoaderContext, tt.filePathArgument)
-			if len(tt.wantErrorsContain) != 0 {
-				assert.Equal(t, len(tt.wantErrorsContain), len(gotErrs), "expected %v errors but got %v", len(tt.wantErrorsContain), len(gotErrs))
-
-				for i, err := range gotErrs {
-					assert.ErrorContains(t, err, tt.wantErrorsContain[i])
-				}
+    api:
+      name: some-api
+      scope:
+        type: value
+        value: var`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "some-api",
+						ConfigId: "profile-id",
+					},
+					Type: config.ClassicApiType{
+						Api: "some-api",
+					},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter:  &value.ValueParameter{Value: "Star Trek > Star Wars"},
+						config.ScopeParameter: &value.ValueParameter{Value: "var"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+					OriginObjectId: "origin-object-id",
+				},
+			},
+		},
+		{
+			name:             "loads complex api config with an invalid value scope",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: profile-id
+  config:
+    name: 'Star Trek > Star Wars'
+    template: 'profile.json'
+    originObjectId: origin-object-id
+  type:
+    api:
+      name: some-api
+      scope:
+        type: value
+        wrong-field: var`,
+			wantErrorsContain: []string{"missing property"},
+		},
+		{
+			name:             "Document dashboard config",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    originObjectId: ext-ID-123
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "dashboard-id",
+					},
+					OriginObjectId: "ext-ID-123",
+					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false},
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+				},
+			},
+		},
+		{
+			name:             "Document dashboard config with custom ID",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    originObjectId: custom-id
+    template: 'profile.json'
+  type:
+    document:
+      id: "custom-id"
+      kind: dashboard`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "dashboard-id",
+					},
+					OriginObjectId: "custom-id",
+					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false, CustomID: "custom-id"},
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+				},
+			},
+		},
+		{
+			name:             "Document dashboard config with labels",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    originObjectId: ext-ID-123
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard
+      labels:
+        - team:foo
+        - env:prod`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "dashboard-id",
+					},
+					OriginObjectId: "ext-ID-123",
+					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false, Labels: []string{"team:foo", "env:prod"}},
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+				},
+			},
+		},
+		{
+			name:             "Document dashboard config with description",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    originObjectId: ext-ID-123
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard
+      description: my description`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "dashboard-id",
+					},
+					OriginObjectId: "ext-ID-123",
+					Type:           config.DocumentType{Kind: config.DashboardKind, Private: false, Description: new("my description")},
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+				},
+			},
+		},
+		{
+			name:             "Document private dashboard config",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test dashboard
+    originObjectId: ext-ID-123
+    template: 'profile.json'
+  type:
+    document:
+      kind: dashboard
+      private: true`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "dashboard-id",
+					},
+					OriginObjectId: "ext-ID-123",
+					Type:           config.DocumentType{Kind: config.DashboardKind, Private: true},
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test dashboard"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+				},
+			},
+		},
+		{
+			name:             "Document notebook config",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: notebook-id
+  config:
+    name: Test notebook
+    originObjectId: ext-ID-123
+    template: 'profile.json'
+  type:
+    document:
+      kind: notebook`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "document",
+						ConfigId: "notebook-id",
+					},
+					OriginObjectId: "ext-ID-123",
+					Type:           config.DocumentType{Kind: config.NotebookKind},
+					Template:       template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test notebook"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+				},
+			},
+		},
+		{
+			name:             "Document config with invalid type",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: dashboard-id
+  config:
+    name: Test document
+    originObjectId: ext-ID-123
+    template: 'profile.json'
+  type:
+    document:
+      kind: other`,
+			wantErrorsContain: []string{
+				"unknown document kind \"other\"",
+			},
+		},
+		{
+			name:             "OpenPipeline config",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: bizevents-openpipeline-id
+  config:
+    name: Test Bizevents OpenPipeline
+    template: 'profile.json'
+  type:
+    openpipeline:
+      kind: bizevents`,
+			wantConfigs: []config.Config{
+				{
+					Coordinate: coordinate.Coordinate{
+						Project:  "project",
+						Type:     "openpipeline",
+						ConfigId: "bizevents-openpipeline-id",
+					},
+					Type:     config.OpenPipelineType{Kind: "bizevents"},
+					Template: template.NewInMemoryTemplate("profile.json", "{}"),
+					Parameters: config.Parameters{
+						config.NameParameter: &value.ValueParameter{Value: "Test Bizevents OpenPipeline"},
+					},
+					Skip:           false,
+					Environment:    "env name",
+					EnvironmentURL: "env url",
+					Group:          "default",
+				},
+			},
+		},
+		{
+			name:             "OpenPipeline config with missing kind",
+			filePathArgument: "test-file.yaml",
+			filePathOnDisk:   "test-file.yaml",
+			fileContentOnDisk: `
+configs:
+- id: bizevents-openpipeline-id
+  config:
+    name: Test Bizevents OpenPipeline
+    template: 'profile.json'
+  type:
+    openpipeline:`,
+			wantErrorsContain: []string{
+				"missing openpipeline kind property",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			for k, v := range tt.envVars {
+				t.Setenv(k, v)
+			}
+
+			testFs := afero.NewMemMapFs()
+			_ = afero.WriteFile(testFs, tt.filePathOnDisk, []byte(tt.fileContentOnDisk), 0644)
+			_ = afero.WriteFile(testFs, "profile.json", []byte("{}"), 0644)
+
+			gotConfigs, gotErrs := LoadConfigFile(t.Context(), testFs, testLoaderContext, tt.filePathArgument)
+			if len(tt.wantErrorsContain) != 0 {
+				assert.Equal(t, len(tt.wantErrorsContain), len(gotErrs), "expected %v errors but got %v", len(tt.wantErrorsContain), len(gotErrs))
+
+				for i, err := range gotErrs {
+					assert.ErrorContains(t, err, tt.wantErrorsContain[i])
+				}
+				return
+			}
+			assert.Empty(t, gotErrs, "expected no errors but got: %v", gotErrs)
+
+			// compare template contents
+			assert.Empty(t, cmp.Diff(tt.wantConfigs, gotConfigs, cmp.Comparer(func(a, b template.Template) bool {
+				cA, _ := a.Content()
+				cA = strings.ReplaceAll(cA, " ", "")
+				cA = strings.ReplaceAll(cA, "\n", "")
+
+				cB, _ := b.Content()
+				cB = strings.ReplaceAll(cB, " ", "")
+				cB = strings.ReplaceAll(cB, "\n", "")
+
+				return assert.Empty(t, cmp.Diff(cA, cB))
+			})))
+		})
+	}
+}
+
+func TestLoadConfigFile_ExtendsConfigOfOtherProject(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "other/base/base.yaml", []byte(`
+configs:
+- id: base
+  config:
+    name: Base
+    template: templates/base.json
+    parameters:
+      threshold: 10
+  type: some-api`), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "other/base/templates/base.json", []byte(`{"threshold": {{.threshold}}}`), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/derived/derived.yaml", []byte(`
+configs:
+- id: derived
+  extends: other:some-api:base
+  config:
+    name: Derived`), 0644))
+
+	definitions := NewConfigDefinitions()
+	definitions.AddFile(testFs, "other", "other/base/base.yaml")
+	definitions.AddFile(testFs, "project", "project/derived/derived.yaml")
+
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "project",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"env": {Name: "env", Group: "default"},
+			},
+		},
+		ParametersSerDe:   config.DefaultParameterParsers,
+		ConfigDefinitions: definitions,
+	}
+
+	got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/derived/derived.yaml")
+	assert.Empty(t, errs)
+	assert.Len(t, got, 1)
+
+	assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "some-api", ConfigId: "derived"}, got[0].Coordinate)
+	assert.Equal(t, config.Parameters{
+		"name":      &value.ValueParameter{Value: "Derived"},
+		"threshold": &value.ValueParameter{Value: 10},
+	}, got[0].Parameters)
+
+	content, err := got[0].Template.Content()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"threshold": {{.threshold}}}`, content)
+}
+
+func TestLoadConfigFile_AppliesProjectDefaults(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "project/_defaults.yaml", []byte(`
+parameters:
+  team: platform
+  channel: general
+groupOverrides:
+  - group: prod
+    override:
+      parameters:
+        channel: alerts
+environmentOverrides:
+  - environment: prod-eu
+    override:
+      parameters:
+        team: platform-eu
+`), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.yaml", []byte(`
+configs:
+- id: profile
+  config:
+    name: Profile
+    template: profile.json
+    parameters:
+      channel: own-channel
+  type: some-api
+- id: other-profile
+  config:
+    name: Other Profile
+    template: profile.json
+  type: some-api`), 0644))
+
+	defaults, err := LoadProjectDefaults(testFs, "project")
+	assert.NoError(t, err)
+
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "project",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"prod-eu": {Name: "prod-eu", Group: "prod"},
+			},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+		Defaults:        defaults,
+	}
+
+	got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/profile.yaml")
+	assert.Empty(t, errs)
+	assert.Len(t, got, 2)
+
+	assert.Equal(t, config.Parameters{
+		"name":    &value.ValueParameter{Value: "Profile"},
+		"team":    &value.ValueParameter{Value: "platform-eu"},
+		"channel": &value.ValueParameter{Value: "own-channel"},
+	}, got[0].Parameters, "own parameters must take precedence over defaults")
+	assert.Equal(t, config.Parameters{
+		"name":    &value.ValueParameter{Value: "Other Profile"},
+		"team":    &value.ValueParameter{Value: "platform-eu"},
+		"channel": &value.ValueParameter{Value: "alerts"},
+	}, got[1].Parameters)
+	assert.Equal(t, []string{"channel"}, got[0].DeclaredParameters)
+	assert.Empty(t, got[1].DeclaredParameters)
+}
+
+func TestLoadProjectDefaults(t *testing.T) {
+	tests := []struct {
+		name              string
+		content           string
+		wantErrorsContain string
+	}{
+		{
+			name:    "loads parameters and overrides",
+			content: "parameters:\n  team: platform\ngroupOverrides:\n- group: prod\n  override:\n    parameters:\n      team: other",
+		},
+		{
+			name:              "rejects unknown properties",
+			content:           "configs:\n- id: profile",
+			wantErrorsContain: "field configs not found",
+		},
+		{
+			name:              "rejects reserved parameter names",
+			content:           "parameters:\n  name: some name",
+			wantErrorsContain: "parameter name `name` is not allowed (reserved)",
+		},
+		{
+			name:              "rejects overrides of anything but parameters",
+			content:           "environmentOverrides:\n- environment: prod\n  override:\n    template: other.json",
+			wantErrorsContain: "override of environment `prod`: defaults may only override `parameters`",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(testFs, "project/_defaults.yaml", []byte(tt.content), 0644))
+
+			got, err := LoadProjectDefaults(testFs, "project")
+			if tt.wantErrorsContain != "" {
+				assert.ErrorContains(t, err, tt.wantErrorsContain)
 				return
 			}
-			assert.Empty(t, gotErrs, "expected no errors but got: %v", gotErrs)
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
 
-			// compare template contents
-			assert.Empty(t, cmp.Diff(tt.wantConfigs, gotConfigs, cmp.Comparer(func(a, b template.Template) bool {
-				cA, _ := a.Content()
-				cA = strings.ReplaceAll(cA, " ", "")
-				cA = strings.ReplaceAll(cA, "\n", "")
+	t.Run("returns nil if project does not define defaults", func(t *testing.T) {
+		got, err := LoadProjectDefaults(afero.NewMemMapFs(), "project")
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+}
 
-				cB, _ := b.Content()
-				cB = strings.ReplaceAll(cB, " ", "")
-				cB = strings.ReplaceAll(cB, "\n", "")
+func TestLoadConfigFile_PatternAndLabelEnvironmentOverrides(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "profile.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "profile.yaml", []byte(`
+configs:
+- id: profile
+  config:
+    name: Profile
+    template: profile.json
+    parameters:
+      source: config
+  type: some-api
+  environmentOverrides:
+    - environment: prod-eu-1
+      override:
+        parameters:
+          source: exact
+    - environment: prod-*
+      override:
+        parameters:
+          source: glob
+    - environmentRegex: "^prod-us-[0-9]+$"
+      override:
+        parameters:
+          source: regex
+    - labels:
+        region: eu
+      override:
+        parameters:
+          source: labels`), 0644))
 
-				return assert.Empty(t, cmp.Diff(cA, cB))
-			})))
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      ".",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"prod-eu-1": {Name: "prod-eu-1", Group: "prod", Labels: map[string]string{"region": "eu"}},
+				"prod-eu-2": {Name: "prod-eu-2", Group: "prod", Labels: map[string]string{"region": "eu"}},
+				"prod-us-1": {Name: "prod-us-1", Group: "prod", Labels: map[string]string{"region": "us"}},
+				"dev-eu":    {Name: "dev-eu", Group: "dev", Labels: map[string]string{"region": "eu"}},
+				"dev-us":    {Name: "dev-us", Group: "dev"},
+			},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "profile.yaml")
+	assert.Empty(t, errs)
+
+	sources := make(map[string]any, len(got))
+	for _, c := range got {
+		sources[c.Environment] = c.Parameters["source"].(*value.ValueParameter).Value
+	}
+
+	assert.Equal(t, map[string]any{
+		"prod-eu-1": "exact",
+		"prod-eu-2": "glob",
+		"prod-us-1": "regex",
+		"dev-eu":    "labels",
+		"dev-us":    "config",
+	}, sources)
+}
+
+func TestLoadConfigFile_ParameterSchema(t *testing.T) {
+	tests := []struct {
+		name              string
+		schema            string
+		parameters        string
+		override          string
+		wantParameters    config.Parameters
+		wantErrorsContain []string
+	}{
+		{
+			name:       "converts values to declared types",
+			schema:     "threshold: {type: int, min: 1, max: 100}\n    enabled: {type: bool}\n    severity: {type: enum, values: [low, high]}\n    zone: {type: string, pattern: '^[0-9]+$'}",
+			parameters: "threshold: 10\n      enabled: true\n      severity: low\n      zone: 42",
+			override:   "threshold: '50'\n          enabled: 'false'",
+			wantParameters: config.Parameters{
+				"name":      &value.ValueParameter{Value: "Profile"},
+				"threshold": &value.ValueParameter{Value: 50},
+				"enabled":   &value.ValueParameter{Value: false},
+				"severity":  &value.ValueParameter{Value: "low"},
+				"zone":      &value.ValueParameter{Value: "42"},
+			},
+		},
+		{
+			name:              "rejects overridden values not matching the schema",
+			schema:            "threshold: {type: int, max: 100}\n    enabled: {type: bool}\n    severity: {type: enum, values: [low, high]}",
+			parameters:        "threshold: 10\n      enabled: true\n      severity: low",
+			override:          "threshold: 500\n          enabled: fals\n          severity: medium",
+			wantErrorsContain: []string{"value 500 is greater than the maximum 100", `value "fals" is not a bool`, `value "medium" is not one of the allowed values [low high]`},
+		},
+		{
+			name:              "rejects missing required parameters",
+			schema:            "threshold: {type: int, required: true}",
+			parameters:        "other: value",
+			override:          "other: value",
+			wantErrorsContain: []string{"required parameter is not defined"},
+		},
+		{
+			name:              "rejects invalid schema",
+			schema:            "threshold: {type: number}",
+			parameters:        "threshold: 10",
+			override:          "threshold: 10",
+			wantErrorsContain: []string{"invalid schema of parameter `threshold`: unknown type `number`"},
+		},
+		{
+			name:       "does not check values resolved at deploy time",
+			schema:     "threshold: {type: int}",
+			parameters: "threshold: {type: environment, name: THRESHOLD}",
+			override:   "other: value",
+			wantParameters: config.Parameters{
+				"name":      &value.ValueParameter{Value: "Profile"},
+				"threshold": &environment.EnvironmentVariableParameter{Name: "THRESHOLD"},
+				"other":     &value.ValueParameter{Value: "value"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(testFs, "profile.json", []byte("{}"), 0644))
+			assert.NoError(t, afero.WriteFile(testFs, "profile.yaml", []byte(fmt.Sprintf(`
+configs:
+- id: profile
+  parameterSchema:
+    %s
+  config:
+    name: Profile
+    template: profile.json
+    parameters:
+      %s
+  type: some-api
+  environmentOverrides:
+    - environment: prod
+      override:
+        parameters:
+          %s`, tt.schema, tt.parameters, tt.override)), 0644))
+
+			loaderContext := &LoaderContext{
+				ProjectId: "project",
+				Path:      ".",
+				KnownApis: map[string]struct{}{"some-api": {}},
+				Environments: manifest.Environments{
+					SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+						"prod": {Name: "prod", Group: "prod"},
+					},
+				},
+				ParametersSerDe: config.DefaultParameterParsers,
+			}
+
+			got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "profile.yaml")
+
+			if len(tt.wantErrorsContain) > 0 {
+				assert.NotEmpty(t, errs)
+				joined := errors.Join(errs...).Error()
+				for _, want := range tt.wantErrorsContain {
+					assert.Contains(t, joined, want)
+				}
+				return
+			}
+
+			assert.Empty(t, errs)
+			assert.Len(t, got, 1)
+			assert.Equal(t, tt.wantParameters, got[0].Parameters)
+		})
+	}
+}
+
+func TestLoadConfigFile_RenderMode(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "project/_defaults.yaml", []byte("renderMode: json"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.yaml", []byte(`
+configs:
+- id: from-defaults
+  config:
+    name: Profile
+    template: profile.json
+  type: some-api
+- id: own
+  config:
+    name: Profile
+    template: profile.json
+    renderMode: text
+  type: some-api
+  environmentOverrides:
+  - environment: dev
+    override:
+      renderMode: json`), 0644))
+
+	defaults, err := LoadProjectDefaults(testFs, "project")
+	assert.NoError(t, err)
+
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "project",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"dev":  {Name: "dev", Group: "dev"},
+				"prod": {Name: "prod", Group: "prod"},
+			},
+			AllEnvironmentNames: map[string]struct{}{"dev": {}, "prod": {}},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+		Defaults:        defaults,
+	}
+
+	got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/profile.yaml")
+	assert.Empty(t, errs)
+
+	modes := make(map[string]template.RenderMode, len(got))
+	for _, c := range got {
+		modes[c.Coordinate.ConfigId+"/"+c.Environment] = c.RenderMode
+	}
+	assert.Equal(t, map[string]template.RenderMode{
+		"from-defaults/dev":  template.RenderModeJSON,
+		"from-defaults/prod": template.RenderModeJSON,
+		"own/dev":            template.RenderModeJSON,
+		"own/prod":           template.RenderModeText,
+	}, modes)
+}
+
+func TestLoadConfigFile_RenderModeErrors(t *testing.T) {
+	tests := []struct {
+		name              string
+		template          string
+		renderMode        string
+		wantErrorsContain string
+	}{
+		{"unknown render mode", "profile.json", "html", "unknown render mode `html`"},
+		{"json render mode for YAML template", "template.yml", "json", "render mode `json` is not supported for YAML templates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(testFs, tt.template, []byte("{}"), 0644))
+			assert.NoError(t, afero.WriteFile(testFs, "profile.yaml", []byte(fmt.Sprintf(`
+configs:
+- id: profile
+  config:
+    name: Profile
+    template: %s
+    renderMode: %s
+  type: some-api`, tt.template, tt.renderMode)), 0644))
+
+			loaderContext := &LoaderContext{
+				ProjectId: "project",
+				Path:      ".",
+				KnownApis: map[string]struct{}{"some-api": {}},
+				Environments: manifest.Environments{
+					SelectedEnvironments: manifest.EnvironmentDefinitionsByName{"dev": {Name: "dev", Group: "dev"}},
+				},
+				ParametersSerDe: config.DefaultParameterParsers,
+			}
+
+			_, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "profile.yaml")
+			assert.ErrorContains(t, errors.Join(errs...), tt.wantErrorsContain)
+		})
+	}
+}
+
+func TestLoadConfigFile_Patches(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "dashboard.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "dashboard.yaml", []byte(`
+configs:
+- id: dashboard
+  config:
+    name: Dashboard
+    template: dashboard.json
+  type: some-api
+  groupOverrides:
+  - group: prod
+    override:
+      mergePatch:
+        settings:
+          refresh: 60
+          legacy: null
+  environmentOverrides:
+  - environment: prod-eu
+    override:
+      jsonPatch:
+      - op: add
+        path: /tiles/-
+        value: {name: extra}
+      - op: remove
+        path: /tiles/0`), 0644))
+
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      ".",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{
+				"dev":     {Name: "dev", Group: "dev"},
+				"prod-eu": {Name: "prod-eu", Group: "prod"},
+			},
+			AllEnvironmentNames: map[string]struct{}{"dev": {}, "prod-eu": {}},
+			AllGroupNames:       map[string]struct{}{"dev": {}, "prod": {}},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "dashboard.yaml")
+	assert.Empty(t, errs)
+
+	patches := make(map[string][]config.Patch, len(got))
+	for _, c := range got {
+		patches[c.Environment] = c.Patches
+	}
+
+	assert.Equal(t, map[string][]config.Patch{
+		"dev": nil,
+		"prod-eu": {
+			{MergePatch: map[string]any{"settings": map[string]any{"refresh": 60, "legacy": nil}}},
+			{JSONPatch: []json.PatchOperation{
+				{Op: "add", Path: "/tiles/-", Value: map[string]any{"name": "extra"}},
+				{Op: "remove", Path: "/tiles/0"},
+			}},
+		},
+	}, patches, "group patches must be applied before environment patches")
+}
+
+func TestLoadConfigFile_InvalidPatch(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "dashboard.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "dashboard.yaml", []byte(`
+configs:
+- id: dashboard
+  config:
+    name: Dashboard
+    template: dashboard.json
+  type: some-api
+  environmentOverrides:
+  - environment: dev
+    override:
+      jsonPatch:
+      - op: merge
+        path: /tiles`), 0644))
+
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      ".",
+		KnownApis: map[string]struct{}{"some-api": {}},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{"dev": {Name: "dev", Group: "dev"}},
+			AllEnvironmentNames:  map[string]struct{}{"dev": {}},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	_, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "dashboard.yaml")
+	assert.ErrorContains(t, errors.Join(errs...), "invalid operation 0 of `jsonPatch`: unknown operation \"merge\"")
+}
+
+func TestLoadConfigFile_AppliesValues(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "project/_defaults.yaml", []byte("parameters:\n  team: platform\n  channel: general"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.yaml", []byte(`
+configs:
+- id: profile
+  config:
+    name: Profile
+    template: profile.json
+    parameters:
+      threshold: 10
+      channel: own-channel
+  type:
+    settings:
+      schema: builtin:alerting.profile
+      scope: tenant
+  environmentOverrides:
+  - environment: prod
+    override:
+      parameters:
+        threshold: 20`), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "values-1.yaml", []byte(`
+project:
+  team: other-team
+  channel: ignored-as-config-defines-channel
+project:builtin:alerting.profile:profile:
+  threshold: 30
+other-project:
+  team: not-applied`), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "values-2.yaml", []byte(`
+project:builtin:alerting.profile:profile:
+  threshold:
+    type: environment
+    name: THRESHOLD`), 0644))
+
+	defaults, err := LoadProjectDefaults(testFs, "project")
+	assert.NoError(t, err)
+	values, err := LoadValuesFiles(testFs, []string{"values-1.yaml", "values-2.yaml"})
+	assert.NoError(t, err)
+
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "project",
+		KnownApis: map[string]struct{}{},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{"prod": {Name: "prod", Group: "prod"}},
+			AllEnvironmentNames:  map[string]struct{}{"prod": {}},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+		Defaults:        defaults,
+		Values:          values,
+	}
+
+	got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/profile.yaml")
+	assert.Empty(t, errs)
+	assert.Len(t, got, 1)
+
+	assert.Equal(t, config.Parameters{
+		"name":      &value.ValueParameter{Value: "Profile"},
+		"team":      &value.ValueParameter{Value: "other-team"},
+		"channel":   &value.ValueParameter{Value: "own-channel"},
+		"threshold": &environment.EnvironmentVariableParameter{Name: "THRESHOLD"},
+		"scope":     &value.ValueParameter{Value: "tenant"},
+	}, got[0].Parameters)
+
+	t.Run("keys not matching any config are reported", func(t *testing.T) {
+		projects := manifest.ProjectDefinitionByProjectID{"project": {Name: "project"}, "other-project": {Name: "other-project"}}
+		assert.Empty(t, values.UnmatchedKeys(projects, []string{"project"}))
+
+		assert.NoError(t, afero.WriteFile(testFs, "values-3.yaml", []byte(`
+project:builtin:alerting.profile:profil:
+  threshold: 1
+project:dashboard:profile:
+  threshold: 1
+projcet:
+  team: typo`), 0644))
+		values, err := LoadValuesFiles(testFs, []string{"values-1.yaml", "values-3.yaml"})
+		assert.NoError(t, err)
+		_, errs := LoadConfigFile(t.Context(), testFs, &LoaderContext{
+			ProjectId:       "project",
+			Path:            "project",
+			KnownApis:       map[string]struct{}{},
+			Environments:    loaderContext.Environments,
+			ParametersSerDe: config.DefaultParameterParsers,
+			Values:          values,
+		}, "project/profile.yaml")
+		assert.Empty(t, errs)
+
+		err = errors.Join(values.UnmatchedKeys(projects, []string{"project"})...)
+		assert.ErrorContains(t, err, "key \"project:builtin:alerting.profile:profil\" does not match any config of project \"project\"")
+		assert.ErrorContains(t, err, "key \"project:dashboard:profile\" does not match any config of project \"project\"")
+		assert.ErrorContains(t, err, "key \"projcet\" does not match any project of the manifest")
+	})
+}
+
+func TestLoadValuesFiles_KeysMayContainDots(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "values.yaml", []byte(`
+group.project:
+  team: platform
+group.project:builtin:alerting.profile:profile.v2:
+  threshold: 30`), 0644))
+
+	values, err := LoadValuesFiles(testFs, []string{"values.yaml"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []persistence.ConfigDefinition{{Parameters: map[string]persistence.ConfigParameter{"team": "platform"}}}, values.forProject("group.project"))
+	assert.Equal(t, []persistence.ConfigDefinition{{Parameters: map[string]persistence.ConfigParameter{"threshold": 30}}},
+		values.forConfig(coordinate.Coordinate{Project: "group.project", Type: "builtin:alerting.profile", ConfigId: "profile.v2"}))
+}
+
+func TestLoadConfigFile_ResolvesManifestVariables(t *testing.T) {
+	testFs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.json", []byte("{}"), 0644))
+	assert.NoError(t, afero.WriteFile(testFs, "project/profile.yaml", []byte(`
+configs:
+- id: profile
+  config:
+    name: Profile
+    template: profile.json
+    parameters:
+      region:
+        type: manifestVariable
+        name: region
+      channel:
+        type: manifestVariable
+        name: slackChannel
+        default: C000
+  type:
+    settings:
+      schema: builtin:alerting.profile
+      scope: tenant`), 0644))
+
+	variables := map[string]string{"region": "eu-west"}
+	loaderContext := &LoaderContext{
+		ProjectId: "project",
+		Path:      "project",
+		KnownApis: map[string]struct{}{},
+		Environments: manifest.Environments{
+			SelectedEnvironments: manifest.EnvironmentDefinitionsByName{"prod": {Name: "prod", Group: "prod", Variables: variables}},
+			AllEnvironmentNames:  map[string]struct{}{"prod": {}},
+		},
+		ParametersSerDe: config.DefaultParameterParsers,
+	}
+
+	got, errs := LoadConfigFile(t.Context(), testFs, loaderContext, "project/profile.yaml")
+	assert.Empty(t, errs)
+	require.Len(t, got, 1)
+
+	assert.Equal(t, &variable.ManifestVariableParameter{Name: "region", Value: "eu-west"}, got[0].Parameters["region"])
+	assert.Equal(t, &variable.ManifestVariableParameter{Name: "slackChannel", HasDefaultValue: true, DefaultValue: "C000", Value: "C000"}, got[0].Parameters["channel"])
+	assert.Equal(t, variables, got[0].ManifestVariables)
+
+	loaderContext.Environments.SelectedEnvironments["prod"] = manifest.EnvironmentDefinition{Name: "prod", Group: "prod"}
+	_, errs = LoadConfigFile(t.Context(), testFs, loaderContext, "project/profile.yaml")
+	assert.ErrorContains(t, errors.Join(errs...), "manifest variable `region` is not defined for the environment")
+}
+
+func TestLoadValuesFiles_RejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name              string
+		content           string
+		wantErrorContains string
+	}{
+		{"key without parameters", "project: value", "must be a map of parameter names to parameters"},
+		{"key in previous format", "project.param: value", "must be a map of parameter names to parameters"},
+		{"key without config", "project:type: {param: value}", "must be either a project name or a coordinate in the form `project:type:configId`"},
+		{"key with empty type", "project::config: {param: value}", "must be either a project name or a coordinate in the form `project:type:configId`"},
+		{"reserved parameter", "project:type:config: {name: value}", "parameter name `name` is not allowed (reserved)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(testFs, "values.yaml", []byte(tt.content), 0644))
+
+			_, err := LoadValuesFiles(testFs, []string{"values.yaml"})
+			assert.ErrorContains(t, err, tt.wantErrorContains)
 		})
 	}
 }
@@ -0,0 +1,133 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// DefaultsFileName is the name of the file in the root folder of a project that defines the default parameters of all
+// configs of the project.
+const DefaultsFileName = "_defaults.yaml"
+
+// ProjectDefaults holds the default parameters of a project. Every config of the project inherits them, while
+// parameters defined by the config itself take precedence.
+type ProjectDefaults struct {
+	definition           persistence.DefaultsDefinition
+	environmentOverrides environmentOverrides
+}
+
+// LoadProjectDefaults loads the defaults file of the project at the given path.
+// If the project does not define defaults, nil is returned.
+func LoadProjectDefaults(fs afero.Fs, projectPath string) (*ProjectDefaults, error) {
+	filePath := filepath.Join(projectPath, DefaultsFileName)
+
+	if exists, err := afero.Exists(fs, filePath); err != nil {
+		return nil, newLoadError(filePath, err)
+	} else if !exists {
+		return nil, nil
+	}
+
+	data, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return nil, newLoadError(filePath, err)
+	}
+
+	definition := persistence.DefaultsDefinition{}
+	if err := yaml.UnmarshalStrict(data, &definition); err != nil {
+		return nil, newLoadError(filePath, err)
+	}
+
+	if err := validateDefaults(definition); err != nil {
+		return nil, newLoadError(filePath, err)
+	}
+
+	environmentOverrides, err := newEnvironmentOverrides(definition.EnvironmentOverrides)
+	if err != nil {
+		return nil, newLoadError(filePath, err)
+	}
+
+	return &ProjectDefaults{definition: definition, environmentOverrides: environmentOverrides}, nil
+}
+
+func validateDefaults(definition persistence.DefaultsDefinition) error {
+	var errs []error
+
+	if definition.RenderMode != "" {
+		if _, err := template.ParseRenderMode(definition.RenderMode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, validateDefaultParameters(definition.Parameters))
+	for _, o := range definition.GroupOverrides {
+		if err := validateDefaultOverride(o.Override); err != nil {
+			errs = append(errs, fmt.Errorf("override of group `%s`: %w", o.Group, err))
+		}
+	}
+	for _, o := range definition.EnvironmentOverrides {
+		if err := validateDefaultOverride(o.Override); err != nil {
+			errs = append(errs, fmt.Errorf("override of environment `%s`: %w", o.Environment, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateDefaultOverride(override persistence.ConfigDefinition) error {
+	if override.Name != nil || override.Template != "" || override.Skip != nil || override.OriginObjectId != "" || override.RenderMode != "" ||
+		override.MergePatch != nil || override.JSONPatch != nil {
+		return errors.New("defaults may only override `parameters`")
+	}
+	return validateDefaultParameters(override.Parameters)
+}
+
+func validateDefaultParameters(parameters map[string]persistence.ConfigParameter) error {
+	for name := range parameters {
+		if slices.Contains(config.ReservedParameterNames, name) {
+			return fmt.Errorf("parameter name `%s` is not allowed (reserved)", name)
+		}
+	}
+	return nil
+}
+
+// forEnvironment returns the default parameters for the given environment, in the order in which they are applied.
+func (d *ProjectDefaults) forEnvironment(environment manifest.EnvironmentDefinition) []persistence.ConfigDefinition {
+	if d == nil {
+		return nil
+	}
+
+	result := []persistence.ConfigDefinition{{Parameters: d.definition.Parameters, RenderMode: d.definition.RenderMode}}
+
+	for _, o := range d.definition.GroupOverrides {
+		if o.Group == environment.Group {
+			result = append(result, o.Override)
+		}
+	}
+
+	return append(result, d.environmentOverrides.forEnvironment(environment)...)
+}
@@ -0,0 +1,276 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+)
+
+// ConfigDefinitions holds the raw config definitions of projects. It is used to resolve configs that extend a config
+// defined in another file or project.
+type ConfigDefinitions struct {
+	definitions []indexedDefinition
+}
+
+type indexedDefinition struct {
+	project    string
+	file       string
+	definition persistence.TopLevelConfigDefinition
+}
+
+// NewConfigDefinitions returns an empty ConfigDefinitions index.
+func NewConfigDefinitions() *ConfigDefinitions {
+	return &ConfigDefinitions{}
+}
+
+// AddFile adds all config definitions of the given file to the index.
+// Files that can not be parsed are ignored, as they are reported once they are actually loaded.
+func (d *ConfigDefinitions) AddFile(fs afero.Fs, projectId string, filePath string) {
+	data, err := afero.ReadFile(fs, filePath)
+	if err != nil {
+		return
+	}
+
+	definition := persistence.TopLevelDefinition{}
+	if err := yaml.Unmarshal(data, &definition); err != nil {
+		return
+	}
+
+	d.add(projectId, filePath, definition.Configs)
+}
+
+func (d *ConfigDefinitions) add(projectId string, filePath string, definitions []persistence.TopLevelConfigDefinition) {
+	for _, c := range definitions {
+		d.definitions = append(d.definitions, indexedDefinition{project: projectId, file: filePath, definition: c})
+	}
+}
+
+// find returns the definition the given extends reference points to. A reference is either the ID of a config in the
+// same project, or the coordinate of a config in the form `project:type:configId`.
+func (d *ConfigDefinitions) find(projectId string, configType string, reference string) (indexedDefinition, error) {
+	var project, id, refType string
+
+	explicit := strings.Contains(reference, ":")
+	if explicit {
+		first, last := strings.Index(reference, ":"), strings.LastIndex(reference, ":")
+		if first == last {
+			return indexedDefinition{}, fmt.Errorf("invalid reference `%s`: must either be a config ID or a coordinate in the form `project:type:configId`", reference)
+		}
+		project, refType, id = reference[:first], reference[first+1:last], reference[last+1:]
+	} else {
+		// without an explicit type, a config extends a config of its own type if there is one
+		project, refType, id = projectId, configType, reference
+	}
+
+	var candidates []indexedDefinition
+	for _, c := range d.definitions {
+		if c.project == project && c.definition.Id == id {
+			candidates = append(candidates, c)
+		}
+	}
+
+	// the type of a coordinate always has to match, while a config ID only prefers configs of the same type
+	if explicit || (len(candidates) > 1 && refType != "") {
+		var ofType []indexedDefinition
+		for _, c := range candidates {
+			if c.definition.Type.GetApiType() == refType {
+				ofType = append(ofType, c)
+			}
+		}
+		if explicit && len(ofType) == 0 && len(candidates) > 0 {
+			return indexedDefinition{}, fmt.Errorf("config `%s` does not exist: config `%s` of project `%s` is not of type `%s`", reference, id, project, refType)
+		}
+		candidates = ofType
+	}
+
+	switch len(candidates) {
+	case 0:
+		return indexedDefinition{}, fmt.Errorf("config `%s` does not exist", reference)
+	case 1:
+		return candidates[0], nil
+	default:
+		return indexedDefinition{}, fmt.Errorf("config ID `%s` is ambiguous, use a coordinate in the form `project:type:configId` instead", reference)
+	}
+}
+
+// resolveExtends returns the definition resulting from applying the given definition on top of the config it extends.
+// The config inherits template, type, parameters and overrides of its base and replaces only what it defines itself.
+// Template paths of the base are rewritten to be relative to the folder of the extending config. All other values,
+// including relative paths of parameters, are inherited as written.
+func resolveExtends(context *configFileLoaderContext, definition persistence.TopLevelConfigDefinition) (persistence.TopLevelConfigDefinition, error) {
+	return resolveExtendsChain(context.ConfigDefinitions, indexedDefinition{project: context.ProjectId, file: context.Path, definition: definition}, nil)
+}
+
+func resolveExtendsChain(definitions *ConfigDefinitions, current indexedDefinition, chain []indexedDefinition) (persistence.TopLevelConfigDefinition, error) {
+	if current.definition.Extends == "" {
+		return current.definition, nil
+	}
+
+	chain = append(chain, current)
+
+	found, err := definitions.find(current.project, current.definition.Type.GetApiType(), current.definition.Extends)
+	if err != nil {
+		return persistence.TopLevelConfigDefinition{}, fmt.Errorf("failed to resolve base config: %w", err)
+	}
+
+	for _, c := range chain {
+		if c.isSame(found) {
+			return persistence.TopLevelConfigDefinition{}, fmt.Errorf("extends cycle detected: %s", formatChain(append(chain, found)))
+		}
+	}
+
+	base, err := resolveExtendsChain(definitions, found, chain)
+	if err != nil {
+		return persistence.TopLevelConfigDefinition{}, err
+	}
+
+	return extend(base, filepath.Dir(found.file), current.definition, filepath.Dir(current.file))
+}
+
+// isSame reports whether both entries refer to the same definition, based on the raw type the definitions declare.
+func (d indexedDefinition) isSame(other indexedDefinition) bool {
+	return d.project == other.project &&
+		filepath.Clean(d.file) == filepath.Clean(other.file) &&
+		d.definition.Id == other.definition.Id &&
+		d.definition.Type.GetApiType() == other.definition.Type.GetApiType()
+}
+
+func formatChain(chain []indexedDefinition) string {
+	ids := make([]string, len(chain))
+	for i, c := range chain {
+		ids[i] = c.project + ":" + c.definition.Id
+	}
+	return strings.Join(ids, " -> ")
+}
+
+// extend applies the extending definition on top of base. The folders are the folders of the files the definitions are
+// declared in and are used to rewrite the template paths of base.
+func extend(base persistence.TopLevelConfigDefinition, baseFolder string, definition persistence.TopLevelConfigDefinition, folder string) (persistence.TopLevelConfigDefinition, error) {
+	result := persistence.TopLevelConfigDefinition{
+		Id:      definition.Id,
+		ForEach: definition.ForEach,
+		Type:    definition.Type,
+		// previous coordinates identify single objects and are never inherited
+		PreviousIds:         definition.PreviousIds,
+		PreviousCoordinates: definition.PreviousCoordinates,
+	}
+
+	if result.Type == (persistence.TypeDefinition{}) {
+		result.Type = base.Type
+	}
+
+	// schemas are inherited by parameter name, the extending config may redeclare single parameters
+	if len(base.ParameterSchema)+len(definition.ParameterSchema) > 0 {
+		result.ParameterSchema = make(map[string]persistence.ParameterSchemaDefinition, len(base.ParameterSchema)+len(definition.ParameterSchema))
+		maps.Copy(result.ParameterSchema, base.ParameterSchema)
+		maps.Copy(result.ParameterSchema, definition.ParameterSchema)
+	}
+
+	baseConfig := rebase(base.Config, baseFolder, folder)
+	// the origin object identifies a single object and is never inherited
+	baseConfig.OriginObjectId = ""
+
+	var err error
+	if result.Config, err = extendConfigDefinition(baseConfig, definition.Config); err != nil {
+		return persistence.TopLevelConfigDefinition{}, err
+	}
+
+	groupOverrides := make(map[string]persistence.ConfigDefinition, len(definition.GroupOverrides))
+	for _, o := range definition.GroupOverrides {
+		groupOverrides[o.Group] = o.Override
+	}
+	for _, o := range base.GroupOverrides {
+		override := rebase(o.Override, baseFolder, folder)
+		if extending, found := groupOverrides[o.Group]; found {
+			if override, err = extendConfigDefinition(override, extending); err != nil {
+				return persistence.TopLevelConfigDefinition{}, fmt.Errorf("failed to extend override of group `%s`: %w", o.Group, err)
+			}
+			delete(groupOverrides, o.Group)
+		}
+		result.GroupOverrides = append(result.GroupOverrides, persistence.GroupOverride{Group: o.Group, Override: override})
+	}
+	for _, o := range definition.GroupOverrides {
+		if _, notInBase := groupOverrides[o.Group]; notInBase {
+			result.GroupOverrides = append(result.GroupOverrides, o)
+		}
+	}
+
+	environmentOverrides := make(map[string]persistence.ConfigDefinition, len(definition.EnvironmentOverrides))
+	for _, o := range definition.EnvironmentOverrides {
+		environmentOverrides[environmentSelectorKey(o)] = o.Override
+	}
+	for _, o := range base.EnvironmentOverrides {
+		key := environmentSelectorKey(o)
+		extended := o
+		extended.Override = rebase(o.Override, baseFolder, folder)
+		if extending, found := environmentOverrides[key]; found {
+			if extended.Override, err = extendConfigDefinition(extended.Override, extending); err != nil {
+				return persistence.TopLevelConfigDefinition{}, fmt.Errorf("failed to extend environment override `%s`: %w", key, err)
+			}
+			delete(environmentOverrides, key)
+		}
+		result.EnvironmentOverrides = append(result.EnvironmentOverrides, extended)
+	}
+	for _, o := range definition.EnvironmentOverrides {
+		if _, notInBase := environmentOverrides[environmentSelectorKey(o)]; notInBase {
+			result.EnvironmentOverrides = append(result.EnvironmentOverrides, o)
+		}
+	}
+
+	return result, nil
+}
+
+// extendConfigDefinition returns a copy of base with definition applied on top of it. base is not modified.
+func extendConfigDefinition(base persistence.ConfigDefinition, definition persistence.ConfigDefinition) (persistence.ConfigDefinition, error) {
+	result := base
+	result.Parameters = make(map[string]persistence.ConfigParameter, len(base.Parameters)+len(definition.Parameters))
+	for name, param := range base.Parameters {
+		result.Parameters[name] = param
+	}
+
+	if err := applyOverrides(&result, definition); err != nil {
+		return persistence.ConfigDefinition{}, err
+	}
+
+	if len(result.Parameters) == 0 {
+		result.Parameters = nil
+	}
+
+	return result, nil
+}
+
+// rebase returns a copy of the definition whose template path, which is relative to baseFolder, is relative to folder.
+func rebase(definition persistence.ConfigDefinition, baseFolder string, folder string) persistence.ConfigDefinition {
+	if definition.Template == "" || filepath.Clean(baseFolder) == filepath.Clean(folder) {
+		return definition
+	}
+
+	templatePath := filepath.Join(baseFolder, filepath.FromSlash(definition.Template))
+	if rel, err := filepath.Rel(folder, templatePath); err == nil {
+		templatePath = rel
+	}
+	definition.Template = filepath.ToSlash(templatePath)
+	return definition
+}
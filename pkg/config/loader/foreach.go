@@ -0,0 +1,171 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	listParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/list"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+const (
+	// ForEachParameter is the name of the parameter holding the current item of a config expanded via `forEach`.
+	ForEachParameter = "item"
+
+	// forEachKey is the property of a map item whose value is used to derive the ID of the generated config.
+	forEachKey = "key"
+)
+
+// forEachKeyPattern matches the keys that can be part of the IDs of generated configs. Other characters, like
+// whitespace or the ':' separating the parts of a coordinate, would result in IDs that can not be referenced.
+var forEachKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// expandForEach returns the definitions generated from a definition that declares `forEach`. For every item, a
+// definition with the ID `<id>-<key>` is generated, which holds the item in the parameter [ForEachParameter].
+// The key of an item is either the item itself for scalar items, or the value of its `key` property for map items.
+// Previous IDs of the definition are expanded the same way, so that renaming a definition keeps all of its items.
+// Definitions without `forEach` are returned as they are.
+func expandForEach(definition persistence.TopLevelConfigDefinition) ([]persistence.TopLevelConfigDefinition, error) {
+	if definition.ForEach == nil {
+		return []persistence.TopLevelConfigDefinition{definition}, nil
+	}
+
+	items, err := forEachItems(definition.ForEach)
+	if err != nil {
+		return nil, err
+	}
+
+	// group and environment overrides are applied after the item, so they must not replace it either
+	definitions := []persistence.ConfigDefinition{definition.Config}
+	for _, o := range definition.GroupOverrides {
+		definitions = append(definitions, o.Override)
+	}
+	for _, o := range definition.EnvironmentOverrides {
+		definitions = append(definitions, o.Override)
+	}
+	for _, d := range definitions {
+		if _, found := d.Parameters[ForEachParameter]; found {
+			return nil, fmt.Errorf("parameter `%s` is reserved for the items of `forEach`", ForEachParameter)
+		}
+	}
+
+	result := make([]persistence.TopLevelConfigDefinition, 0, len(items))
+	seenKeys := make(map[string]struct{}, len(items))
+	for i, item := range items {
+		key, err := forEachItemKey(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `forEach` item %d: %w", i, err)
+		}
+
+		if _, found := seenKeys[key]; found {
+			return nil, fmt.Errorf("invalid `forEach` item %d: duplicate key `%s`", i, key)
+		}
+		seenKeys[key] = struct{}{}
+
+		expanded := definition
+		expanded.Id = ForEachConfigID(definition.Id, key)
+		expanded.ForEach = nil
+		expanded.PreviousIds = make([]string, len(definition.PreviousIds))
+		for j, id := range definition.PreviousIds {
+			expanded.PreviousIds[j] = ForEachConfigID(id, key)
+		}
+		expanded.PreviousCoordinates = make([]persistence.PreviousCoordinateDefinition, len(definition.PreviousCoordinates))
+		for j, c := range definition.PreviousCoordinates {
+			c.ConfigId = ForEachConfigID(c.ConfigId, key)
+			expanded.PreviousCoordinates[j] = c
+		}
+		expanded.Config.Parameters = make(map[string]persistence.ConfigParameter, len(definition.Config.Parameters)+1)
+		for name, param := range definition.Config.Parameters {
+			expanded.Config.Parameters[name] = param
+		}
+		expanded.Config.Parameters[ForEachParameter] = map[any]any{
+			"type":  valueParam.ValueParameterType,
+			"value": item,
+		}
+
+		result = append(result, expanded)
+	}
+
+	return result, nil
+}
+
+// ForEachKeys returns the keys of the items of a `forEach` definition, from which the IDs of the generated configs are
+// derived, see ForEachConfigID.
+func ForEachKeys(forEach any) ([]string, error) {
+	items, err := forEachItems(forEach)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(items))
+	for i, item := range items {
+		if keys[i], err = forEachItemKey(item); err != nil {
+			return nil, fmt.Errorf("invalid `forEach` item %d: %w", i, err)
+		}
+	}
+	return keys, nil
+}
+
+// ForEachConfigID returns the ID of the config generated for the item with the given key from the definition with
+// the given ID.
+func ForEachConfigID(id string, key string) string {
+	return fmt.Sprintf("%s-%s", id, key)
+}
+
+// forEachItems returns the items of a `forEach` definition, which is either an inline list or a list parameter.
+func forEachItems(forEach any) ([]any, error) {
+	switch v := forEach.(type) {
+	case []any:
+		return v, nil
+	case map[any]any:
+		if paramType := toString(v["type"]); paramType != listParam.ListParameterType {
+			return nil, fmt.Errorf("`forEach` must be a list or a parameter of type `%s`, not `%s`", listParam.ListParameterType, paramType)
+		}
+		values, ok := v["values"].([]any)
+		if !ok {
+			return nil, fmt.Errorf("`forEach` parameter of type `%s` requires a list of `values`", listParam.ListParameterType)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("`forEach` must be a list or a parameter of type `%s`", listParam.ListParameterType)
+	}
+}
+
+func forEachItemKey(item any) (string, error) {
+	switch v := item.(type) {
+	case map[any]any:
+		key, found := v[forEachKey]
+		if !found {
+			return "", fmt.Errorf("map items require a `%s` property", forEachKey)
+		}
+		return forEachItemKey(key)
+	case []any, nil:
+		return "", fmt.Errorf("items must be scalars or maps with a `%s` property", forEachKey)
+	default:
+		key := toString(v)
+		if key == "" {
+			return "", fmt.Errorf("key must not be empty")
+		}
+		if !forEachKeyPattern.MatchString(key) {
+			return "", fmt.Errorf("key `%s` can not be part of a config ID: keys may only contain letters, digits, '-', '_' and '.'", key)
+		}
+		return key, nil
+	}
+}
@@ -0,0 +1,94 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	valueParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+)
+
+const (
+	// mergeStrategyKey is the property of a parameter definition that defines how an override is applied to the
+	// parameter it overrides. If it is not set, the override replaces the parameter.
+	mergeStrategyKey = "merge"
+
+	// mergeStrategyDeep merges the map value of an overriding 'value' parameter into the value of the parameter it
+	// overrides, so that an override only needs to define the nested keys it changes.
+	mergeStrategyDeep = "deep"
+)
+
+// mergeParameter returns the parameter definition resulting from applying override on top of base.
+// Unless override defines a merge strategy, override replaces base.
+func mergeParameter(base, override persistence.ConfigParameter) (persistence.ConfigParameter, error) {
+	overrideMap, ok := override.(map[any]any)
+	if !ok {
+		return override, nil
+	}
+
+	strategy, found := overrideMap[mergeStrategyKey]
+	if !found {
+		return override, nil
+	}
+
+	if toString(strategy) != mergeStrategyDeep {
+		return nil, fmt.Errorf("unknown merge strategy `%v`. Allowed strategies: [%s]", strategy, mergeStrategyDeep)
+	}
+
+	if paramType := toString(overrideMap["type"]); paramType != valueParam.ValueParameterType {
+		return nil, fmt.Errorf("merge strategy `%s` is only supported for parameters of type `%s`, not `%s`", mergeStrategyDeep, valueParam.ValueParameterType, paramType)
+	}
+
+	overrideMap = withoutMergeStrategy(overrideMap)
+
+	baseMap, ok := base.(map[any]any)
+	if !ok || toString(baseMap["type"]) != valueParam.ValueParameterType {
+		// there is no structured value to merge into, the override is used as is
+		return overrideMap, nil
+	}
+
+	merged := withoutMergeStrategy(baseMap)
+	merged["value"] = deepMerge(baseMap["value"], overrideMap["value"])
+	return merged, nil
+}
+
+// deepMerge recursively merges override into base. Maps are merged key by key, any other value of override replaces
+// the value of base. Neither base nor override are modified.
+func deepMerge(base, override any) any {
+	baseMap, baseIsMap := base.(map[any]any)
+	overrideMap, overrideIsMap := override.(map[any]any)
+	if !baseIsMap || !overrideIsMap {
+		return override
+	}
+
+	result := make(map[any]any, len(baseMap)+len(overrideMap))
+	maps.Copy(result, baseMap)
+	for key, value := range overrideMap {
+		result[key] = deepMerge(baseMap[key], value)
+	}
+
+	return result
+}
+
+// withoutMergeStrategy returns a copy of the given parameter definition without its merge strategy property.
+func withoutMergeStrategy(param map[any]any) map[any]any {
+	result := maps.Clone(param)
+	delete(result, mergeStrategyKey)
+	return result
+}
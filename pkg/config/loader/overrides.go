@@ -0,0 +1,184 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// environmentOverrides holds the environment overrides of a config, grouped by how they select environments.
+//
+// Overrides are applied from least to most specific: first all overrides selecting environments by labels, then all
+// overrides selecting environments by a glob pattern or regular expression, each in the order they are declared, and
+// finally the override naming the environment exactly. Group overrides are applied before any environment override.
+type environmentOverrides struct {
+	byLabels  []matchingOverride
+	byPattern []matchingOverride
+	byName    map[string]persistence.ConfigDefinition
+}
+
+type matchingOverride struct {
+	matches  func(manifest.EnvironmentDefinition) bool
+	override persistence.ConfigDefinition
+}
+
+// newEnvironmentOverrides validates and groups the given overrides.
+func newEnvironmentOverrides(overrides []persistence.EnvironmentOverride) (environmentOverrides, error) {
+	result := environmentOverrides{byName: make(map[string]persistence.ConfigDefinition)}
+
+	var errs []error
+	for _, o := range overrides {
+		if err := validateEnvironmentSelector(o); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		switch {
+		case len(o.Labels) > 0:
+			labels := o.Labels
+			result.byLabels = append(result.byLabels, matchingOverride{
+				matches:  func(env manifest.EnvironmentDefinition) bool { return hasLabels(env, labels) },
+				override: o.Override,
+			})
+		case o.EnvironmentRegex != "":
+			re := regexp.MustCompile(o.EnvironmentRegex)
+			result.byPattern = append(result.byPattern, matchingOverride{
+				matches:  func(env manifest.EnvironmentDefinition) bool { return re.MatchString(env.Name) },
+				override: o.Override,
+			})
+		case isEnvironmentPattern(o.Environment):
+			pattern := o.Environment
+			result.byPattern = append(result.byPattern, matchingOverride{
+				matches: func(env manifest.EnvironmentDefinition) bool {
+					matched, _ := path.Match(pattern, env.Name)
+					return matched
+				},
+				override: o.Override,
+			})
+		default:
+			result.byName[o.Environment] = o.Override
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func validateEnvironmentSelector(o persistence.EnvironmentOverride) error {
+	selectors := 0
+	for _, set := range []bool{o.Environment != "", o.EnvironmentRegex != "", len(o.Labels) > 0} {
+		if set {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return errors.New("environment override must define exactly one of `environment`, `environmentRegex` or `labels`")
+	}
+
+	if o.EnvironmentRegex != "" {
+		if _, err := regexp.Compile(o.EnvironmentRegex); err != nil {
+			return fmt.Errorf("invalid `environmentRegex` %q: %w", o.EnvironmentRegex, err)
+		}
+	}
+
+	if isEnvironmentPattern(o.Environment) {
+		if _, err := path.Match(o.Environment, ""); err != nil {
+			return fmt.Errorf("invalid environment pattern %q: %w", o.Environment, err)
+		}
+	}
+
+	return nil
+}
+
+// forEnvironment returns the overrides applying to the given environment, in the order in which they are applied.
+func (o environmentOverrides) forEnvironment(env manifest.EnvironmentDefinition) []persistence.ConfigDefinition {
+	var result []persistence.ConfigDefinition
+
+	for _, m := range slices.Concat(o.byLabels, o.byPattern) {
+		if m.matches(env) {
+			result = append(result, m.override)
+		}
+	}
+
+	if override, found := o.byName[env.Name]; found {
+		result = append(result, override)
+	}
+
+	return result
+}
+
+// matchesAny reports whether the selector of the override matches any of the given environment names.
+// Overrides selecting environments by labels are always considered to match.
+func matchesAny(o persistence.EnvironmentOverride, environmentNames map[string]struct{}) bool {
+	if len(o.Labels) > 0 {
+		return true
+	}
+
+	for name := range environmentNames {
+		switch {
+		case o.EnvironmentRegex != "":
+			if matched, _ := regexp.MatchString(o.EnvironmentRegex, name); matched {
+				return true
+			}
+		case isEnvironmentPattern(o.Environment):
+			if matched, _ := path.Match(o.Environment, name); matched {
+				return true
+			}
+		case o.Environment == name:
+			return true
+		}
+	}
+
+	return false
+}
+
+// environmentSelectorKey returns a key identifying the environments selected by the override.
+func environmentSelectorKey(o persistence.EnvironmentOverride) string {
+	switch {
+	case len(o.Labels) > 0:
+		labels := make([]string, 0, len(o.Labels))
+		for k, v := range o.Labels {
+			labels = append(labels, k+"="+v)
+		}
+		slices.Sort(labels)
+		return "labels:" + strings.Join(labels, ",")
+	case o.EnvironmentRegex != "":
+		return "regex:" + o.EnvironmentRegex
+	default:
+		return "environment:" + o.Environment
+	}
+}
+
+func isEnvironmentPattern(environment string) bool {
+	return strings.ContainsAny(environment, "*?[")
+}
+
+func hasLabels(env manifest.EnvironmentDefinition, labels map[string]string) bool {
+	for k, v := range labels {
+		if actual, found := env.Labels[k]; !found || actual != v {
+			return false
+		}
+	}
+	return true
+}
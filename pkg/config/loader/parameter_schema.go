@@ -0,0 +1,97 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// parseParameterSchemas converts and validates the parameter schemas declared by a config definition.
+// It returns nil if the definition does not declare any.
+func parseParameterSchemas(definitions map[string]persistence.ParameterSchemaDefinition) (config.ParameterSchemas, error) {
+	if len(definitions) == 0 {
+		return nil, nil
+	}
+
+	schemas := make(config.ParameterSchemas, len(definitions))
+	for name, d := range definitions {
+		schema := config.ParameterSchema{
+			Type:     config.ParameterSchemaType(d.Type),
+			Min:      d.Min,
+			Max:      d.Max,
+			Pattern:  d.Pattern,
+			Required: d.Required,
+		}
+		for _, v := range d.Values {
+			schema.Values = append(schema.Values, fmt.Sprint(v))
+		}
+
+		if err := schema.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid schema of parameter `%s`: %w", name, err)
+		}
+		schemas[name] = schema
+	}
+
+	return schemas, nil
+}
+
+// applyParameterSchemas checks the parameters of a config against the declared schemas. Required parameters must be
+// defined, and the values of value parameters are checked and replaced by their typed representation. The values of
+// all other parameters are only known at deploy time and are checked when they are resolved.
+func applyParameterSchemas(
+	context *singleConfigEntryLoadContext,
+	configId string,
+	environment manifest.EnvironmentDefinition,
+	schemas config.ParameterSchemas,
+	parameters config.Parameters,
+) []error {
+
+	var errs []error
+
+	for _, name := range slices.Sorted(maps.Keys(schemas)) {
+		schema := schemas[name]
+
+		param, found := parameters[name]
+		if !found {
+			if schema.Required {
+				errs = append(errs, newParameterDefinitionParserError(name, configId, context, environment, "required parameter is not defined"))
+			}
+			continue
+		}
+
+		valueParam, ok := param.(*value.ValueParameter)
+		if !ok {
+			continue
+		}
+
+		typed, err := schema.Apply(valueParam.Value)
+		if err != nil {
+			errs = append(errs, newParameterDefinitionParserError(name, configId, context, environment, fmt.Sprintf("value does not match schema: %s", err)))
+			continue
+		}
+		parameters[name] = value.New(typed)
+	}
+
+	return errs
+}
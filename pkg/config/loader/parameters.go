@@ -133,9 +133,10 @@ func parseParameter(fs afero.Fs, context *singleConfigEntryLoadContext, environm
 				Type:     context.Type,
 				ConfigId: configId,
 			},
-			Fs:            fs,
-			ParameterName: name,
-			Value:         maps.ToStringMap(val),
+			Fs:                fs,
+			ParameterName:     name,
+			Value:             maps.ToStringMap(val),
+			ManifestVariables: environment.Variables,
 		})
 	}
 
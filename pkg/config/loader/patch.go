@@ -0,0 +1,89 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+)
+
+// parsePatch returns the patch the given definition applies to the rendered template, or nil if it does not define any.
+func parsePatch(definition persistence.ConfigDefinition) (*config.Patch, error) {
+	if definition.MergePatch == nil && definition.JSONPatch == nil {
+		return nil, nil
+	}
+
+	var patch config.Patch
+
+	if definition.MergePatch != nil {
+		mergePatch, err := toJSONValue(definition.MergePatch)
+		if err != nil {
+			return nil, fmt.Errorf("invalid `mergePatch`: %w", err)
+		}
+		patch.MergePatch = mergePatch
+	}
+
+	for i, o := range definition.JSONPatch {
+		value, err := toJSONValue(o.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operation %d of `jsonPatch`: %w", i, err)
+		}
+
+		operation := json.PatchOperation{Op: o.Op, Path: o.Path, From: o.From, Value: value}
+		if err := operation.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid operation %d of `jsonPatch`: %w", i, err)
+		}
+		patch.JSONPatch = append(patch.JSONPatch, operation)
+	}
+
+	return &patch, nil
+}
+
+// toJSONValue turns a value parsed from YAML into one that can be part of a decoded JSON document.
+func toJSONValue(v any) (any, error) {
+	switch val := v.(type) {
+	case map[any]any:
+		result := make(map[string]any, len(val))
+		for k, e := range val {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("key %v is not a string", k)
+			}
+			converted, err := toJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(val))
+		for i, e := range val {
+			converted, err := toJSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	default:
+		return val, nil
+	}
+}
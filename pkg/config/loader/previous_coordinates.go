@@ -0,0 +1,67 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+)
+
+// previousCoordinates returns the coordinates a config was known by before, as declared by `previousIds` and
+// `previousCoordinates`. Project and type of previous coordinates default to the ones of the config.
+func previousCoordinates(current coordinate.Coordinate, definition persistence.TopLevelConfigDefinition) ([]coordinate.Coordinate, error) {
+	var result []coordinate.Coordinate
+	add := func(c coordinate.Coordinate) error {
+		if c.ConfigId == "" {
+			return errors.New("previous config IDs must not be empty")
+		}
+		if c == current {
+			return fmt.Errorf("previous coordinate %s must differ from the coordinate of the config", c)
+		}
+		for _, existing := range result {
+			if existing == c {
+				return fmt.Errorf("previous coordinate %s is declared more than once", c)
+			}
+		}
+		result = append(result, c)
+		return nil
+	}
+
+	for _, id := range definition.PreviousIds {
+		if err := add(coordinate.Coordinate{Project: current.Project, Type: current.Type, ConfigId: id}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range definition.PreviousCoordinates {
+		c := coordinate.Coordinate{Project: p.Project, Type: p.ConfigType, ConfigId: p.ConfigId}
+		if c.Project == "" {
+			c.Project = current.Project
+		}
+		if c.Type == "" {
+			c.Type = current.Type
+		}
+		if err := add(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,212 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+)
+
+// Values are parameter values defined outside the project tree, in values files. Each key of a values file maps to
+// the parameters it sets and is either the name of a project, setting default parameters of the project, or the
+// coordinate of a config in the form `project:type:configId`, setting parameters of a single config:
+//
+//	my-project:
+//	  team: platform
+//	my-project:builtin:alerting.profile:my-profile:
+//	  threshold: 30
+//
+// As types may contain colons, everything between the first and the last colon of a coordinate is considered the type.
+// Coordinates are not written as dotted paths like `project.type.configId.parameter`, as types like
+// `builtin:alerting.profile` and project names may contain dots, which would make such paths ambiguous.
+//
+// Parameters of configs take precedence over all overrides defined in YAML, while default parameters take precedence
+// over the defaults defined by the project.
+type Values struct {
+	defaults map[string]map[string]persistence.ConfigParameter
+	configs  map[coordinate.Coordinate]map[string]persistence.ConfigParameter
+
+	// keys holds all keys of the values files, to report the ones that do not match any project or config
+	keys []valuesKey
+	// matched holds the keys that were looked up while loading projects
+	matched map[string]struct{}
+}
+
+type valuesKey struct {
+	file    string
+	key     string
+	project string
+}
+
+// LoadValuesFiles loads the given values files. If files set the same value, the later file takes precedence.
+func LoadValuesFiles(fs afero.Fs, filePaths []string) (*Values, error) {
+	values := &Values{
+		defaults: make(map[string]map[string]persistence.ConfigParameter),
+		configs:  make(map[coordinate.Coordinate]map[string]persistence.ConfigParameter),
+		matched:  make(map[string]struct{}),
+	}
+
+	for _, filePath := range filePaths {
+		data, err := afero.ReadFile(fs, filePath)
+		if err != nil {
+			return nil, newLoadError(filePath, err)
+		}
+
+		var content yaml.MapSlice
+		if err := yaml.Unmarshal(data, &content); err != nil {
+			return nil, newLoadError(filePath, err)
+		}
+
+		for _, item := range content {
+			if err := values.set(filePath, item.Key, item.Value); err != nil {
+				return nil, newLoadError(filePath, err)
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func (v *Values) set(file string, rawKey any, rawParameters any) error {
+	key, ok := rawKey.(string)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid key %v: must be either a project name or a coordinate in the form `project:type:configId`", rawKey)
+	}
+
+	parameters, err := toParameters(rawParameters)
+	if err != nil {
+		return fmt.Errorf("invalid value of key %q: %w", key, err)
+	}
+	if err := validateDefaultParameters(parameters); err != nil {
+		return fmt.Errorf("invalid value of key %q: %w", key, err)
+	}
+
+	if !strings.Contains(key, ":") {
+		v.keys = append(v.keys, valuesKey{file: file, key: key, project: key})
+		setParameters(v.defaults, key, parameters)
+		return nil
+	}
+
+	first, last := strings.Index(key, ":"), strings.LastIndex(key, ":")
+	if first == last {
+		return fmt.Errorf("invalid key %q: must be either a project name or a coordinate in the form `project:type:configId`", key)
+	}
+	c := coordinate.Coordinate{Project: key[:first], Type: key[first+1 : last], ConfigId: key[last+1:]}
+	if c.Project == "" || c.Type == "" || c.ConfigId == "" {
+		return fmt.Errorf("invalid key %q: must be either a project name or a coordinate in the form `project:type:configId`", key)
+	}
+
+	v.keys = append(v.keys, valuesKey{file: file, key: c.String(), project: c.Project})
+	setParameters(v.configs, c, parameters)
+	return nil
+}
+
+// toParameters converts the value of a key of a values file to the parameters it sets.
+func toParameters(value any) (map[string]persistence.ConfigParameter, error) {
+	items, ok := value.(yaml.MapSlice)
+	if !ok {
+		return nil, fmt.Errorf("must be a map of parameter names to parameters, but is %T", value)
+	}
+
+	parameters := make(map[string]persistence.ConfigParameter, len(items))
+	for _, item := range items {
+		name, ok := item.Key.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid parameter name %v", item.Key)
+		}
+		parameters[name] = toPlainValue(item.Value)
+	}
+	return parameters, nil
+}
+
+// toPlainValue converts the ordered maps of a values file to the maps parameters are parsed from.
+func toPlainValue(value any) any {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		result := make(map[any]any, len(v))
+		for _, item := range v {
+			result[item.Key] = toPlainValue(item.Value)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for i, e := range v {
+			result[i] = toPlainValue(e)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func setParameters[K comparable](parameters map[K]map[string]persistence.ConfigParameter, key K, values map[string]persistence.ConfigParameter) {
+	if parameters[key] == nil {
+		parameters[key] = make(map[string]persistence.ConfigParameter)
+	}
+	for name, value := range values {
+		parameters[key][name] = value
+	}
+}
+
+// forProject returns the default parameters of the given project, nil if none are defined.
+func (v *Values) forProject(projectId string) []persistence.ConfigDefinition {
+	if v == nil || v.defaults[projectId] == nil {
+		return nil
+	}
+	v.matched[projectId] = struct{}{}
+	return []persistence.ConfigDefinition{{Parameters: v.defaults[projectId]}}
+}
+
+// forConfig returns the parameters of the given config, nil if none are defined.
+func (v *Values) forConfig(c coordinate.Coordinate) []persistence.ConfigDefinition {
+	if v == nil || v.configs[c] == nil {
+		return nil
+	}
+	v.matched[c.String()] = struct{}{}
+	return []persistence.ConfigDefinition{{Parameters: v.configs[c]}}
+}
+
+// UnmatchedKeys returns an error for each key that does not match a project of the given manifest projects or, for
+// the given loaded projects, any of their configs, so that values are not silently ignored, e.g. due to a typo. Configs
+// of projects that were not loaded are not known and their keys are not checked.
+func (v *Values) UnmatchedKeys(projects manifest.ProjectDefinitionByProjectID, loadedProjects []string) []error {
+	if v == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, k := range v.keys {
+		if _, found := projects[k.project]; !found {
+			errs = append(errs, newLoadError(k.file, fmt.Errorf("key %q does not match any project of the manifest", k.key)))
+			continue
+		}
+
+		if _, found := v.matched[k.key]; found || k.key == k.project || !slices.Contains(loadedProjects, k.project) {
+			continue
+		}
+		errs = append(errs, newLoadError(k.file, fmt.Errorf("key %q does not match any config of project %q", k.key, k.project)))
+	}
+	return errs
+}
@@ -90,6 +90,8 @@ type ParameterParserContext struct {
 	ParameterName    string
 	Fs               afero.Fs
 	Value            map[string]any
+	// ManifestVariables are the variables the manifest defines for the environment the parameter is parsed for
+	ManifestVariables map[string]string
 }
 
 type ParameterParserError struct {
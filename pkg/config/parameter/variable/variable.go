@@ -0,0 +1,108 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variable
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+// ManifestVariableParameterType specifies the type of the parameter used in config files
+const ManifestVariableParameterType = "manifestVariable"
+
+var ManifestVariableParameterSerde = parameter.ParameterSerDe{
+	Serializer:   writeManifestVariableParameter,
+	Deserializer: parseManifestVariableParameter,
+}
+
+// ManifestVariableParameter defines a parameter holding the value of a variable the manifest defines for the
+// environment (or its group) the config is loaded for. As configs are loaded per environment, the value is looked up
+// while parsing the parameter.
+type ManifestVariableParameter struct {
+	// name of the referenced manifest variable
+	Name string
+
+	// flag indicating that a default value has been set. this is needed, as
+	// we cannot distinguish an empty string from an not set value.
+	HasDefaultValue bool
+
+	// default value used if the manifest does not define the variable specified by `name`.
+	// note: this value is only used, if the `HasDefaultValue` flag is set to true.
+	DefaultValue string
+
+	// Value is the value of the variable for the environment the parameter was parsed for
+	Value string
+}
+
+// this forces the compiler to check if ManifestVariableParameter is of type Parameter
+var _ parameter.Parameter = (*ManifestVariableParameter)(nil)
+
+func (p *ManifestVariableParameter) GetType() string {
+	return ManifestVariableParameterType
+}
+
+func (p *ManifestVariableParameter) GetReferences() []parameter.ParameterReference {
+	// manifest variable parameters cannot have references
+	return []parameter.ParameterReference{}
+}
+
+func (p *ManifestVariableParameter) ResolveValue(_ parameter.ResolveContext) (any, error) {
+	return template.EscapeSpecialCharactersInValue(p.Value, template.FullStringEscapeFunction)
+}
+
+// parseManifestVariableParameter parses a ManifestVariableParameter from a given context.
+// it requires a `name` field to be set. `default` is an optional field, used if the manifest does not define the
+// variable for the environment.
+func parseManifestVariableParameter(context parameter.ParameterParserContext) (parameter.Parameter, error) {
+	nameVal, ok := context.Value["name"]
+	if !ok {
+		return nil, parameter.NewParameterParserError(context, "missing property `name`")
+	}
+
+	p := &ManifestVariableParameter{Name: strings.ToString(nameVal)}
+	if val, ok := context.Value["default"]; ok {
+		p.HasDefaultValue = true
+		p.DefaultValue = strings.ToString(val)
+	}
+
+	if val, found := context.ManifestVariables[p.Name]; found {
+		p.Value = val
+	} else if p.HasDefaultValue {
+		p.Value = p.DefaultValue
+	} else {
+		return nil, parameter.NewParameterParserError(context, fmt.Sprintf("manifest variable `%s` is not defined for the environment", p.Name))
+	}
+
+	return p, nil
+}
+
+func writeManifestVariableParameter(context parameter.ParameterWriterContext) (map[string]any, error) {
+	p, ok := context.Parameter.(*ManifestVariableParameter)
+
+	if !ok {
+		return nil, parameter.NewParameterWriterError(context, "unexpected type. parameter is not of type `ManifestVariableParameter`")
+	}
+
+	result := map[string]any{"name": p.Name}
+
+	if p.HasDefaultValue {
+		result["default"] = p.DefaultValue
+	}
+
+	return result, nil
+}
@@ -0,0 +1,116 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unit
+
+package variable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+)
+
+func TestParseManifestVariableParameter(t *testing.T) {
+	param, err := parseManifestVariableParameter(parameter.ParameterParserContext{
+		Value:             map[string]any{"name": "region"},
+		ManifestVariables: map[string]string{"region": "eu-west"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &ManifestVariableParameter{Name: "region", Value: "eu-west"}, param)
+	assert.Equal(t, ManifestVariableParameterType, param.GetType())
+	assert.Empty(t, param.GetReferences())
+}
+
+func TestParseManifestVariableParameter_UsesDefaultIfVariableIsNotDefined(t *testing.T) {
+	param, err := parseManifestVariableParameter(parameter.ParameterParserContext{
+		Value:             map[string]any{"name": "slackChannel", "default": "C000"},
+		ManifestVariables: map[string]string{"region": "eu-west"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, &ManifestVariableParameter{Name: "slackChannel", HasDefaultValue: true, DefaultValue: "C000", Value: "C000"}, param)
+}
+
+func TestParseManifestVariableParameter_PrefersVariableOverDefault(t *testing.T) {
+	param, err := parseManifestVariableParameter(parameter.ParameterParserContext{
+		Value:             map[string]any{"name": "slackChannel", "default": "C000"},
+		ManifestVariables: map[string]string{"slackChannel": "C123"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "C123", param.(*ManifestVariableParameter).Value)
+}
+
+func TestParseManifestVariableParameter_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value map[string]any
+	}{
+		{"missing name", map[string]any{"default": "value"}},
+		{"undefined variable without default", map[string]any{"name": "undefined"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseManifestVariableParameter(parameter.ParameterParserContext{
+				Value:             tt.value,
+				ManifestVariables: map[string]string{"region": "eu-west"},
+			})
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestResolveValue_EscapesSpecialCharacters(t *testing.T) {
+	fixture := &ManifestVariableParameter{Name: "test", Value: `this is a "test"`}
+
+	result, err := fixture.ResolveValue(parameter.ResolveContext{ParameterName: "test"})
+
+	require.NoError(t, err)
+	assert.Equal(t, `this is a \"test\"`, result)
+}
+
+func TestWriteManifestVariableParameter(t *testing.T) {
+	tests := []struct {
+		name     string
+		param    *ManifestVariableParameter
+		expected map[string]any
+	}{
+		{
+			"without default",
+			&ManifestVariableParameter{Name: "region", Value: "eu-west"},
+			map[string]any{"name": "region"},
+		},
+		{
+			"with default",
+			&ManifestVariableParameter{Name: "region", HasDefaultValue: true, DefaultValue: "us-east", Value: "eu-west"},
+			map[string]any{"name": "region", "default": "us-east"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := writeManifestVariableParameter(parameter.ParameterWriterContext{Parameter: tt.param})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
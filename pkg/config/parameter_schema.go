@@ -0,0 +1,198 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/template"
+)
+
+// ParameterSchemaType is the type of value a parameter declared via a ParameterSchema holds.
+type ParameterSchemaType string
+
+const (
+	IntParameterSchemaType    ParameterSchemaType = "int"
+	BoolParameterSchemaType   ParameterSchemaType = "bool"
+	StringParameterSchemaType ParameterSchemaType = "string"
+	EnumParameterSchemaType   ParameterSchemaType = "enum"
+)
+
+// ParameterSchemas maps parameter names to their declared schema
+type ParameterSchemas map[string]ParameterSchema
+
+// ParameterSchema declares the type and constraints of the value of a parameter.
+type ParameterSchema struct {
+	Type ParameterSchemaType
+	// Min and Max restrict the values of int parameters
+	Min *int
+	Max *int
+	// Pattern is a regular expression values of string parameters must match
+	Pattern string
+	// Values are the allowed values of enum parameters
+	Values []string
+	// Required parameters need to be defined for every environment
+	Required bool
+}
+
+// Validate checks whether the schema itself is valid.
+func (s ParameterSchema) Validate() error {
+	switch s.Type {
+	case IntParameterSchemaType, BoolParameterSchemaType, StringParameterSchemaType:
+	case EnumParameterSchemaType:
+		if len(s.Values) == 0 {
+			return fmt.Errorf("type `%s` requires `values`", EnumParameterSchemaType)
+		}
+	default:
+		return fmt.Errorf("unknown type `%s`. Allowed types: %v", s.Type, []ParameterSchemaType{IntParameterSchemaType, BoolParameterSchemaType, StringParameterSchemaType, EnumParameterSchemaType})
+	}
+
+	if (s.Min != nil || s.Max != nil) && s.Type != IntParameterSchemaType {
+		return fmt.Errorf("`min` and `max` are only supported for type `%s`", IntParameterSchemaType)
+	}
+	if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
+		return fmt.Errorf("`min` (%d) must not be greater than `max` (%d)", *s.Min, *s.Max)
+	}
+
+	if s.Pattern != "" {
+		if s.Type != StringParameterSchemaType {
+			return fmt.Errorf("`pattern` is only supported for type `%s`", StringParameterSchemaType)
+		}
+		if _, err := regexp.Compile(s.Pattern); err != nil {
+			return fmt.Errorf("invalid `pattern`: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Apply checks the given value against the schema and returns it converted to the declared type. Strings, like the
+// values of environment variables, are converted to the declared type if possible.
+func (s ParameterSchema) Apply(value any) (any, error) {
+	switch s.Type {
+	case IntParameterSchemaType:
+		i, err := toInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if s.Min != nil && i < *s.Min {
+			return nil, fmt.Errorf("value %d is less than the minimum %d", i, *s.Min)
+		}
+		if s.Max != nil && i > *s.Max {
+			return nil, fmt.Errorf("value %d is greater than the maximum %d", i, *s.Max)
+		}
+		return i, nil
+
+	case BoolParameterSchemaType:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("value %q is not a bool", fmt.Sprint(value))
+
+	case StringParameterSchemaType:
+		str, err := toScalarString(value)
+		if err != nil {
+			return nil, err
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			return nil, fmt.Errorf("value %q does not match pattern %q", str, s.Pattern)
+		}
+		return str, nil
+
+	case EnumParameterSchemaType:
+		str, err := toScalarString(value)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(s.Values, str) {
+			return nil, fmt.Errorf("value %q is not one of the allowed values %v", str, s.Values)
+		}
+		return str, nil
+	}
+
+	return nil, fmt.Errorf("unknown type `%s`", s.Type)
+}
+
+// applyToResolvedValue works like Apply for values resolved by parameters, whose strings are escaped for use in JSON
+// strings. Strings are checked against the schema unescaped, so patterns and allowed values match the actual value,
+// and are escaped again afterward.
+func (s ParameterSchema) applyToResolvedValue(value any) (any, error) {
+	escaped, ok := value.(string)
+	if !ok {
+		return s.Apply(value)
+	}
+
+	raw, err := template.UnescapeJSONString(escaped)
+	if err != nil {
+		return nil, err
+	}
+
+	typed, err := s.Apply(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if str, ok := typed.(string); ok {
+		return template.FullStringEscapeFunction(str)
+	}
+	return typed, nil
+}
+
+func toInt(value any) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case uint64:
+		if v <= math.MaxInt {
+			return int(v), nil
+		}
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v), nil
+		}
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("value %q is not an int", fmt.Sprint(value))
+}
+
+func toScalarString(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	case nil:
+		return "", errors.New("value is not defined")
+	default:
+		return "", fmt.Errorf("value of type %T is not a scalar", value)
+	}
+}
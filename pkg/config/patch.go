@@ -0,0 +1,66 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"bytes"
+	encJson "encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+)
+
+// Patch modifies the rendered template of a config. If both a JSON Merge Patch and a JSON Patch are defined, the merge
+// patch is applied first.
+type Patch struct {
+	// MergePatch is a JSON Merge Patch (RFC 7396), nil if none is defined
+	MergePatch any
+	// JSONPatch are the operations of a JSON Patch (RFC 6902)
+	JSONPatch []json.PatchOperation
+}
+
+// applyPatches applies the given patches in order to the rendered JSON content.
+func applyPatches(content string, patches []Patch) (string, error) {
+	decoder := encJson.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return "", err
+	}
+
+	for _, p := range patches {
+		if p.MergePatch != nil {
+			doc = json.ApplyMergePatch(doc, p.MergePatch)
+		}
+
+		var err error
+		if doc, err = json.ApplyJSONPatch(doc, p.JSONPatch); err != nil {
+			return "", err
+		}
+	}
+
+	buf := bytes.Buffer{}
+	encoder := encJson.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return "", fmt.Errorf("failed to serialize patched template: %w", err)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
@@ -0,0 +1,471 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package refactor restructures projects without changing what is deployed.
+//
+// Config files are rewritten key by key, so their structure is kept, but comments in rewritten files are lost.
+package refactor
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/configfile"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/internal/persistence"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/loader"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+)
+
+// configEntry is a single config of a config file.
+type configEntry struct {
+	file       *configfile.File
+	index      int
+	definition yaml.MapSlice
+	coordinate coordinate.Coordinate
+	// declaredType is the type the config declares itself. It is empty for configs that inherit their type.
+	declaredType string
+}
+
+// Options define how a config is moved.
+type Options struct {
+	// KeepPreviousID declares the coordinate the config is moved from in `previousIds` or `previousCoordinates` of the
+	// config, so that deploying it updates the object deployed under that coordinate instead of creating a new one.
+	KeepPreviousID bool
+}
+
+// changes are all file changes of a refactoring. They are collected first, and only written once all of them are known
+// to be valid.
+type changes struct {
+	writes  map[string][]byte
+	removes []string
+}
+
+// Move moves the config with the coordinate from, and updates all references to it in all projects of the manifest.
+//
+// If target is the name of a project of the manifest, the config is moved to that project, keeping its ID. Its
+// definition is moved to the file at the same relative path in the target project, and its templates are copied along.
+// Templates that are not used by any other config anymore are removed from the source project.
+// Otherwise, target is the new ID of the config.
+//
+// Previous IDs of a config moved to another project are kept as previous coordinates in its source project.
+//
+// If the config declares `forEach`, the configs generated from it are moved along, and references to them are updated
+// as well. Configs generated by `forEach` can not be moved on their own.
+//
+// Move returns the new coordinate of the config.
+func Move(fs afero.Fs, manifestPath string, from coordinate.Coordinate, target string, opts Options) (coordinate.Coordinate, error) {
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts:         manifestloader.Options{DoNotResolveEnvVars: true},
+	})
+	if len(errs) > 0 {
+		return coordinate.Coordinate{}, fmt.Errorf("failed to load manifest %q: %w", manifestPath, errors.Join(errs...))
+	}
+
+	projectPaths := make(map[string]string, len(m.Projects))
+	for name, p := range m.Projects {
+		projectPaths[name] = filepath.Join(filepath.Dir(manifestPath), p.Path)
+	}
+
+	if _, found := projectPaths[from.Project]; !found {
+		return coordinate.Coordinate{}, fmt.Errorf("project %q is not defined in manifest %q", from.Project, manifestPath)
+	}
+
+	to := from
+	if _, isProject := projectPaths[target]; isProject {
+		to.Project = target
+	} else {
+		to.ConfigId = target
+	}
+	if to == from {
+		return coordinate.Coordinate{}, fmt.Errorf("config %s is already named %q", from, target)
+	}
+
+	var configFiles, defaultsFiles []*configfile.File
+	seen := map[string]struct{}{}
+	for _, name := range slices.Sorted(maps.Keys(projectPaths)) {
+		projectFiles, err := configfile.ReadProject(fs, projectPaths[name], name, seen)
+		if err != nil {
+			return coordinate.Coordinate{}, err
+		}
+		configFiles = append(configFiles, projectFiles...)
+
+		defaults, err := configfile.ReadDefaults(fs, projectPaths[name], name)
+		if err != nil {
+			return coordinate.Coordinate{}, err
+		}
+		if defaults != nil {
+			defaultsFiles = append(defaultsFiles, defaults)
+		}
+	}
+
+	entries, err := configEntries(configFiles)
+	if err != nil {
+		return coordinate.Coordinate{}, err
+	}
+
+	var moved *configEntry
+	for _, e := range entries {
+		switch e.coordinate {
+		case from:
+			if moved == nil {
+				moved = e
+			}
+		case to:
+			return coordinate.Coordinate{}, fmt.Errorf("config %s already exists in %q", to, e.file.Path)
+		}
+	}
+	if moved == nil {
+		if generator := findGenerator(entries, from); generator != nil {
+			return coordinate.Coordinate{}, fmt.Errorf("config %s is generated by the `forEach` of config %s, which has to be moved instead", from, generator.coordinate)
+		}
+		return coordinate.Coordinate{}, fmt.Errorf("config %s not found", from)
+	}
+
+	// configs generated by `forEach` are moved along, previous IDs and coordinates of the config apply to them as well
+	targets := moves{from: to}
+	keys, err := forEachKeys(moved.definition)
+	if err != nil {
+		return coordinate.Coordinate{}, fmt.Errorf("failed to expand `forEach` of config %s: %w", from, err)
+	}
+	for _, key := range keys {
+		targets[forEachCoordinate(from, key)] = forEachCoordinate(to, key)
+	}
+	for _, e := range entries {
+		for _, generated := range targets {
+			if e.coordinate == generated && e != moved {
+				return coordinate.Coordinate{}, fmt.Errorf("config %s already exists in %q", generated, e.file.Path)
+			}
+		}
+	}
+
+	// references are resolved relative to the coordinate of the config they are defined in, so all of them are
+	// updated before the config itself is moved
+	for _, e := range entries {
+		updateReferences(e, targets)
+		updateExtends(e, entries, targets)
+	}
+	for _, d := range defaultsFiles {
+		if err := updateDefaultsReferences(d, entries, targets); err != nil {
+			return coordinate.Coordinate{}, err
+		}
+	}
+
+	c := changes{writes: map[string][]byte{}}
+	configfile.Set(moved.definition, "id", to.ConfigId)
+	if opts.KeepPreviousID || to.Project != from.Project {
+		moved.definition = updatePreviousCoordinates(moved.definition, from, to, opts.KeepPreviousID)
+		configs, _ := configfile.Get(moved.file.Document, "configs")
+		configs.([]any)[moved.index] = moved.definition
+	}
+	moved.file.Changed = true
+
+	if to.Project != from.Project {
+		target, err := moveToProject(fs, moved, entries, configFiles, projectPaths[from.Project], projectPaths[to.Project], &c)
+		if err != nil {
+			return coordinate.Coordinate{}, err
+		}
+		if !slices.Contains(configFiles, target) {
+			configFiles = append(configFiles, target)
+		}
+	}
+
+	for _, f := range slices.Concat(configFiles, defaultsFiles) {
+		if !f.Changed || slices.Contains(c.removes, f.Path) {
+			continue
+		}
+		content, err := f.Marshal()
+		if err != nil {
+			return coordinate.Coordinate{}, err
+		}
+		c.writes[f.Path] = content
+	}
+
+	if err := c.apply(fs); err != nil {
+		return coordinate.Coordinate{}, err
+	}
+	return to, nil
+}
+
+// moveToProject moves the config of the given entry to the config file at the same relative path in the target
+// project, and copies its templates along. It returns the file the config is moved to.
+func moveToProject(fs afero.Fs, moved *configEntry, entries []*configEntry, configFiles []*configfile.File, sourcePath string, targetPath string, c *changes) (*configfile.File, error) {
+	rel, err := filepath.Rel(sourcePath, moved.file.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path of %q in project %q: %w", moved.file.Path, moved.file.Project, err)
+	}
+	targetFilePath := filepath.Join(targetPath, rel)
+
+	var target *configfile.File
+	for _, f := range configFiles {
+		if f.Path == targetFilePath {
+			target = f
+		}
+	}
+	if target == nil {
+		if exists, _ := afero.Exists(fs, targetFilePath); exists {
+			return nil, fmt.Errorf("can not move config to %q, as the file exists and is not a config file", targetFilePath)
+		}
+		target = &configfile.File{Path: targetFilePath, Document: yaml.MapSlice{{Key: "configs", Value: []any{}}}}
+	}
+
+	for _, t := range configfile.TemplatesOf(moved.definition) {
+		source := filepath.Join(filepath.Dir(moved.file.Path), filepath.FromSlash(t))
+		destination := filepath.Join(filepath.Dir(targetFilePath), filepath.FromSlash(t))
+		if source == destination {
+			continue
+		}
+
+		content, err := afero.ReadFile(fs, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %q: %w", source, err)
+		}
+		if existing, err := afero.ReadFile(fs, destination); err == nil {
+			if string(existing) != string(content) {
+				return nil, fmt.Errorf("can not copy template %q to %q, as a different file already exists there", source, destination)
+			}
+		} else {
+			c.writes[destination] = content
+		}
+
+		if !usedByOthers(source, moved, entries) {
+			c.removes = append(c.removes, source)
+		}
+	}
+
+	sourceConfigs, _ := configfile.Get(moved.file.Document, "configs")
+	remaining := slices.Delete(slices.Clone(sourceConfigs.([]any)), moved.index, moved.index+1)
+	if len(remaining) == 0 && len(moved.file.Document) == 1 {
+		c.removes = append(c.removes, moved.file.Path)
+	} else {
+		configfile.Set(moved.file.Document, "configs", remaining)
+	}
+
+	targetConfigs, _ := configfile.Get(target.Document, "configs")
+	configfile.Set(target.Document, "configs", append(slices.Clone(targetConfigs.([]any)), moved.definition))
+	target.Changed = true
+
+	return target, nil
+}
+
+// updatePreviousCoordinates returns the given definition of the config moved from one coordinate to the other, with its
+// previous IDs and coordinates kept valid. If keep is set, the coordinate the config is moved from is added.
+// Previous IDs and coordinates that equal the new coordinate of the config are removed.
+func updatePreviousCoordinates(definition yaml.MapSlice, from coordinate.Coordinate, to coordinate.Coordinate, keep bool) yaml.MapSlice {
+	v, _ := configfile.Get(definition, "previousIds")
+	ids, _ := v.([]any)
+	ids = slices.Clone(ids)
+	v, _ = configfile.Get(definition, "previousCoordinates")
+	coordinates, _ := v.([]any)
+	coordinates = slices.Clone(coordinates)
+
+	// previous IDs and coordinates without project refer to the project of the config
+	if to.Project != from.Project {
+		for i, c := range coordinates {
+			if c, ok := c.(yaml.MapSlice); ok {
+				if _, found := configfile.Get(c, "project"); !found {
+					coordinates[i] = append(yaml.MapSlice{{Key: "project", Value: from.Project}}, c...)
+				}
+			}
+		}
+		for _, id := range ids {
+			coordinates = append(coordinates, previousCoordinate(from.Project, from.Type, id))
+		}
+		ids = nil
+	}
+
+	if keep && to.Project == from.Project {
+		ids = append(ids, from.ConfigId)
+	} else if keep {
+		coordinates = append(coordinates, previousCoordinate(from.Project, from.Type, from.ConfigId))
+	}
+
+	ids = slices.DeleteFunc(ids, func(id any) bool { return fmt.Sprint(id) == to.ConfigId })
+	coordinates = slices.DeleteFunc(coordinates, func(c any) bool {
+		m, ok := c.(yaml.MapSlice)
+		if !ok {
+			return false
+		}
+		previous := to
+		for key, field := range map[string]*string{"project": &previous.Project, "configType": &previous.Type, "configId": &previous.ConfigId} {
+			if value, found := configfile.Get(m, key); found {
+				*field = fmt.Sprint(value)
+			}
+		}
+		return previous == to
+	})
+
+	definition = withValue(definition, "previousIds", ids, "id", "extends", "forEach", "config", "type")
+	return withValue(definition, "previousCoordinates", coordinates, "id", "extends", "forEach", "config", "type", "previousIds")
+}
+
+func previousCoordinate(project string, configType string, configID any) yaml.MapSlice {
+	return yaml.MapSlice{{Key: "project", Value: project}, {Key: "configType", Value: configType}, {Key: "configId", Value: configID}}
+}
+
+// withValue returns the given map with the key set to the list. If the key is not set yet, it is inserted after the
+// last of the given keys that is set. Empty lists are removed.
+func withValue(m yaml.MapSlice, key string, list []any, after ...string) yaml.MapSlice {
+	index := slices.IndexFunc(m, func(item yaml.MapItem) bool { return item.Key == key })
+	switch {
+	case len(list) == 0 && index >= 0:
+		return slices.Delete(m, index, index+1)
+	case len(list) == 0:
+		return m
+	case index >= 0:
+		m[index].Value = list
+		return m
+	}
+
+	position := 0
+	for i, item := range m {
+		if slices.Contains(after, fmt.Sprint(item.Key)) {
+			position = i + 1
+		}
+	}
+	return slices.Insert(m, position, yaml.MapItem{Key: key, Value: list})
+}
+
+// usedByOthers returns whether the template at the given path is used by any config but the moved one.
+func usedByOthers(template string, moved *configEntry, entries []*configEntry) bool {
+	for _, e := range entries {
+		if e == moved {
+			continue
+		}
+		for _, t := range configfile.TemplatesOf(e.definition) {
+			if filepath.Join(filepath.Dir(e.file.Path), filepath.FromSlash(t)) == template {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (c changes) apply(fs afero.Fs) error {
+	for _, path := range slices.Sorted(maps.Keys(c.writes)) {
+		if err := fs.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory of %q: %w", path, err)
+		}
+		if err := afero.WriteFile(fs, path, c.writes[path], 0644); err != nil {
+			return fmt.Errorf("failed to write %q: %w", path, err)
+		}
+		log.Info("Updated %s", path)
+	}
+
+	for _, path := range c.removes {
+		if err := fs.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %q: %w", path, err)
+		}
+		log.Info("Removed %s", path)
+	}
+	return nil
+}
+
+// configEntries returns all configs of the given files with their coordinates. Configs that do not declare a type
+// have the type of the config they extend.
+func configEntries(configFiles []*configfile.File) ([]*configEntry, error) {
+	var result []*configEntry
+	for _, f := range configFiles {
+		configs, _ := configfile.Get(f.Document, "configs")
+		for i, item := range configs.([]any) {
+			definition, ok := item.(yaml.MapSlice)
+			if !ok {
+				continue
+			}
+
+			id, _ := configfile.Get(definition, "id")
+			configType, err := typeOf(definition)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse type of config %q in %q: %w", id, f.Path, err)
+			}
+
+			result = append(result, &configEntry{
+				file:         f,
+				index:        i,
+				definition:   definition,
+				coordinate:   coordinate.Coordinate{Project: f.Project, Type: configType, ConfigId: fmt.Sprint(id)},
+				declaredType: configType,
+			})
+		}
+	}
+
+	resolveInheritedTypes(result)
+	return result, nil
+}
+
+// forEachKeys returns the keys of the configs generated from the given config by `forEach`, nil if it does not declare
+// `forEach`.
+func forEachKeys(definition yaml.MapSlice) ([]string, error) {
+	v, found := configfile.Get(definition, "forEach")
+	if !found {
+		return nil, nil
+	}
+
+	// the loader reads `forEach` as plain YAML, not as ordered map
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var forEach any
+	if err := yaml.Unmarshal(data, &forEach); err != nil {
+		return nil, err
+	}
+	return loader.ForEachKeys(forEach)
+}
+
+// forEachCoordinate returns the coordinate of the config generated for the given key from the config with coordinate c.
+func forEachCoordinate(c coordinate.Coordinate, key string) coordinate.Coordinate {
+	c.ConfigId = loader.ForEachConfigID(c.ConfigId, key)
+	return c
+}
+
+// findGenerator returns the config declaring the `forEach` that generates the config with the given coordinate, nil if
+// there is none.
+func findGenerator(entries []*configEntry, c coordinate.Coordinate) *configEntry {
+	for _, e := range entries {
+		keys, _ := forEachKeys(e.definition)
+		for _, key := range keys {
+			if forEachCoordinate(e.coordinate, key) == c {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// typeOf returns the type of the given config, as used in its coordinate.
+func typeOf(definition yaml.MapSlice) (string, error) {
+	t, _ := configfile.Get(definition, "type")
+	data, err := yaml.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	var typeDefinition persistence.TypeDefinition
+	if err := yaml.Unmarshal(data, &typeDefinition); err != nil {
+		return "", err
+	}
+	return typeDefinition.GetApiType(), nil
+}
@@ -0,0 +1,413 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package refactor
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+const testManifest = `manifestVersion: 1.0
+projects: [{name: project}, {name: other}]
+`
+
+const testConfigs = `configs:
+- id: zone
+  type:
+    api: management-zone
+  config:
+    name: Zone
+    template: zone.json
+- id: profile
+  type:
+    api: alerting-profile
+  config:
+    name: Profile
+    parameters:
+      zone: [management-zone, zone, id]
+      self: [name]
+    template: profile.json
+- id: rule
+  type:
+    settings:
+      schema: builtin:some.schema
+      scope:
+        type: reference
+        configType: management-zone
+        configId: zone
+        property: id
+  config:
+    name: Rule
+    template: profile.json
+`
+
+const testOtherConfigs = `configs:
+- id: dashboard
+  type:
+    api: dashboard
+  config:
+    name: [project, management-zone, zone, name]
+    template: dashboard.json
+`
+
+var zone = coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "zone"}
+
+func newTestFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		"manifest.yaml":        testManifest,
+		"project/configs.yaml": testConfigs,
+		"project/zone.json":    `{"name": "{{.name}}"}`,
+		"project/profile.json": `{"name": "{{.name}}"}`,
+		"other/configs.yaml":   testOtherConfigs,
+		"other/dashboard.json": `{}`,
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+	return fs
+}
+
+func readFile(t *testing.T, fs afero.Fs, path string) string {
+	t.Helper()
+
+	content, err := afero.ReadFile(fs, path)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestMove_RenamesConfigAndUpdatesReferences(t *testing.T) {
+	fs := newTestFs(t)
+
+	to, err := Move(fs, "manifest.yaml", zone, "main-zone", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "main-zone"}, to)
+
+	assert.Equal(t, `configs:
+- id: main-zone
+  type:
+    api: management-zone
+  config:
+    name: Zone
+    template: zone.json
+- id: profile
+  type:
+    api: alerting-profile
+  config:
+    name: Profile
+    parameters:
+      zone:
+      - management-zone
+      - main-zone
+      - id
+      self:
+      - name
+    template: profile.json
+- id: rule
+  type:
+    settings:
+      schema: builtin:some.schema
+      scope:
+        type: reference
+        configType: management-zone
+        configId: main-zone
+        property: id
+  config:
+    name: Rule
+    template: profile.json
+`, readFile(t, fs, "project/configs.yaml"))
+
+	assert.Contains(t, readFile(t, fs, "other/configs.yaml"), "name:\n    - project\n    - management-zone\n    - main-zone\n    - name\n")
+	assert.Equal(t, `{"name": "{{.name}}"}`, readFile(t, fs, "project/zone.json"))
+}
+
+func TestMove_MovesConfigToOtherProject(t *testing.T) {
+	fs := newTestFs(t)
+
+	to, err := Move(fs, "manifest.yaml", zone, "other", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, coordinate.Coordinate{Project: "other", Type: "management-zone", ConfigId: "zone"}, to)
+
+	project := readFile(t, fs, "project/configs.yaml")
+	assert.NotContains(t, project, "id: zone\n")
+	assert.Contains(t, project, "zone:\n      - other\n      - management-zone\n      - zone\n      - id\n", "short references are extended to include the project")
+	assert.Contains(t, project, "scope:\n        type: reference\n        project: other\n        configType: management-zone\n        configId: zone\n        property: id\n", "missing fields are added to references")
+
+	other := readFile(t, fs, "other/configs.yaml")
+	assert.Contains(t, other, "- id: zone\n  type:\n    api: management-zone\n")
+	assert.Contains(t, other, "name:\n    - other\n    - management-zone\n    - zone\n    - name\n", "fields that are already set are kept")
+
+	assert.Equal(t, `{"name": "{{.name}}"}`, readFile(t, fs, "other/zone.json"))
+	exists, err := afero.Exists(fs, "project/zone.json")
+	require.NoError(t, err)
+	assert.False(t, exists, "templates not used anymore are removed")
+}
+
+func TestMove_MovedConfigKeepsReferencesToItsOldProject(t *testing.T) {
+	fs := newTestFs(t)
+
+	_, err := Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "profile"}, "other", Options{})
+	require.NoError(t, err)
+
+	other := readFile(t, fs, "other/configs.yaml")
+	assert.Contains(t, other, "zone:\n      - project\n      - management-zone\n      - zone\n      - id\n")
+	assert.Contains(t, other, "self:\n      - name\n")
+	assert.Equal(t, `{"name": "{{.name}}"}`, readFile(t, fs, "other/profile.json"))
+	assert.Equal(t, `{"name": "{{.name}}"}`, readFile(t, fs, "project/profile.json"), "templates still used by other configs are kept")
+}
+
+func TestMove_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		from          coordinate.Coordinate
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "unknown project",
+			from:          coordinate.Coordinate{Project: "unknown", Type: "management-zone", ConfigId: "zone"},
+			target:        "new",
+			expectedError: `project "unknown" is not defined`,
+		},
+		{
+			name:          "unknown config",
+			from:          coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "unknown"},
+			target:        "new",
+			expectedError: "config project:management-zone:unknown not found",
+		},
+		{
+			name:          "target exists",
+			from:          coordinate.Coordinate{Project: "project", Type: "alerting-profile", ConfigId: "profile"},
+			target:        "profile",
+			expectedError: "is already named",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newTestFs(t)
+
+			_, err := Move(fs, "manifest.yaml", tt.from, tt.target, Options{})
+			assert.ErrorContains(t, err, tt.expectedError)
+			assert.Equal(t, testConfigs, readFile(t, fs, "project/configs.yaml"))
+		})
+	}
+}
+
+func TestMove_FailsIfTargetCoordinateExists(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "other/zone.yaml", []byte("configs:\n- id: zone\n  type: {api: management-zone}\n  config: {name: Zone, template: ../project/zone.json}\n"), 0644))
+
+	_, err := Move(fs, "manifest.yaml", zone, "other", Options{})
+	assert.ErrorContains(t, err, "config other:management-zone:zone already exists")
+	assert.Equal(t, testConfigs, readFile(t, fs, "project/configs.yaml"))
+}
+
+func TestMove_UpdatesExtendsReferences(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "project/extending.yaml", []byte("configs:\n- id: child\n  extends: zone\n  config: {name: Child}\n"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "other/extending.yaml", []byte("configs:\n- id: child\n  extends: project:management-zone:zone\n  config: {name: Child}\n"), 0644))
+
+	_, err := Move(fs, "manifest.yaml", zone, "main-zone", Options{})
+	require.NoError(t, err)
+	assert.Contains(t, readFile(t, fs, "project/extending.yaml"), "extends: main-zone\n")
+	assert.Contains(t, readFile(t, fs, "other/extending.yaml"), "extends: project:management-zone:main-zone\n")
+
+	_, err = Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "main-zone"}, "other", Options{})
+	require.NoError(t, err)
+	assert.Contains(t, readFile(t, fs, "project/extending.yaml"), "extends: other:management-zone:main-zone\n", "config IDs can only refer to configs of the same project")
+	assert.Contains(t, readFile(t, fs, "other/extending.yaml"), "extends: other:management-zone:main-zone\n")
+}
+
+func TestMove_MovesConfigsThatInheritTheirType(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "project/extending.yaml", []byte("configs:\n- id: child\n  extends: zone\n  config: {name: Child}\n"), 0644))
+
+	_, err := Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "child"}, "other", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "configs:\n- id: child\n  extends: project:management-zone:zone\n  config:\n    name: Child\n", readFile(t, fs, "other/extending.yaml"))
+}
+
+func TestMove_UpdatesDefaultsReferences(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "project/_defaults.yaml", []byte(`parameters:
+  zone: [management-zone, zone, id]
+  threshold: 5
+environmentOverrides:
+- environment: env
+  override:
+    parameters:
+      zoneName: {type: reference, configType: management-zone, configId: zone, property: name}
+`), 0644))
+
+	_, err := Move(fs, "manifest.yaml", zone, "main-zone", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, `parameters:
+  zone:
+  - management-zone
+  - main-zone
+  - id
+  threshold: 5
+environmentOverrides:
+- environment: env
+  override:
+    parameters:
+      zoneName:
+        type: reference
+        configType: management-zone
+        configId: main-zone
+        property: name
+`, readFile(t, fs, "project/_defaults.yaml"))
+
+	_, err = Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "main-zone"}, "other", Options{})
+	require.NoError(t, err)
+	defaults := readFile(t, fs, "project/_defaults.yaml")
+	assert.Contains(t, defaults, "zone:\n  - other\n  - management-zone\n  - main-zone\n  - id\n")
+	assert.Contains(t, defaults, "project: other\n        configType: management-zone\n        configId: main-zone\n")
+}
+
+func TestMove_FailsIfDefaultsReferenceDifferentConfigs(t *testing.T) {
+	fs := newTestFs(t)
+	defaults := "parameters:\n  zone: [zone, id]\n"
+	require.NoError(t, afero.WriteFile(fs, "project/_defaults.yaml", []byte(defaults), 0644))
+
+	_, err := Move(fs, "manifest.yaml", zone, "main-zone", Options{})
+	assert.ErrorContains(t, err, `can not update parameter "zone"`)
+	assert.Equal(t, defaults, readFile(t, fs, "project/_defaults.yaml"))
+	assert.Equal(t, testConfigs, readFile(t, fs, "project/configs.yaml"))
+}
+
+func TestMove_KeepPreviousID(t *testing.T) {
+	fs := newTestFs(t)
+
+	_, err := Move(fs, "manifest.yaml", zone, "main-zone", Options{KeepPreviousID: true})
+	require.NoError(t, err)
+	assert.Contains(t, readFile(t, fs, "project/configs.yaml"), `- id: main-zone
+  type:
+    api: management-zone
+  config:
+    name: Zone
+    template: zone.json
+  previousIds:
+  - zone
+`)
+
+	_, err = Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "main-zone"}, "other", Options{KeepPreviousID: true})
+	require.NoError(t, err)
+	assert.Contains(t, readFile(t, fs, "other/configs.yaml"), `- id: main-zone
+  type:
+    api: management-zone
+  config:
+    name: Zone
+    template: zone.json
+  previousCoordinates:
+  - project: project
+    configType: management-zone
+    configId: zone
+  - project: project
+    configType: management-zone
+    configId: main-zone
+`, "previous IDs are kept as coordinates of the source project")
+
+	_, err = Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "other", Type: "management-zone", ConfigId: "main-zone"}, "project", Options{KeepPreviousID: true})
+	require.NoError(t, err)
+	assert.Contains(t, readFile(t, fs, "project/configs.yaml"), `- id: main-zone
+  type:
+    api: management-zone
+  config:
+    name: Zone
+    template: zone.json
+  previousCoordinates:
+  - project: project
+    configType: management-zone
+    configId: zone
+  - project: other
+    configType: management-zone
+    configId: main-zone
+`, "the coordinate the config is moved to is removed")
+}
+
+func TestMove_MovesConfigsGeneratedByForEach(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "project/monitors.yaml", []byte(`configs:
+- id: monitor
+  forEach: [a, {key: b}]
+  type: {api: management-zone}
+  config: {name: Monitor, template: zone.json}
+- id: user
+  type: {api: alerting-profile}
+  config:
+    name: User
+    parameters:
+      a: [management-zone, monitor-a, id]
+      b: {type: reference, configType: management-zone, configId: monitor-b, property: id}
+    template: profile.json
+`), 0644))
+
+	_, err := Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "monitor"}, "check", Options{KeepPreviousID: true})
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+- id: check
+  forEach:
+  - a
+  - key: b
+  type:
+    api: management-zone
+  config:
+    name: Monitor
+    template: zone.json
+  previousIds:
+  - monitor
+- id: user
+  type:
+    api: alerting-profile
+  config:
+    name: User
+    parameters:
+      a:
+      - management-zone
+      - check-a
+      - id
+      b:
+        type: reference
+        configType: management-zone
+        configId: check-b
+        property: id
+    template: profile.json
+`, readFile(t, fs, "project/monitors.yaml"), "previous IDs are expanded for each item like the ID")
+
+	_, err = Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "check-a"}, "other", Options{})
+	assert.ErrorContains(t, err, "config project:management-zone:check-a is generated by the `forEach` of config project:management-zone:check")
+}
+
+func TestMove_FailsIfCoordinateOfGeneratedConfigExists(t *testing.T) {
+	fs := newTestFs(t)
+	monitors := "configs:\n- id: monitor\n  forEach: [a]\n  type: {api: management-zone}\n  config: {name: Monitor, template: zone.json}\n- id: check-a\n  type: {api: management-zone}\n  config: {name: Check, template: zone.json}\n"
+	require.NoError(t, afero.WriteFile(fs, "project/monitors.yaml", []byte(monitors), 0644))
+
+	_, err := Move(fs, "manifest.yaml", coordinate.Coordinate{Project: "project", Type: "management-zone", ConfigId: "monitor"}, "check", Options{})
+	assert.ErrorContains(t, err, "config project:management-zone:check-a already exists")
+	assert.Equal(t, monitors, readFile(t, fs, "project/monitors.yaml"))
+}
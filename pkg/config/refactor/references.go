@@ -0,0 +1,200 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package refactor
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/configfile"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// updateReferences rewrites all references of the config of the given entry that point to moved configs, so that they
+// point to their new coordinates. If the config itself is moved, its references to other configs are rewritten to stay
+// valid as well. References are all parameters of configfile.ForEachReferenceParameter.
+func updateReferences(e *configEntry, m moves) {
+	owner := e.coordinate
+	newOwner := m.of(owner)
+
+	update := func(parent yaml.MapSlice, key string) {
+		for i := range parent {
+			if parent[i].Key != key {
+				continue
+			}
+
+			ref, ok := configfile.ReferencedCoordinate(parent[i].Value, owner)
+			if !ok {
+				return
+			}
+			if updated := configfile.RewriteReference(parent[i].Value, m.of(ref), newOwner); !reflect.DeepEqual(updated, parent[i].Value) {
+				parent[i].Value = updated
+				e.file.Changed = true
+			}
+			return
+		}
+	}
+
+	configfile.ForEachReferenceParameter(e.definition, update)
+}
+
+// updateExtends rewrites the `extends` reference of the config of the given entry if the config it extends is moved, or
+// if the config ID it refers to would identify another config after the move.
+func updateExtends(e *configEntry, entries []*configEntry, m moves) {
+	value, found := configfile.Get(e.definition, "extends")
+	if !found {
+		return
+	}
+	reference := fmt.Sprint(value)
+
+	current := func(c *configEntry) coordinate.Coordinate { return c.coordinate }
+	base := findExtended(entries, current, e.coordinate.Project, e.declaredType, reference)
+	if base == nil {
+		return
+	}
+
+	moved := func(c *configEntry) coordinate.Coordinate { return m.of(c.coordinate) }
+	newOwner := moved(e)
+	newBase := moved(base)
+
+	updated := newBase.String()
+	if !strings.Contains(reference, ":") && newBase.Project == newOwner.Project &&
+		findExtended(entries, moved, newOwner.Project, e.declaredType, newBase.ConfigId) == base {
+		updated = newBase.ConfigId
+	}
+
+	if updated != reference {
+		configfile.Set(e.definition, "extends", updated)
+		e.file.Changed = true
+	}
+}
+
+// findExtended returns the config an `extends` reference of a config of the given project and declared type points
+// to, the same way the config loader resolves it. coordinateOf returns the coordinate of each config. If the reference
+// can not be resolved unambiguously, nil is returned.
+func findExtended(entries []*configEntry, coordinateOf func(*configEntry) coordinate.Coordinate, project string, configType string, reference string) *configEntry {
+	id := reference
+	explicit := strings.Contains(reference, ":")
+	if explicit {
+		first, last := strings.Index(reference, ":"), strings.LastIndex(reference, ":")
+		if first == last {
+			return nil
+		}
+		project, configType, id = reference[:first], reference[first+1:last], reference[last+1:]
+	}
+
+	var candidates []*configEntry
+	for _, c := range entries {
+		if cc := coordinateOf(c); cc.Project == project && cc.ConfigId == id {
+			candidates = append(candidates, c)
+		}
+	}
+
+	if explicit || (len(candidates) > 1 && configType != "") {
+		candidates = slices.DeleteFunc(candidates, func(c *configEntry) bool { return c.declaredType != configType })
+	}
+	if len(candidates) != 1 {
+		return nil
+	}
+	return candidates[0]
+}
+
+// resolveInheritedTypes sets the type of configs that do not declare a type to the type of the config they extend.
+func resolveInheritedTypes(entries []*configEntry) {
+	current := func(c *configEntry) coordinate.Coordinate { return c.coordinate }
+
+	for _, e := range entries {
+		base := e
+		// extends chains can not be longer than the number of configs, unless they are cyclic
+		for range entries {
+			if base == nil || base.declaredType != "" {
+				break
+			}
+			reference, found := configfile.Get(base.definition, "extends")
+			if !found {
+				base = nil
+				break
+			}
+			base = findExtended(entries, current, base.coordinate.Project, "", fmt.Sprint(reference))
+		}
+
+		if base != nil {
+			e.coordinate.Type = base.declaredType
+		}
+	}
+}
+
+// updateDefaultsReferences rewrites all references of the given defaults file that point to moved configs. References of
+// defaults are resolved relative to each config of the project that inherits them, so a reference is only rewritten if
+// it points to the same configs for all of them afterward. Otherwise, an error is returned.
+func updateDefaultsReferences(defaults *configfile.File, entries []*configEntry, m moves) error {
+	var owners []coordinate.Coordinate
+	for _, e := range entries {
+		// a config moved to another project inherits the defaults of its new project instead
+		if e.coordinate.Project == defaults.Project && m.of(e.coordinate).Project == defaults.Project {
+			owners = append(owners, e.coordinate)
+		}
+	}
+
+	for _, d := range append([]yaml.MapSlice{defaults.Document}, configfile.DefinitionsOf(defaults.Document)...) {
+		parameters, _ := configfile.GetMapSlice(d, "parameters")
+		for i, p := range parameters {
+			updated := p.Value
+			for _, owner := range owners {
+				ref, ok := configfile.ReferencedCoordinate(p.Value, owner)
+				if !ok {
+					break
+				}
+				newOwner := m.of(owner)
+				if m.of(ref) == ref && newOwner == owner {
+					continue
+				}
+				updated = configfile.RewriteReference(updated, m.of(ref), newOwner)
+			}
+			if reflect.DeepEqual(updated, p.Value) {
+				continue
+			}
+
+			for _, owner := range owners {
+				ref, _ := configfile.ReferencedCoordinate(p.Value, owner)
+				if newRef, _ := configfile.ReferencedCoordinate(updated, m.of(owner)); newRef != m.of(ref) {
+					return fmt.Errorf("can not update parameter %q of %q, as it references different configs for the configs of project %q - please update it manually", p.Key, defaults.Path, defaults.Project)
+				}
+			}
+
+			parameters[i].Value = updated
+			defaults.Changed = true
+		}
+	}
+	return nil
+}
+
+// moves maps the coordinates of all configs that are moved to their new coordinates. Besides the moved config itself,
+// these are the configs generated from it by `forEach`.
+type moves map[coordinate.Coordinate]coordinate.Coordinate
+
+// of returns the coordinate c is moved to, or c if it is not moved.
+func (m moves) of(c coordinate.Coordinate) coordinate.Coordinate {
+	if to, found := m[c]; found {
+		return to
+	}
+	return c
+}
@@ -22,12 +22,14 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 )
 
-// resolveValues validates and resolves the given sorted parameters into actual values
-func resolveValues(c *Config, entities EntityLookup, parameters []parameter.NamedParameter) (parameter.Properties, []error) {
+// resolveValues validates and resolves the given sorted parameters into actual values.
+// The builtins are available to all parameters, unless a parameter of the same name exists, but are not returned.
+func resolveValues(c *Config, entities EntityLookup, parameters []parameter.NamedParameter, builtins parameter.Properties) (parameter.Properties, []error) {
 
 	var errors []error
 
 	properties := make(parameter.Properties)
+	available := withBuiltins(properties, builtins)
 
 	for _, container := range parameters {
 		name := container.Name
@@ -46,7 +48,7 @@ func resolveValues(c *Config, entities EntityLookup, parameters []parameter.Name
 			Group:                   c.Group,
 			Environment:             c.Environment,
 			ParameterName:           name,
-			ResolvedParameterValues: properties,
+			ResolvedParameterValues: available,
 		})
 
 		if err != nil {
@@ -54,11 +56,18 @@ func resolveValues(c *Config, entities EntityLookup, parameters []parameter.Name
 			continue
 		}
 
+		if schema, found := c.ParameterSchemas[name]; found {
+			if val, err = schema.applyToResolvedValue(val); err != nil {
+				errors = append(errors, newParamsSchemaErr(c.Coordinate, c.Group, c.Environment, name, err))
+				continue
+			}
+		}
+
 		if name == NameParameter {
-			properties[name] = strings.ToString(val)
-		} else {
-			properties[name] = val
+			val = strings.ToString(val)
 		}
+		properties[name] = val
+		available[name] = val
 	}
 
 	if len(errors) > 0 {
@@ -0,0 +1,204 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	parsetree "text/template/parse"
+)
+
+// Fields returns the sorted names of all top-level properties the given template accesses, e.g. 'name' for
+// {{ .name }} or 'environment' for {{ .environment.name }}. Properties accessed by partials included with the
+// properties of the template, like {{ include "header" . }}, and properties read using index, like
+// {{ index . "name" }}, are part of the result as well.
+// Properties accessed relative to a changed dot, e.g. within {{ range }} or {{ with }}, can not be attributed to a
+// top-level property and are not part of the result, unless they are accessed via '$'.
+func Fields(template Template) ([]string, error) {
+	c, err := collectFields(template)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Sorted(maps.Keys(c.fields)), nil
+}
+
+// OptionalFields returns the sorted names of the top-level properties of Fields that the given template only passes to
+// the functions accepting undefined values, like {{ .optional | default "fallback" }} or {{ required "message" .id }}.
+// Rendering the template does not fail because of these properties being undefined.
+func OptionalFields(template Template) ([]string, error) {
+	c, err := collectFields(template)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []string
+	for _, name := range slices.Sorted(maps.Keys(c.fields)) {
+		if _, accessed := c.accessed[name]; !accessed {
+			result = append(result, name)
+		}
+	}
+	return result, nil
+}
+
+func collectFields(template Template) (*fieldCollector, error) {
+	content, err := template.Content()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template %s: %w", template.ID(), err)
+	}
+
+	var partials *Partials
+	if p, ok := template.(partialsProvider); ok {
+		partials = p.Partials()
+	}
+
+	c := fieldCollector{fields: map[string]struct{}{}, accessed: map[string]struct{}{}, partials: partials, visited: map[string]struct{}{}}
+	if err := c.collect(template.ID(), content); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+type fieldCollector struct {
+	fields map[string]struct{}
+	// accessed holds the names of all fields that are accessed directly, and not via index like optional fields are
+	accessed map[string]struct{}
+	partials *Partials
+	// visited holds the names of all partials already collected, so that each partial is only collected once
+	visited map[string]struct{}
+}
+
+func (c *fieldCollector) collect(id string, content string) error {
+	// the template is parsed like for rendering, so that optional fields are accessed via index
+	parsed, err := parse(id, content)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", id, err)
+	}
+	if parsed.Tree != nil {
+		c.list(parsed.Tree.Root, true)
+	}
+	return nil
+}
+
+// list collects the fields of all nodes of the given list. topLevel defines whether dot refers to the properties of the
+// template within the list.
+func (c *fieldCollector) list(list *parsetree.ListNode, topLevel bool) {
+	if list == nil {
+		return
+	}
+	for _, n := range list.Nodes {
+		c.node(n, topLevel)
+	}
+}
+
+func (c *fieldCollector) node(node parsetree.Node, topLevel bool) {
+	switch n := node.(type) {
+	case *parsetree.ActionNode:
+		c.pipe(n.Pipe, topLevel)
+	case *parsetree.IfNode:
+		c.pipe(n.Pipe, topLevel)
+		c.list(n.List, topLevel)
+		c.list(n.ElseList, topLevel)
+	case *parsetree.RangeNode:
+		c.pipe(n.Pipe, topLevel)
+		c.list(n.List, false)
+		c.list(n.ElseList, topLevel)
+	case *parsetree.WithNode:
+		c.pipe(n.Pipe, topLevel)
+		c.list(n.List, false)
+		c.list(n.ElseList, topLevel)
+	case *parsetree.ListNode:
+		c.list(n, topLevel)
+	}
+}
+
+func (c *fieldCollector) pipe(pipe *parsetree.PipeNode, topLevel bool) {
+	if pipe == nil {
+		return
+	}
+	for _, cmd := range pipe.Cmds {
+		c.include(cmd, topLevel)
+		c.index(cmd, topLevel)
+		for _, arg := range cmd.Args {
+			c.arg(arg, topLevel)
+		}
+	}
+}
+
+func (c *fieldCollector) arg(node parsetree.Node, topLevel bool) {
+	switch n := node.(type) {
+	case *parsetree.FieldNode:
+		if topLevel {
+			c.fields[n.Ident[0]] = struct{}{}
+			c.accessed[n.Ident[0]] = struct{}{}
+		}
+	case *parsetree.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 {
+			c.fields[n.Ident[1]] = struct{}{}
+			c.accessed[n.Ident[1]] = struct{}{}
+		}
+	case *parsetree.ChainNode:
+		c.arg(n.Node, topLevel)
+	case *parsetree.PipeNode:
+		c.pipe(n, topLevel)
+	}
+}
+
+// index collects the field read by the given command, if it reads a top-level property using index, like
+// {{ index . "optional" }}.
+func (c *fieldCollector) index(cmd *parsetree.CommandNode, topLevel bool) {
+	if !topLevel || len(cmd.Args) != 3 {
+		return
+	}
+	if ident, ok := cmd.Args[0].(*parsetree.IdentifierNode); !ok || ident.Ident != "index" {
+		return
+	}
+	if _, isDot := cmd.Args[1].(*parsetree.DotNode); !isDot {
+		return
+	}
+	if name, ok := cmd.Args[2].(*parsetree.StringNode); ok {
+		c.fields[name.Text] = struct{}{}
+	}
+}
+
+// include collects the fields of the partial included by the given command, if the partial is passed the properties of
+// the template.
+func (c *fieldCollector) include(cmd *parsetree.CommandNode, topLevel bool) {
+	if c.partials == nil || !topLevel || len(cmd.Args) != 3 {
+		return
+	}
+	if ident, ok := cmd.Args[0].(*parsetree.IdentifierNode); !ok || ident.Ident != "include" {
+		return
+	}
+	name, ok := cmd.Args[1].(*parsetree.StringNode)
+	if !ok {
+		return
+	}
+	if _, isDot := cmd.Args[2].(*parsetree.DotNode); !isDot {
+		return
+	}
+
+	if _, found := c.visited[name.Text]; found {
+		return
+	}
+	c.visited[name.Text] = struct{}{}
+
+	// partials that can not be loaded fail the rendering of the template, and are reported there
+	if content, err := c.partials.load(name.Text); err == nil {
+		_ = c.collect(name.Text, content)
+	}
+}
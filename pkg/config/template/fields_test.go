@@ -0,0 +1,77 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+)
+
+func TestFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{"no fields", `{"a": 1}`, nil},
+		{"fields and nested fields", `{"name": "{{ .name }}", "env": "{{ .environment.name }}", "again": "{{.name}}"}`, []string{"environment", "name"}},
+		{"fields in functions and pipes", `{"a": {{ toJson .list }}, "b": "{{ .text | upper }}", "c": "{{ default "x" (.fallback) }}"}`, []string{"fallback", "list", "text"}},
+		{"conditions", `{ {{ if .enabled }}"a": "{{ .a }}"{{ else }}"b": "{{ .b }}"{{ end }} }`, []string{"a", "b", "enabled"}},
+		{"optional fields", `{"a": "{{ .a | default "x" }}", "b": "{{ required "b is missing" .b }}", "c": "{{ index . "c" }}"}`, []string{"a", "b", "c"}},
+		{"fields relative to changed dot are skipped", `[ {{ range .items }}"{{ .name }}"{{ end }} ] {{ with .nested }}{{ .x }}{{ $.root }}{{ end }}`, []string{"items", "nested", "root"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := template.Fields(template.NewInMemoryTemplate("test", tt.content))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
+
+func TestOptionalFields(t *testing.T) {
+	content := `{"a": "{{ .a | default "x" }}", "b": "{{ required "b is missing" .b }}", "c": "{{ .c | default "y" }}{{ .c }}", "d": "{{ .d }}"}`
+
+	fields, err := template.OptionalFields(template.NewInMemoryTemplate("test", content))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, fields, "fields that are also accessed directly are not optional")
+}
+
+func TestFields_IncludesFieldsOfPartials(t *testing.T) {
+	tmpl := newTemplateWithPartials(t,
+		`[ {{ include "header" . }}, {{ include "header" . }}, {{ include "markdown" .markdown }} ]`,
+		map[string]string{
+			"header.json":   `{ "name": "{{ .title }}", "nested": {{ include "header" . }} }`,
+			"markdown.json": `{ "markdown": "{{ .text }}" }`,
+		})
+
+	fields, err := template.Fields(tmpl)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"markdown", "title"}, fields)
+}
+
+func TestFields_InvalidTemplate(t *testing.T) {
+	_, err := template.Fields(template.NewInMemoryTemplate("test", `{{ .name `))
+	assert.Error(t, err)
+}
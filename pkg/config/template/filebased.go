@@ -38,6 +38,8 @@ type FileBasedTemplate struct {
 	fs afero.Fs
 	// path of the template file
 	path string
+	// partials the template may include, nil if the template can not include partials
+	partials *Partials
 }
 
 func (t *FileBasedTemplate) ID() string {
@@ -56,6 +58,11 @@ func (t *FileBasedTemplate) FilePath() string {
 	return t.path
 }
 
+// Partials returns the Partials the template may include, or nil if it can not include any.
+func (t *FileBasedTemplate) Partials() *Partials {
+	return t.partials
+}
+
 func (t *FileBasedTemplate) UpdateContent(newContent string) error {
 	f, err := t.fs.Open(t.path)
 	if err != nil {
@@ -70,7 +77,14 @@ func (t *FileBasedTemplate) UpdateContent(newContent string) error {
 
 // NewFileTemplate creates a FileBasedTemplate for a given afero.Fs and filepath.
 // If the file can not be accessed or is a symlink, an error will be returned.
+// To create a FileBasedTemplate that may include partials, use NewFileTemplateWithPartials.
 func NewFileTemplate(fs afero.Fs, path string) (Template, error) {
+	return NewFileTemplateWithPartials(fs, path, nil)
+}
+
+// NewFileTemplateWithPartials creates a FileBasedTemplate for a given afero.Fs and filepath, which may include any of the
+// given Partials. If the file can not be accessed or is a symlink, an error will be returned.
+func NewFileTemplateWithPartials(fs afero.Fs, path string, partials *Partials) (Template, error) {
 	sanitizedPath := filepath.Clean(strings.ReplaceAll(path, `\`, `/`))
 
 	log.Debug("Loading template for %s", sanitizedPath)
@@ -86,8 +100,9 @@ func NewFileTemplate(fs afero.Fs, path string) (Template, error) {
 	}
 
 	template := FileBasedTemplate{
-		fs:   fs,
-		path: sanitizedPath,
+		fs:       fs,
+		path:     sanitizedPath,
+		partials: partials,
 	}
 
 	return &template, nil
@@ -0,0 +1,417 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	templ "text/template"
+	parsetree "text/template/parse"
+	"time"
+)
+
+// templateFuncs holds the functions available in every template monaco parses.
+// Functions are deliberately limited to deterministic ones - e.g. there is no access to the current time or the
+// environment - so that rendering a template only depends on its parameters.
+var templateFuncs = templ.FuncMap{
+	"include":    includeUnavailable,
+	"toJson":     toJson,
+	"escape":     escape,
+	"quote":      quote,
+	"default":    defaultValue,
+	"required":   required,
+	"join":       join,
+	"split":      split,
+	"upper":      textFunc(strings.ToUpper),
+	"lower":      textFunc(strings.ToLower),
+	"indent":     indent,
+	"formatDate": formatDate,
+	"b64enc":     b64enc,
+}
+
+// escapedString is a string that is escaped for use inside a JSON string. Strings passed to a template as properties
+// are usually escaped - resolved 'value', 'environment' and 'compound' parameters are escaped when resolving them - and
+// are marked as escapedString when rendering, see markEscaped. Template functions operate on the unescaped text and return their
+// results as escapedString again, so that values are never escaped twice.
+type escapedString string
+
+func (s escapedString) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + s + `"`), nil
+}
+
+// fragment is the rendered output of a partial template. It is part of the document itself and is written as it is
+// wherever a whole value is expected.
+type fragment string
+
+// markEscaped returns a copy of v in which all strings that are escaped for use inside a JSON string are marked as
+// escapedString. Other strings, e.g. resolved reference values containing a quote, can not be the result of escaping
+// and are kept as they are, so that they are taken as text.
+func markEscaped(v any) any {
+	switch val := v.(type) {
+	case string:
+		return markIfEscaped(val)
+	case map[string]string:
+		result := make(map[string]any, len(val))
+		for k, e := range val {
+			result[k] = markIfEscaped(e)
+		}
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(val))
+		for k, e := range val {
+			result[k] = markEscaped(e)
+		}
+		return result
+	case map[any]any:
+		result := make(map[any]any, len(val))
+		for k, e := range val {
+			result[k] = markEscaped(e)
+		}
+		return result
+	case []any:
+		result := make([]any, len(val))
+		for i, e := range val {
+			result[i] = markEscaped(e)
+		}
+		return result
+	default:
+		return val
+	}
+}
+
+// markIfEscaped returns s as escapedString if it is valid content of a JSON string, and as it is otherwise.
+func markIfEscaped(s string) any {
+	if !json.Valid([]byte(`"` + s + `"`)) {
+		return s
+	}
+	return escapedString(s)
+}
+
+// text returns the unescaped text of v. Strings not marked as escapedString - e.g. string literals of the template -
+// are taken as they are.
+func text(v any) (string, error) {
+	switch val := v.(type) {
+	case escapedString:
+		var s string
+		if err := json.Unmarshal([]byte(`"`+val+`"`), &s); err != nil {
+			return "", fmt.Errorf("value %q is not escaped for use in a JSON string", string(val))
+		}
+		return s, nil
+	case string:
+		return val, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// escapeText escapes the given text for use inside a JSON string.
+func escapeText(s string) (escapedString, error) {
+	quoted, err := quoteJSONString(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to escape value: %w", err)
+	}
+	return escapedString(quoted[1 : len(quoted)-1]), nil
+}
+
+// textFunc turns a function operating on text into a template function operating on escaped values.
+func textFunc(f func(string) string) func(any) (escapedString, error) {
+	return func(v any) (escapedString, error) {
+		s, err := text(v)
+		if err != nil {
+			return "", err
+		}
+		return escapeText(f(s))
+	}
+}
+
+// toJson renders a resolved parameter value, such as a map or list, as JSON.
+// Escaped strings are not escaped again, any other string is.
+func toJson(v any) (string, error) {
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(toJsonValue(v)); err != nil {
+		return "", fmt.Errorf("failed to render value as JSON: %w", err)
+	}
+
+	// Encoder.Encode adds a trailing newline, which is not wanted in a rendered template
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n"))), nil
+}
+
+// toJsonValue turns v into a value encoding/json is able to serialize, converting YAML maps to string-keyed ones.
+func toJsonValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(val))
+		for k, e := range val {
+			result[k] = toJsonValue(e)
+		}
+		return result
+	case map[any]any:
+		result := make(map[string]any, len(val))
+		for k, e := range val {
+			result[fmt.Sprint(k)] = toJsonValue(e)
+		}
+		return result
+	case []any:
+		result := make([]any, len(val))
+		for i, e := range val {
+			result[i] = toJsonValue(e)
+		}
+		return result
+	default:
+		return val
+	}
+}
+
+// escape renders the given value as content of a JSON string, without adding surrounding quotes.
+// Escaped values, such as resolved parameters, are returned as they are.
+func escape(v any) (escapedString, error) {
+	s, err := text(v)
+	if err != nil {
+		return "", err
+	}
+	return escapeText(s)
+}
+
+// quote renders the given value as a quoted JSON string.
+// Escaped values, such as resolved parameters, are not escaped again.
+func quote(v any) (string, error) {
+	escaped, err := escape(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to quote value: %w", err)
+	}
+	return `"` + string(escaped) + `"`, nil
+}
+
+// defaultValue returns v, or the given fallback if v is empty or undefined, e.g. {{ .optional | default "fallback" }}.
+func defaultValue(fallback any, v any) any {
+	if isEmpty(v) {
+		return fallback
+	}
+	return v
+}
+
+// required returns v, or fails rendering with the given message if v is empty or undefined, e.g.
+// {{ required "the profile ID must be set" .profileId }}.
+func required(message string, v any) (any, error) {
+	if isEmpty(v) {
+		return nil, fmt.Errorf("required value is missing: %s", message)
+	}
+	return v, nil
+}
+
+// isEmpty returns whether v is nil or the zero value of its type, or an empty map, slice or string.
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.String:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
+
+// join joins the elements of the given list with the given separator, e.g. {{ .tags | join ", " }}.
+func join(separator any, list any) (escapedString, error) {
+	sep, err := text(separator)
+	if err != nil {
+		return "", err
+	}
+
+	var elems []string
+	switch l := list.(type) {
+	case []string:
+		elems = l
+	case []any:
+		elems = make([]string, len(l))
+		for i, e := range l {
+			if elems[i], err = text(e); err != nil {
+				return "", err
+			}
+		}
+	case escapedString:
+		return "", fmt.Errorf("cannot join value of type string, a list is required")
+	default:
+		return "", fmt.Errorf("cannot join value of type %T, a list is required", list)
+	}
+	return escapeText(strings.Join(elems, sep))
+}
+
+// split splits the given string at each occurrence of the given separator, e.g. {{ split "," .csv }}.
+func split(separator any, s any) ([]any, error) {
+	sep, err := text(separator)
+	if err != nil {
+		return nil, err
+	}
+	str, err := text(s)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(str, sep)
+	result := make([]any, len(parts))
+	for i, p := range parts {
+		if result[i], err = escapeText(p); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// indent prefixes every line of the given string with the given number of spaces.
+func indent(spaces int, s any) (escapedString, error) {
+	padding := strings.Repeat(" ", spaces)
+	return textFunc(func(s string) string {
+		return padding + strings.ReplaceAll(s, "\n", "\n"+padding)
+	})(s)
+}
+
+// formatDate formats a date given as an RFC 3339 string or as Unix timestamp in seconds using the given Go time layout,
+// e.g. {{ formatDate "2006-01-02" .startDate }}.
+func formatDate(layout any, date any) (escapedString, error) {
+	l, err := text(layout)
+	if err != nil {
+		return "", err
+	}
+
+	var t time.Time
+	switch d := date.(type) {
+	case string, escapedString:
+		s, err := text(d)
+		if err != nil {
+			return "", err
+		}
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse date %q: expected RFC 3339 format, e.g. 2006-01-02T15:04:05Z", s)
+		}
+		t = parsed
+	case int:
+		t = time.Unix(int64(d), 0).UTC()
+	case int64:
+		t = time.Unix(d, 0).UTC()
+	case float64:
+		t = time.Unix(int64(d), 0).UTC()
+	default:
+		return "", fmt.Errorf("cannot format value of type %T as date, a RFC 3339 string or Unix timestamp is required", date)
+	}
+	return escapeText(t.Format(l))
+}
+
+// b64enc returns the base64 encoding of the unescaped text of the given value.
+func b64enc(v any) (string, error) {
+	s, err := text(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(s)), nil
+}
+
+// optionalValueFuncs are the template functions that accept undefined values.
+var optionalValueFuncs = []string{"default", "required"}
+
+// allowUndefinedOptionalFields rewrites the parsed template so that top-level fields passed to the functions in
+// optionalValueFuncs, like {{ .optional | default "fallback" }} or {{ required "message" .id }}, are read using index.
+// Other than accessing an undefined field, index does not fail rendering for undefined properties.
+func allowUndefinedOptionalFields(t *templ.Template) {
+	if t.Tree != nil {
+		rewriteOptionalFieldsInNode(t.Tree.Root)
+	}
+}
+
+func rewriteOptionalFieldsInNode(node parsetree.Node) {
+	switch n := node.(type) {
+	case *parsetree.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			rewriteOptionalFieldsInNode(child)
+		}
+	case *parsetree.ActionNode:
+		rewriteOptionalFieldsInPipe(n.Pipe)
+	case *parsetree.IfNode:
+		rewriteOptionalFieldsInBranch(&n.BranchNode)
+	case *parsetree.RangeNode:
+		rewriteOptionalFieldsInBranch(&n.BranchNode)
+	case *parsetree.WithNode:
+		rewriteOptionalFieldsInBranch(&n.BranchNode)
+	}
+}
+
+func rewriteOptionalFieldsInBranch(branch *parsetree.BranchNode) {
+	rewriteOptionalFieldsInPipe(branch.Pipe)
+	rewriteOptionalFieldsInNode(branch.List)
+	if branch.ElseList != nil {
+		rewriteOptionalFieldsInNode(branch.ElseList)
+	}
+}
+
+func rewriteOptionalFieldsInPipe(pipe *parsetree.PipeNode) {
+	if pipe == nil {
+		return
+	}
+
+	for i, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args {
+			if p, ok := arg.(*parsetree.PipeNode); ok {
+				rewriteOptionalFieldsInPipe(p)
+			}
+		}
+
+		if ident, ok := cmd.Args[0].(*parsetree.IdentifierNode); !ok || !slices.Contains(optionalValueFuncs, ident.Ident) {
+			continue
+		}
+
+		switch {
+		case len(cmd.Args) == 3:
+			// value passed as argument, e.g. {{ required "message" .id }}
+			if field, ok := cmd.Args[2].(*parsetree.FieldNode); ok && len(field.Ident) == 1 {
+				cmd.Args[2] = &parsetree.PipeNode{NodeType: parsetree.NodePipe, Pos: field.Pos, Cmds: []*parsetree.CommandNode{indexCommand(field)}}
+			}
+		case len(cmd.Args) == 2 && i > 0:
+			// value passed by the pipeline, e.g. {{ .optional | default "fallback" }}
+			prev := pipe.Cmds[i-1]
+			if field, ok := prev.Args[0].(*parsetree.FieldNode); ok && len(prev.Args) == 1 && len(field.Ident) == 1 {
+				pipe.Cmds[i-1] = indexCommand(field)
+			}
+		}
+	}
+}
+
+// indexCommand returns the command {{ index . "<field>" }} reading the given top-level field.
+func indexCommand(field *parsetree.FieldNode) *parsetree.CommandNode {
+	name := field.Ident[0]
+	return &parsetree.CommandNode{
+		NodeType: parsetree.NodeCommand,
+		Pos:      field.Pos,
+		Args: []parsetree.Node{
+			parsetree.NewIdentifier("index").SetPos(field.Pos),
+			&parsetree.DotNode{NodeType: parsetree.NodeDot, Pos: field.Pos},
+			&parsetree.StringNode{NodeType: parsetree.NodeString, Pos: field.Pos, Quoted: strconv.Quote(name), Text: name},
+		},
+	}
+}
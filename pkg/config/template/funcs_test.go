@@ -0,0 +1,209 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenTemplate   string
+		givenProperties map[string]any
+		want            string
+	}{
+		{
+			"quote quotes escaped text without escaping it again",
+			`{ "description": {{ quote .text }} }`,
+			map[string]any{"text": `a \"quoted\"\nmarkdown <b>text</b>`},
+			`{ "description": "a \"quoted\"\nmarkdown <b>text</b>" }`,
+		},
+		{
+			"escape keeps escaped text",
+			`{ "description": "prefix {{ escape .text }}" }`,
+			map[string]any{"text": `C:\\path \"x\"`},
+			`{ "description": "prefix C:\\path \"x\"" }`,
+		},
+		{
+			"quote escapes values that are not escaped",
+			`{ "a": {{ quote .val }} }`,
+			map[string]any{"val": `[ "a","b" ]`},
+			`{ "a": "[ \"a\",\"b\" ]" }`,
+		},
+		{
+			"functions take resolved reference values containing a quote as text",
+			`{ "name": "{{ .ref | upper }}" }`,
+			map[string]any{"ref": `say "hi"`},
+			`{ "name": "SAY \"HI\"" }`,
+		},
+		{
+			"quote and escape escape template literals",
+			`{ "a": {{ quote "say \"hi\"" }}, "b": "{{ escape "C:\\path" }}" }`,
+			map[string]any{},
+			`{ "a": "say \"hi\"", "b": "C:\\path" }`,
+		},
+		{
+			"default returns value if set",
+			`{{ index . "val" | default "fallback" }}`,
+			map[string]any{"val": "set"},
+			`set`,
+		},
+		{
+			"default returns fallback if value is missing",
+			`{{ index . "val" | default "fallback" }}`,
+			map[string]any{},
+			`fallback`,
+		},
+		{
+			"default returns fallback if field is undefined",
+			`{{ .val | default "fallback" }}`,
+			map[string]any{},
+			`fallback`,
+		},
+		{
+			"default returns fallback if value is empty",
+			`{{ .val | default 42 }}`,
+			map[string]any{"val": ""},
+			`42`,
+		},
+		{
+			"required returns set value",
+			`{{ required "val must be set" .val }}`,
+			map[string]any{"val": "set"},
+			`set`,
+		},
+		{
+			"join joins list",
+			`{{ .list | join ", " }}`,
+			map[string]any{"list": []any{"a", "b", 3}},
+			`a, b, 3`,
+		},
+		{
+			"split splits string",
+			`{{ range split "," .csv }}[{{ . }}]{{ end }}`,
+			map[string]any{"csv": "a,b,c"},
+			`[a][b][c]`,
+		},
+		{
+			"upper and lower",
+			`{{ upper .val }} {{ lower .val }}`,
+			map[string]any{"val": "MiXeD"},
+			`MIXED mixed`,
+		},
+		{
+			"upper keeps escape sequences",
+			`{{ upper .val }}`,
+			map[string]any{"val": `line\nbreak \u00e9`},
+			`LINE\nBREAK É`,
+		},
+		{
+			"split and join operate on unescaped text",
+			`{{ split "\"" .val | join "," }}`,
+			map[string]any{"val": `a\"b\\\"c`},
+			`a,b\\,c`,
+		},
+		{
+			"indent indents all lines",
+			`{{ indent 2 .val }}`,
+			map[string]any{"val": `a\nb`},
+			`  a\n  b`,
+		},
+		{
+			"formatDate formats RFC 3339 date",
+			`{{ formatDate "2006-01-02" .date }}`,
+			map[string]any{"date": "2024-03-15T10:00:00Z"},
+			`2024-03-15`,
+		},
+		{
+			"formatDate formats unix timestamp",
+			`{{ formatDate "2006-01-02 15:04" .date }}`,
+			map[string]any{"date": 1710496800},
+			`2024-03-15 10:00`,
+		},
+		{
+			"b64enc encodes string",
+			`{{ b64enc .val }}`,
+			map[string]any{"val": "monaco"},
+			`bW9uYWNv`,
+		},
+		{
+			"b64enc encodes unescaped text",
+			`{{ b64enc .val }}`,
+			map[string]any{"val": `a\"b`},
+			`YSJi`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(&InMemoryTemplate{content: tt.givenTemplate}, tt.givenProperties)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTemplateFuncs_Errors(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenTemplate   string
+		givenProperties map[string]any
+		wantErr         string
+	}{
+		{
+			"required fails with message if value is empty",
+			`{{ required "the alerting profile ID must be set" .val }}`,
+			map[string]any{"val": ""},
+			"required value is missing: the alerting profile ID must be set",
+		},
+		{
+			"required fails with message if field is undefined",
+			`{{ .val | required "the alerting profile ID must be set" }}`,
+			map[string]any{},
+			"required value is missing: the alerting profile ID must be set",
+		},
+		{
+			"join fails for non-list",
+			`{{ join "," .val }}`,
+			map[string]any{"val": "not a list"},
+			"cannot join value of type string",
+		},
+		{
+			"formatDate fails for invalid date",
+			`{{ formatDate "2006" .val }}`,
+			map[string]any{"val": "15.03.2024"},
+			"expected RFC 3339 format",
+		},
+		{
+			"no access to current time",
+			`{{ now }}`,
+			map[string]any{},
+			`function "now" not defined`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(&InMemoryTemplate{content: tt.givenTemplate}, tt.givenProperties)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"fmt"
+	templ "text/template"
+	parsetree "text/template/parse"
+)
+
+// RenderMode defines how the values of placeholders are written into a rendered template.
+type RenderMode string
+
+const (
+	// RenderModeText writes values as they are. Strings are expected to be escaped for the position they are placed at,
+	// as is done for most resolved parameters. This is the default.
+	RenderModeText RenderMode = "text"
+	// RenderModeJSON writes values depending on their position in the JSON template. Inside a JSON string, values are
+	// escaped unless they already are, anywhere else maps, lists, numbers and booleans are serialized as JSON. Included
+	// partials are rendered the same way.
+	RenderModeJSON RenderMode = "json"
+)
+
+// RenderModes are all supported render modes
+var RenderModes = []RenderMode{RenderModeText, RenderModeJSON}
+
+const (
+	jsonStringContentFunc = "jsonStringContent"
+	jsonValueFunc         = "jsonValue"
+)
+
+var jsonContextFuncs = templ.FuncMap{
+	jsonStringContentFunc: jsonStringContent,
+	jsonValueFunc:         jsonValue,
+}
+
+// jsonContext is the position in a JSON document text is written at.
+type jsonContext int
+
+const (
+	jsonContextValue jsonContext = iota
+	jsonContextString
+	jsonContextStringEscape
+)
+
+// escapeJSONContext rewrites the parsed template so that the output of each placeholder is passed to a function
+// escaping it for its position in the JSON document.
+func escapeJSONContext(t *templ.Template) error {
+	if t.Tree == nil {
+		return nil
+	}
+
+	if _, err := escapeJSONContextInList(t.Tree, t.Tree.Root, jsonContextValue); err != nil {
+		return err
+	}
+	t.Funcs(jsonContextFuncs)
+	return nil
+}
+
+func escapeJSONContextInList(tree *parsetree.Tree, list *parsetree.ListNode, ctx jsonContext) (jsonContext, error) {
+	if list == nil {
+		return ctx, nil
+	}
+
+	var err error
+	for _, node := range list.Nodes {
+		switch n := node.(type) {
+		case *parsetree.TextNode:
+			ctx = advanceJSONContext(ctx, n.Text)
+		case *parsetree.ActionNode:
+			// actions declaring variables do not write any output
+			if len(n.Pipe.Decl) == 0 {
+				appendJSONContextFunc(tree, n, ctx)
+			}
+		case *parsetree.IfNode:
+			ctx, err = escapeJSONContextInBranch(tree, &n.BranchNode, ctx)
+		case *parsetree.RangeNode:
+			ctx, err = escapeJSONContextInBranch(tree, &n.BranchNode, ctx)
+		case *parsetree.WithNode:
+			ctx, err = escapeJSONContextInBranch(tree, &n.BranchNode, ctx)
+		case *parsetree.ListNode:
+			ctx, err = escapeJSONContextInList(tree, n, ctx)
+		}
+
+		if err != nil {
+			return ctx, err
+		}
+	}
+
+	return ctx, nil
+}
+
+// escapeJSONContextInBranch rewrites the lists of the branch. All lists have to end in the same JSON context, as it
+// is not known which of them is executed.
+func escapeJSONContextInBranch(tree *parsetree.Tree, branch *parsetree.BranchNode, ctx jsonContext) (jsonContext, error) {
+	listCtx, err := escapeJSONContextInList(tree, branch.List, ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	elseCtx := ctx
+	if branch.ElseList != nil {
+		if elseCtx, err = escapeJSONContextInList(tree, branch.ElseList, ctx); err != nil {
+			return ctx, err
+		}
+	}
+
+	if listCtx != elseCtx || (branch.NodeType == parsetree.NodeRange && listCtx != ctx) {
+		location, _ := tree.ErrorContext(branch)
+		return ctx, fmt.Errorf("%s: a JSON string must not start or end in only some branches of a conditional or loop", location)
+	}
+
+	return listCtx, nil
+}
+
+func appendJSONContextFunc(tree *parsetree.Tree, action *parsetree.ActionNode, ctx jsonContext) {
+	funcName := jsonValueFunc
+	if ctx != jsonContextValue {
+		funcName = jsonStringContentFunc
+	}
+
+	action.Pipe.Cmds = append(action.Pipe.Cmds, &parsetree.CommandNode{
+		NodeType: parsetree.NodeCommand,
+		Pos:      action.Pos,
+		Args:     []parsetree.Node{parsetree.NewIdentifier(funcName).SetTree(tree).SetPos(action.Pos)},
+	})
+}
+
+// advanceJSONContext returns the JSON context after writing the given text in the given context.
+func advanceJSONContext(ctx jsonContext, text []byte) jsonContext {
+	for _, c := range text {
+		switch ctx {
+		case jsonContextValue:
+			if c == '"' {
+				ctx = jsonContextString
+			}
+		case jsonContextString:
+			switch c {
+			case '\\':
+				ctx = jsonContextStringEscape
+			case '"':
+				ctx = jsonContextValue
+			}
+		case jsonContextStringEscape:
+			ctx = jsonContextString
+		}
+	}
+	return ctx
+}
+
+// jsonStringContent renders a value placed inside a JSON string. Escaped strings - as resolved parameter values
+// are - are kept as they are, any other value, including string literals and the output of partials, is escaped.
+func jsonStringContent(v any) (string, error) {
+	var escaped escapedString
+	var err error
+	switch val := v.(type) {
+	case nil:
+		return "", nil
+	case escapedString:
+		return string(val), nil
+	case map[string]string, map[string]any, map[any]any, []any:
+		j, jsonErr := toJson(val)
+		if jsonErr != nil {
+			return "", jsonErr
+		}
+		escaped, err = escape(j)
+	default:
+		escaped, err = escape(val)
+	}
+	return string(escaped), err
+}
+
+// jsonValue renders a value placed anywhere but inside a JSON string. Strings and the output of partials are written
+// as they are, as they may contain JSON themselves - e.g. resolved list parameters - while any other value is
+// serialized as JSON.
+func jsonValue(v any) (string, error) {
+	switch val := v.(type) {
+	case escapedString:
+		return string(val), nil
+	case string:
+		return val, nil
+	case fragment:
+		return string(val), nil
+	default:
+		return toJson(v)
+	}
+}
+
+// ParseRenderMode returns the RenderMode of the given name. An empty name results in RenderModeText.
+func ParseRenderMode(name string) (RenderMode, error) {
+	if name == "" {
+		return RenderModeText, nil
+	}
+
+	for _, m := range RenderModes {
+		if string(m) == name {
+			return m, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown render mode `%s`. Allowed modes: %v", name, RenderModes)
+}
@@ -0,0 +1,115 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	templ "text/template"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+)
+
+// PartialsDirectory is the directory within a project holding partial templates - shared JSON fragments, which any
+// template of the project may include using {{ include "<name>" . }}.
+const PartialsDirectory = "_partials"
+
+// partialFileExtension is appended to the name of an included partial if the name does not define an extension.
+const partialFileExtension = ".json"
+
+// Partials provides access to the partial templates stored in a directory.
+type Partials struct {
+	// fs is the file system to read partials from
+	fs afero.Fs
+	// dir is the directory holding the partials
+	dir string
+}
+
+// NewPartials creates Partials reading partial templates from the given directory.
+func NewPartials(fs afero.Fs, dir string) *Partials {
+	return &Partials{fs: fs, dir: filepath.Clean(dir)}
+}
+
+// partialsProvider is implemented by Template types that support including partials.
+type partialsProvider interface {
+	Partials() *Partials
+}
+
+// load returns the content of the partial with the given name. The name is resolved relative to the partials
+// directory and must not point outside of it.
+func (p *Partials) load(name string) (string, error) {
+	fileName := filepath.FromSlash(name)
+	if filepath.Ext(fileName) == "" {
+		fileName += partialFileExtension
+	}
+
+	path := filepath.Join(p.dir, fileName)
+	if filepath.IsAbs(fileName) || !strings.HasPrefix(path, p.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("partial %q must be located within the %q directory", name, PartialsDirectory)
+	}
+
+	if err := files.RejectSymlinkRecursive(p.fs, path); err != nil {
+		return "", err
+	}
+
+	content, err := afero.ReadFile(p.fs, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read partial %q: %w", name, err)
+	}
+	return string(content), nil
+}
+
+// includeFunc returns the 'include' template function for a template including partials. Partials are rendered using
+// the RenderMode of the including template. includeChain holds the names of all partials currently being rendered and
+// is used to detect include cycles.
+func (p *Partials) includeFunc(mode RenderMode, includeChain []string) func(name string, data any) (fragment, error) {
+	return func(name string, data any) (fragment, error) {
+		if slices.Contains(includeChain, name) {
+			return "", fmt.Errorf("include cycle detected: %s", strings.Join(append(includeChain, name), " -> "))
+		}
+
+		content, err := p.load(name)
+		if err != nil {
+			return "", err
+		}
+
+		partial, err := parse(name, content)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse partial %q: %w", name, err)
+		}
+		if mode == RenderModeJSON {
+			if err := escapeJSONContext(partial); err != nil {
+				return "", fmt.Errorf("failed to parse partial %q: %w", name, err)
+			}
+		}
+		partial.Funcs(templ.FuncMap{"include": p.includeFunc(mode, append(slices.Clone(includeChain), name))})
+
+		result := bytes.Buffer{}
+		if err := partial.Execute(&result, data); err != nil {
+			return "", fmt.Errorf("failed to render partial %q: %w", name, err)
+		}
+		return fragment(result.String()), nil
+	}
+}
+
+// includeUnavailable is the 'include' template function for templates that have no access to partials.
+func includeUnavailable(name string, _ any) (fragment, error) {
+	return "", fmt.Errorf("cannot include partial %q: partials are only available in templates loaded from a project", name)
+}
@@ -0,0 +1,131 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+)
+
+func newTemplateWithPartials(t *testing.T, content string, partials map[string]string) template.Template {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for name, partial := range partials {
+		require.NoError(t, afero.WriteFile(fs, "project/_partials/"+name, []byte(partial), 0644))
+	}
+	require.NoError(t, afero.WriteFile(fs, "project/dashboard/template.json", []byte(content), 0644))
+
+	tmpl, err := template.NewFileTemplateWithPartials(fs, "project/dashboard/template.json", template.NewPartials(fs, "project/_partials"))
+	require.NoError(t, err)
+	return tmpl
+}
+
+func TestRender_IncludesPartials(t *testing.T) {
+	tmpl := newTemplateWithPartials(t,
+		`{ "tiles": [ {{ include "tile-header" . }}, {{ include "tiles/markdown.json" .markdown }} ] }`,
+		map[string]string{
+			"tile-header.json":    `{ "name": "{{ .title }}", "nested": {{ include "bounds" . }} }`,
+			"bounds.json":         `{ "top": 0 }`,
+			"tiles/markdown.json": `{ "markdown": "{{ .text }}" }`,
+		})
+
+	got, err := template.Render(tmpl, map[string]any{"title": "Header", "markdown": map[string]any{"text": "# Hello"}})
+	require.NoError(t, err)
+	assert.Equal(t, `{ "tiles": [ { "name": "Header", "nested": { "top": 0 } }, { "markdown": "# Hello" } ] }`, got)
+}
+
+func TestRenderWithMode_JSON_EscapesPlaceholdersOfPartials(t *testing.T) {
+	tmpl := newTemplateWithPartials(t,
+		`{ "tile": {{ include "tile" . }}, "embedded": "{{ include "bounds" . }}" }`,
+		map[string]string{
+			"tile.json":   `{ "name": "{{ .title }}", "path": "{{ "C:\\new" }}", "tags": {{ .tags }}, "bounds": {{ include "bounds" . }} }`,
+			"bounds.json": `{ "top": {{ .top }} }`,
+		})
+
+	got, err := template.RenderWithMode(tmpl, map[string]any{"title": `\"Header\"`, "tags": []any{"a", "b"}, "top": 0}, template.RenderModeJSON)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{ "tile": { "name": "\"Header\"", "path": "C:\\new", "tags": ["a", "b"], "bounds": { "top": 0 } }, "embedded": "{ \"top\": 0 }" }`, got)
+}
+
+func TestRender_IncludingSamePartialTwiceIsNoCycle(t *testing.T) {
+	tmpl := newTemplateWithPartials(t,
+		`[{{ include "a" . }},{{ include "a" . }}]`,
+		map[string]string{"a.json": `{{ include "b" . }}`, "b.json": `1`})
+
+	got, err := template.Render(tmpl, map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, `[1,1]`, got)
+}
+
+func TestRender_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		partials map[string]string
+		wantErr  string
+	}{
+		{
+			name:     "include cycle",
+			content:  `{{ include "a" . }}`,
+			partials: map[string]string{"a.json": `{{ include "b" . }}`, "b.json": `{{ include "a" . }}`},
+			wantErr:  "include cycle detected: a -> b -> a",
+		},
+		{
+			name:     "partial including itself",
+			content:  `{{ include "a" . }}`,
+			partials: map[string]string{"a.json": `{{ include "a" . }}`},
+			wantErr:  "include cycle detected: a -> a",
+		},
+		{
+			name:    "missing partial",
+			content: `{{ include "missing" . }}`,
+			wantErr: `failed to read partial "missing"`,
+		},
+		{
+			name:    "partial outside of partials directory",
+			content: `{{ include "../dashboard/template" . }}`,
+			wantErr: `must be located within the "_partials" directory`,
+		},
+		{
+			name:     "missing property in partial",
+			content:  `{{ include "a" . }}`,
+			partials: map[string]string{"a.json": `{{ .missing }}`},
+			wantErr:  `failed to render partial "a"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := newTemplateWithPartials(t, tt.content, tt.partials)
+
+			_, err := template.Render(tmpl, map[string]any{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRender_IncludeFailsWithoutPartials(t *testing.T) {
+	_, err := template.Render(template.NewInMemoryTemplate("id", `{{ include "a" . }}`), map[string]any{})
+	assert.ErrorContains(t, err, `cannot include partial "a"`)
+}
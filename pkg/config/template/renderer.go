@@ -24,24 +24,41 @@ import (
 // Render tries to render a given template with the given properties and returns the
 // resulting string. if any error occurs during rendering, an error is returned.
 func Render(template Template, properties map[string]any) (string, error) {
+	return RenderWithMode(template, properties, RenderModeText)
+}
+
+// RenderWithMode works like Render, but writes the values of placeholders as defined by the given RenderMode.
+// Placeholders of YAML templates are always written depending on their position in the YAML document, as described by
+// escapeYAMLContext.
+func RenderWithMode(template Template, properties map[string]any, mode RenderMode) (string, error) {
 	content, err := template.Content()
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
 
-	// special handling to fix the case that a payload was fetched that after the download and processing
-	// results in three subsequent {. This can happen e.g. if the payload allows to have content embraced between
-	// curly braces like {"somekey" : "some {VALUE}"}
-	content = strings.ReplaceAll(content, "{{{", "{{\"{\"}}{{")
-	parsedTemplate, err := ParseTemplate(template.ID(), content)
+	parsedTemplate, err := parse(template.ID(), content)
 
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
 
+	switch {
+	case IsYAML(template):
+		err = escapeYAMLContext(parsedTemplate)
+	case mode == RenderModeJSON:
+		err = escapeJSONContext(parsedTemplate)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
+	}
+
+	if p, ok := template.(partialsProvider); ok && p.Partials() != nil {
+		parsedTemplate.Funcs(templ.FuncMap{"include": p.Partials().includeFunc(mode, nil)})
+	}
+
 	result := bytes.Buffer{}
 
-	err = parsedTemplate.Execute(&result, properties)
+	err = parsedTemplate.Execute(&result, markEscaped(properties))
 	if err != nil {
 		return "", fmt.Errorf("failure trying to render template %s: %w", template.ID(), err)
 	}
@@ -49,8 +66,28 @@ func Render(template Template, properties map[string]any) (string, error) {
 	return result.String(), nil
 }
 
+// parse creates a go Template with the given id from the given template file content, ready to be rendered.
+func parse(id, content string) (*templ.Template, error) {
+	t, err := parseContent(id, content)
+	if err != nil {
+		return nil, err
+	}
+	allowUndefinedOptionalFields(t)
+	return t, nil
+}
+
+// parseContent creates a go Template with the given id from the given template file content as it is written.
+func parseContent(id, content string) (*templ.Template, error) {
+	// special handling to fix the case that a payload was fetched that after the download and processing
+	// results in three subsequent {. This can happen e.g. if the payload allows to have content embraced between
+	// curly braces like {"somekey" : "some {VALUE}"}
+	content = strings.ReplaceAll(content, "{{{", "{{\"{\"}}{{")
+	return ParseTemplate(id, content)
+}
+
 // ParseTemplate creates go Template with the given id from the given string content
 // in any error occurs creating the template, an erro is returned
+// The template has access to all functions defined in templateFuncs.
 func ParseTemplate(id, content string) (*templ.Template, error) {
-	return templ.New(id).Option("missingkey=error").Parse(content)
+	return templ.New(id).Option("missingkey=error").Funcs(templateFuncs).Parse(content)
 }
@@ -35,8 +35,8 @@ const (
 
 func TestParseTemplate(t *testing.T) {
 
-	emptyTemplate, _ := templ.New("").Option("missingkey=error").Parse("")
-	expectedTemplate, _ := templ.New("id").Option("missingkey=error").Parse(simpleTemplateString)
+	emptyTemplate, _ := templ.New("").Option("missingkey=error").Funcs(templateFuncs).Parse("")
+	expectedTemplate, _ := templ.New("id").Option("missingkey=error").Funcs(templateFuncs).Parse(simpleTemplateString)
 
 	type args struct {
 		id      string
@@ -83,7 +83,14 @@ func TestParseTemplate(t *testing.T) {
 				t.Errorf("ParseTemplate() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if got == nil || tt.want == nil {
+				if got != tt.want {
+					t.Errorf("ParseTemplate() got = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			// templates hold function values, which can not be compared - compare the parsed trees instead
+			if got.Name() != tt.want.Name() || !reflect.DeepEqual(got.Tree, tt.want.Tree) {
 				t.Errorf("ParseTemplate() got = %v, want %v", got, tt.want)
 			}
 		})
@@ -197,6 +204,18 @@ func TestRender(t *testing.T) {
 			"",
 			true,
 		},
+		{
+			"renders map and list values as JSON using toJson",
+			&InMemoryTemplate{
+				content: `{ "rules": {{ toJson .rules }}, "tags": {{ toJson .tags }} }`,
+			},
+			map[string]any{
+				"rules": map[string]any{"enabled": true, "threshold": 5, "name": `a \"quoted\" name`},
+				"tags":  []any{"a", map[any]any{"key": "b"}},
+			},
+			`{ "rules": {"enabled":true,"name":"a \"quoted\" name","threshold":5}, "tags": ["a",{"key":"b"}] }`,
+			false,
+		},
 		{
 			"escapes any newlines when rendering template",
 			&InMemoryTemplate{
@@ -220,3 +239,97 @@ func TestRender(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderWithMode_JSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		givenTemplate   string
+		givenProperties map[string]any
+		want            string
+		wantErr         bool
+	}{
+		{
+			"escapes strings of the template inside JSON strings",
+			`{"description": "{{ "a \"quoted\"\nmulti-line \\ description" }}"}`,
+			map[string]any{},
+			`{"description": "a \"quoted\"\nmulti-line \\ description"}`,
+			false,
+		},
+		{
+			"escapes strings of the template even if they look escaped",
+			`{"path": "{{ "C:\\new" }}"}`,
+			map[string]any{},
+			`{"path": "C:\\new"}`,
+			false,
+		},
+		{
+			"keeps already escaped strings inside JSON strings",
+			`{"description": "{{ .description }}"}`,
+			map[string]any{"description": `a \"quoted\" description`},
+			`{"description": "a \"quoted\" description"}`,
+			false,
+		},
+		{
+			"escapes values in the middle of JSON strings",
+			`{"description": "prefix \"{{ .description }}\" suffix"}`,
+			map[string]any{"description": `\"`},
+			`{"description": "prefix \"\"\" suffix"}`,
+			false,
+		},
+		{
+			"serializes values outside of JSON strings",
+			`{"threshold": {{ .threshold }}, "enabled": {{ .enabled }}, "tags": {{ .tags }}, "owner": {{ .owner }}, "unset": {{ .unset }}}`,
+			map[string]any{"threshold": 42, "enabled": true, "tags": []any{"a", "b"}, "owner": map[any]any{"name": "team"}, "unset": nil},
+			`{"threshold": 42, "enabled": true, "tags": ["a","b"], "owner": {"name":"team"}, "unset": null}`,
+			false,
+		},
+		{
+			"keeps strings outside of JSON strings",
+			`{"list": {{ .list }}}`,
+			map[string]any{"list": `[ "a","b" ]`},
+			`{"list": [ "a","b" ]}`,
+			false,
+		},
+		{
+			"serializes maps inside JSON strings as escaped JSON",
+			`{"json": "{{ .owner }}"}`,
+			map[string]any{"owner": map[string]any{"name": "team"}},
+			`{"json": "{\"name\":\"team\"}"}`,
+			false,
+		},
+		{
+			"tracks JSON strings across conditionals",
+			`{"name": "{{ if .short }}{{ .short }}{{ else }}{{ .long }}{{ end }}", "count": {{ range .items }}{{ . }}{{ end }}}`,
+			map[string]any{"short": "", "long": `\"long\"`, "items": []any{1}},
+			`{"name": "\"long\"", "count": 1}`,
+			false,
+		},
+		{
+			"does not treat escaped quotes as end of JSON string",
+			`{"name": "say \"{{ .name }}"}`,
+			map[string]any{"name": `\"hi\"`},
+			`{"name": "say \"\"hi\""}`,
+			false,
+		},
+		{
+			"fails if branches end in different JSON contexts",
+			`{"name": {{ if .quoted }}"{{ end }}{{ .name }}"}`,
+			map[string]any{"quoted": true, "name": "value"},
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderWithMode(&InMemoryTemplate{content: tt.givenTemplate}, tt.givenProperties, RenderModeJSON)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RenderWithMode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("RenderWithMode() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
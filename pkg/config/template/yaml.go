@@ -0,0 +1,289 @@
+// @license
+// Copyright 2026 Dynatrace LLC
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+)
+
+// Format defines the file format templates are persisted in.
+type Format string
+
+const (
+	// FormatJSON persists templates as JSON, the format of the payloads sent to Dynatrace.
+	FormatJSON Format = "json"
+	// FormatYAML persists templates as YAML. They are converted to JSON after rendering.
+	FormatYAML Format = "yaml"
+)
+
+// IsYAML returns whether the given Template is stored in a YAML file. The content of a YAML template needs to be
+// converted to JSON using YAMLToJSON after rendering it.
+func IsYAML(t Template) bool {
+	switch tmpl := t.(type) {
+	case *FileBasedTemplate:
+		return files.IsYamlFileExtension(tmpl.FilePath())
+	case *InMemoryTemplate:
+		return tmpl.FilePath() != nil && files.IsYamlFileExtension(*tmpl.FilePath())
+	default:
+		return false
+	}
+}
+
+// YAMLToJSON converts the rendered content of a YAML template to JSON.
+func YAMLToJSON(content string) (string, error) {
+	var v any
+	if err := yaml.Unmarshal([]byte(content), &v); err != nil {
+		return "", fmt.Errorf("rendered template is not valid YAML: %w", err)
+	}
+
+	jsonValue, err := toJSONCompatible(v)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(jsonValue); err != nil {
+		return "", fmt.Errorf("failed to convert YAML template to JSON: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// toJSONCompatible turns YAML maps into string-keyed maps, which encoding/json is able to serialize.
+func toJSONCompatible(v any) (any, error) {
+	switch val := v.(type) {
+	case map[any]any:
+		result := make(map[string]any, len(val))
+		for k, e := range val {
+			key, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("failed to convert YAML template to JSON: key %v is not a string", k)
+			}
+			converted, err := toJSONCompatible(e)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = converted
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(val))
+		for i, e := range val {
+			converted, err := toJSONCompatible(e)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = converted
+		}
+		return result, nil
+	default:
+		return val, nil
+	}
+}
+
+// JSONToYAML converts the content of a JSON template to YAML, keeping the order of all object keys.
+// All strings are written as double-quoted YAML strings. Their escape sequences are compatible with JSON, so template
+// placeholders within strings are replaced with correctly escaped values when rendering.
+func JSONToYAML(content string) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+
+	v, err := decodeOrdered(decoder)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert JSON template to YAML: %w", err)
+	}
+	if decoder.More() {
+		return "", fmt.Errorf("failed to convert JSON template to YAML: unexpected content after JSON value")
+	}
+
+	lines, err := yamlLines(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert JSON template to YAML: %w", err)
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// orderedObject is a JSON object which keeps the order of its fields.
+type orderedObject []orderedField
+
+type orderedField struct {
+	key   string
+	value any
+}
+
+// decodeOrdered decodes the next JSON value of the decoder, keeping objects as orderedObject.
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := orderedObject{}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, orderedField{key: keyToken.(string), value: value})
+		}
+		_, err = decoder.Token() // consume closing '}'
+		return obj, err
+	case '[':
+		arr := []any{}
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err = decoder.Token() // consume closing ']'
+		return arr, err
+	default:
+		return nil, fmt.Errorf("unexpected delimiter %q", delim)
+	}
+}
+
+// yamlLines returns the block style YAML lines representing v.
+func yamlLines(v any) ([]string, error) {
+	var lines []string
+
+	switch val := v.(type) {
+	case orderedObject:
+		if len(val) == 0 {
+			return []string{"{}"}, nil
+		}
+		for _, f := range val {
+			key, err := yamlKey(f.key)
+			if err != nil {
+				return nil, err
+			}
+			sub, err := yamlLines(f.value)
+			if err != nil {
+				return nil, err
+			}
+			if !isYAMLBlock(f.value) {
+				lines = append(lines, key+": "+sub[0])
+				continue
+			}
+			lines = append(lines, key+":")
+			for _, l := range sub {
+				lines = append(lines, "  "+l)
+			}
+		}
+	case []any:
+		if len(val) == 0 {
+			return []string{"[]"}, nil
+		}
+		for _, e := range val {
+			sub, err := yamlLines(e)
+			if err != nil {
+				return nil, err
+			}
+			lines = append(lines, "- "+sub[0])
+			for _, l := range sub[1:] {
+				lines = append(lines, "  "+l)
+			}
+		}
+	default:
+		scalar, err := yamlScalar(val)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, scalar)
+	}
+
+	return lines, nil
+}
+
+// isYAMLBlock returns whether v is written as a YAML block spanning multiple lines.
+func isYAMLBlock(v any) bool {
+	switch val := v.(type) {
+	case orderedObject:
+		return len(val) > 0
+	case []any:
+		return len(val) > 0
+	default:
+		return false
+	}
+}
+
+func yamlScalar(v any) (string, error) {
+	switch val := v.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return fmt.Sprint(val), nil
+	case json.Number:
+		return val.String(), nil
+	case string:
+		return quoteJSONString(val)
+	default:
+		return "", fmt.Errorf("unexpected value %v of type %T", v, v)
+	}
+}
+
+// plainYAMLKey matches keys that can be written without quotes.
+var plainYAMLKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// reservedYAMLWords are plain scalars YAML would not read as strings.
+var reservedYAMLWords = []string{"true", "false", "yes", "no", "on", "off", "y", "n", "null"}
+
+func yamlKey(key string) (string, error) {
+	if plainYAMLKey.MatchString(key) && !isReservedYAMLWord(key) {
+		return key, nil
+	}
+	return quoteJSONString(key)
+}
+
+func isReservedYAMLWord(s string) bool {
+	for _, w := range reservedYAMLWords {
+		if strings.EqualFold(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteJSONString(s string) (string, error) {
+	buf := bytes.Buffer{}
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(s); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
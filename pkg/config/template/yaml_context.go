@@ -0,0 +1,293 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template
+
+import (
+	"fmt"
+	"strings"
+	templ "text/template"
+	parsetree "text/template/parse"
+)
+
+const (
+	yamlStringContentFunc = "yamlStringContent"
+	yamlValueFunc         = "yamlValue"
+)
+
+var yamlContextFuncs = templ.FuncMap{
+	yamlStringContentFunc: jsonStringContent,
+	yamlValueFunc:         yamlValue,
+}
+
+// yamlContext is the position in a YAML document text is written at.
+type yamlContext struct {
+	// quote is the quote character of the scalar text is written in, or 0 outside of quoted scalars
+	quote byte
+	// escaped defines whether the previous character started an escape sequence within a double-quoted scalar
+	escaped bool
+	// comment defines whether the rest of the current line is a comment
+	comment bool
+	// indentation is the number of spaces the current line is indented by
+	indentation int
+	// indented defines whether the indentation of the current line is complete
+	indented bool
+	// line holds the content of the current line outside of placeholders, which are written as 0
+	line string
+	// blockIndentation is the indentation of the line starting the current block scalar, or -1 outside of block scalars
+	blockIndentation int
+	// afterValue defines whether a placeholder wrote a whole plain value, which only a comment or the end of a flow
+	// collection may follow on the same line
+	afterValue bool
+}
+
+// escapeYAMLContext rewrites the parsed YAML template so that the output of each placeholder is passed to a function
+// writing it for its position in the YAML document. Placeholders within double-quoted scalars are written as escaped
+// string content - the escape sequences of JSON are valid in double-quoted YAML scalars. Placeholders taking the
+// position of a whole value write strings as double-quoted scalars and any other value as JSON. Placeholders at any
+// other position, e.g. within plain, single-quoted or block scalars, fail parsing, as their values can not be escaped.
+func escapeYAMLContext(t *templ.Template) error {
+	if t.Tree == nil {
+		return nil
+	}
+
+	if _, err := escapeYAMLContextInList(t.Tree, t.Tree.Root, yamlContext{blockIndentation: -1}); err != nil {
+		return err
+	}
+	t.Funcs(yamlContextFuncs)
+	return nil
+}
+
+func escapeYAMLContextInList(tree *parsetree.Tree, list *parsetree.ListNode, ctx yamlContext) (yamlContext, error) {
+	if list == nil {
+		return ctx, nil
+	}
+
+	var err error
+	for _, node := range list.Nodes {
+		switch n := node.(type) {
+		case *parsetree.TextNode:
+			ctx, err = advanceYAMLContext(ctx, n.Text)
+			if err != nil {
+				location, _ := tree.ErrorContext(n)
+				return ctx, fmt.Errorf("%s: %w", location, err)
+			}
+		case *parsetree.ActionNode:
+			// actions declaring variables do not write any output
+			if len(n.Pipe.Decl) == 0 {
+				ctx, err = appendYAMLContextFunc(tree, n, ctx)
+			}
+		case *parsetree.IfNode:
+			ctx, err = escapeYAMLContextInBranch(tree, &n.BranchNode, ctx)
+		case *parsetree.RangeNode:
+			ctx, err = escapeYAMLContextInBranch(tree, &n.BranchNode, ctx)
+		case *parsetree.WithNode:
+			ctx, err = escapeYAMLContextInBranch(tree, &n.BranchNode, ctx)
+		case *parsetree.ListNode:
+			ctx, err = escapeYAMLContextInList(tree, n, ctx)
+		}
+
+		if err != nil {
+			return ctx, err
+		}
+	}
+
+	return ctx, nil
+}
+
+// escapeYAMLContextInBranch rewrites the lists of the branch. All lists have to end within the same kind of scalar, as
+// it is not known which of them is executed.
+func escapeYAMLContextInBranch(tree *parsetree.Tree, branch *parsetree.BranchNode, ctx yamlContext) (yamlContext, error) {
+	listCtx, err := escapeYAMLContextInList(tree, branch.List, ctx)
+	if err != nil {
+		return ctx, err
+	}
+
+	elseCtx := ctx
+	if branch.ElseList != nil {
+		if elseCtx, err = escapeYAMLContextInList(tree, branch.ElseList, ctx); err != nil {
+			return ctx, err
+		}
+	}
+
+	if listCtx.quote != elseCtx.quote || (branch.NodeType == parsetree.NodeRange && listCtx.quote != ctx.quote) {
+		location, _ := tree.ErrorContext(branch)
+		return ctx, fmt.Errorf("%s: a quoted YAML scalar must not start or end in only some branches of a conditional or loop", location)
+	}
+
+	return listCtx, nil
+}
+
+func appendYAMLContextFunc(tree *parsetree.Tree, action *parsetree.ActionNode, ctx yamlContext) (yamlContext, error) {
+	location, _ := tree.ErrorContext(action)
+
+	var funcName string
+	switch {
+	case ctx.comment:
+		return ctx, nil
+	case ctx.inBlockScalar(len(ctx.line)):
+		return ctx, fmt.Errorf("%s: placeholders within YAML block scalars are not supported - use a double-quoted scalar instead", location)
+	case ctx.quote == '"':
+		funcName = yamlStringContentFunc
+	case ctx.quote == '\'':
+		return ctx, fmt.Errorf("%s: placeholders within single-quoted YAML scalars are not supported - use a double-quoted scalar instead", location)
+	case ctx.afterValue || !isYAMLValueStart(ctx.line):
+		return ctx, fmt.Errorf("%s: placeholders within plain YAML scalars must make up the whole value - use a double-quoted scalar instead", location)
+	default:
+		funcName = yamlValueFunc
+		ctx.afterValue = true
+	}
+
+	ctx.line += "\x00"
+	action.Pipe.Cmds = append(action.Pipe.Cmds, &parsetree.CommandNode{
+		NodeType: parsetree.NodeCommand,
+		Pos:      action.Pos,
+		Args:     []parsetree.Node{parsetree.NewIdentifier(funcName).SetTree(tree).SetPos(action.Pos)},
+	})
+	return ctx, nil
+}
+
+// inBlockScalar returns whether text written at the current position is part of a block scalar.
+func (ctx yamlContext) inBlockScalar(lineLength int) bool {
+	if ctx.blockIndentation < 0 {
+		return false
+	}
+	if ctx.indented {
+		return ctx.indentation > ctx.blockIndentation
+	}
+	// empty lines and lines that are indented further belong to the block scalar
+	return lineLength > ctx.blockIndentation
+}
+
+// isYAMLValueStart returns whether text written after the given line content starts a new value, e.g. after 'key: ',
+// '- ' or within a flow collection.
+func isYAMLValueStart(line string) bool {
+	trimmed := strings.TrimRight(line, " \t")
+	if trimmed == "" {
+		return true
+	}
+
+	separated := len(trimmed) < len(line)
+	switch trimmed[len(trimmed)-1] {
+	case '[', '{', ',':
+		return true
+	case ':':
+		return separated
+	case '-', '?':
+		// sequence entries and complex keys only start at the beginning of a line or of another sequence entry
+		rest := strings.TrimRight(trimmed[:len(trimmed)-1], " \t")
+		return separated && (rest == "" || strings.HasSuffix(rest, "-"))
+	default:
+		return false
+	}
+}
+
+// advanceYAMLContext returns the YAML context after writing the given text in the given context.
+func advanceYAMLContext(ctx yamlContext, text []byte) (yamlContext, error) {
+	for _, c := range text {
+		if c == '\n' {
+			ctx = ctx.nextLine()
+			continue
+		}
+
+		if !ctx.indented {
+			if c == ' ' {
+				ctx.indentation++
+				ctx.line += " "
+				continue
+			}
+			ctx.indented = true
+			if ctx.blockIndentation >= 0 && ctx.indentation <= ctx.blockIndentation {
+				ctx.blockIndentation = -1
+			}
+		}
+
+		switch {
+		case ctx.comment || ctx.inBlockScalar(len(ctx.line)):
+		case ctx.quote == '"':
+			switch {
+			case ctx.escaped:
+				ctx.escaped = false
+			case c == '\\':
+				ctx.escaped = true
+			case c == '"':
+				ctx.quote = 0
+			}
+		case ctx.quote == '\'':
+			if c == '\'' {
+				ctx.quote = 0
+			}
+		case c == '#' && (ctx.line == "" || strings.HasSuffix(ctx.line, " ")):
+			ctx.comment = true
+		case ctx.afterValue:
+			if c != ' ' && c != '\t' && c != ',' && c != ']' && c != '}' {
+				return ctx, fmt.Errorf("placeholders within plain YAML scalars must make up the whole value - use a double-quoted scalar instead")
+			}
+			if c != ' ' && c != '\t' {
+				ctx.afterValue = false
+			}
+		case (c == '"' || c == '\'') && isYAMLValueStart(ctx.line):
+			ctx.quote = c
+		}
+
+		ctx.line += string(c)
+	}
+	return ctx, nil
+}
+
+// nextLine returns the context at the start of the next line.
+func (ctx yamlContext) nextLine() yamlContext {
+	if ctx.quote == 0 && ctx.blockIndentation < 0 && startsBlockScalar(ctx.line) {
+		ctx.blockIndentation = ctx.indentation
+	}
+
+	ctx.comment = false
+	ctx.afterValue = false
+	ctx.escaped = false
+	ctx.indentation = 0
+	ctx.indented = false
+	ctx.line = ""
+	return ctx
+}
+
+// startsBlockScalar returns whether the given line ends with a block scalar indicator, like 'key: |' or '- >-'.
+func startsBlockScalar(line string) bool {
+	if i := strings.Index(line, " #"); i >= 0 {
+		line = line[:i]
+	}
+	line = strings.TrimRight(line, " \t")
+	indicator := strings.TrimRight(line, "+-0123456789")
+	if !strings.HasSuffix(indicator, "|") && !strings.HasSuffix(indicator, ">") {
+		return false
+	}
+	return isYAMLValueStart(indicator[:len(indicator)-1])
+}
+
+// yamlValue renders a value placed at the position of a whole YAML value. Strings are written as double-quoted
+// scalars, the JSON output of partials is written as it is, and any other value is serialized as JSON, which is valid
+// YAML.
+func yamlValue(v any) (string, error) {
+	switch val := v.(type) {
+	case escapedString:
+		return `"` + string(val) + `"`, nil
+	case string:
+		return quoteJSONString(val)
+	case fragment:
+		return string(val), nil
+	default:
+		return toJson(v)
+	}
+}
@@ -0,0 +1,189 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package template_test
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+)
+
+func TestIsYAML(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "template.yaml", []byte("{}"), 0644))
+	require.NoError(t, afero.WriteFile(fs, "template.json", []byte("{}"), 0644))
+
+	yamlTemplate, err := template.NewFileTemplate(fs, "template.yaml")
+	require.NoError(t, err)
+	jsonTemplate, err := template.NewFileTemplate(fs, "template.json")
+	require.NoError(t, err)
+
+	assert.True(t, template.IsYAML(yamlTemplate))
+	assert.False(t, template.IsYAML(jsonTemplate))
+	assert.False(t, template.IsYAML(template.NewInMemoryTemplate("id", "{}")))
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	given := `
+# comments are allowed
+name: "a \"quoted\" name"
+enabled: true
+threshold: 5
+tags:
+  - a
+  - key: b
+empty: null
+`
+	got, err := template.YAMLToJSON(given)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "a \"quoted\" name", "enabled": true, "threshold": 5, "tags": ["a", {"key": "b"}], "empty": null}`, got)
+}
+
+func TestYAMLToJSON_Errors(t *testing.T) {
+	_, err := template.YAMLToJSON("key: [unclosed")
+	assert.ErrorContains(t, err, "not valid YAML")
+
+	_, err = template.YAMLToJSON("1: non-string key")
+	assert.ErrorContains(t, err, "is not a string")
+}
+
+func TestJSONToYAML(t *testing.T) {
+	given := `{
+  "name": "{{.name}}",
+  "description": "line\nbreak and \"quotes\"",
+  "enabled": true,
+  "threshold": 5.5,
+  "nothing": null,
+  "yes": "reserved key",
+  "tiles": [
+    { "type": "markdown", "bounds": { "top": 0 } },
+    [ 1, 2 ]
+  ],
+  "emptyObject": {},
+  "emptyList": []
+}`
+	want := `name: "{{.name}}"
+description: "line\nbreak and \"quotes\""
+enabled: true
+threshold: 5.5
+nothing: null
+"yes": "reserved key"
+tiles:
+  - type: "markdown"
+    bounds:
+      top: 0
+  - - 1
+    - 2
+emptyObject: {}
+emptyList: []
+`
+	got, err := template.JSONToYAML(given)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// converting back must result in the same JSON
+	rendered, err := template.Render(template.NewInMemoryTemplate("id", got), map[string]any{"name": `some \"escaped\" name`})
+	require.NoError(t, err)
+	roundTrip, err := template.YAMLToJSON(rendered)
+	require.NoError(t, err)
+	expected, err := template.Render(template.NewInMemoryTemplate("id", given), map[string]any{"name": `some \"escaped\" name`})
+	require.NoError(t, err)
+	assert.JSONEq(t, expected, roundTrip)
+}
+
+func TestJSONToYAML_FailsForInvalidJSON(t *testing.T) {
+	_, err := template.JSONToYAML(`{ "key": {{ .val }} }`)
+	assert.Error(t, err)
+}
+
+func TestRender_YAMLTemplate(t *testing.T) {
+	given := `# comments may contain {{ .name }}
+name: "{{ .name }}"
+description: {{ .description }}
+threshold: {{ .threshold }}
+tags: [{{ .tag }}, "b"]
+owner: {{ .owner }}
+script: |
+  echo "hello"
+enabled: {{ .enabled }} # a comment
+`
+	got := renderYAML(t, given, map[string]any{
+		"name":        `a \"quoted\"\nname`,
+		"description": `contains: a colon # and a hash`,
+		"threshold":   5,
+		"tag":         "a",
+		"owner":       map[string]any{"team": "core"},
+		"enabled":     true,
+	})
+
+	want := `{
+  "name": "a \"quoted\"\nname",
+  "description": "contains: a colon # and a hash",
+  "threshold": 5,
+  "tags": ["a", "b"],
+  "owner": {"team": "core"},
+  "script": "echo \"hello\"\n",
+  "enabled": true
+}`
+	assert.JSONEq(t, want, got)
+}
+
+func TestRender_YAMLTemplate_RejectsPlaceholdersThatCanNotBeEscaped(t *testing.T) {
+	tests := []struct {
+		name          string
+		givenTemplate string
+		wantErr       string
+	}{
+		{"single-quoted scalar", `name: '{{ .name }}'`, "single-quoted"},
+		{"part of a plain scalar", `name: prefix-{{ .name }}`, "must make up the whole value"},
+		{"followed by plain text", `name: {{ .name }} suffix`, "must make up the whole value"},
+		{"block scalar", "script: |\n  echo {{ .name }}", "block scalars"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "template.yaml", []byte(tt.givenTemplate), 0644))
+			tmpl, err := template.NewFileTemplate(fs, "template.yaml")
+			require.NoError(t, err)
+
+			_, err = template.Render(tmpl, map[string]any{"name": "value"})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func renderYAML(t *testing.T, content string, properties map[string]any) string {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "template.yaml", []byte(content), 0644))
+	tmpl, err := template.NewFileTemplate(fs, "template.yaml")
+	require.NoError(t, err)
+
+	rendered, err := template.Render(tmpl, properties)
+	require.NoError(t, err)
+
+	got, err := template.YAMLToJSON(rendered)
+	require.NoError(t, err, rendered)
+	return got
+}
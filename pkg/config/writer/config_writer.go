@@ -27,6 +27,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/environment"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	mystrings "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/strings"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
@@ -42,8 +43,16 @@ type WriterContext struct {
 	OutputFolder    string
 	ProjectFolder   string
 	ParametersSerde map[string]parameter.ParameterSerDe
+	// TemplateFormat defines the format templates are written in. If not set, templates are written as JSON.
+	TemplateFormat template.Format
 }
 
+const (
+	jsonTemplateFileExtension = ".json"
+	// yamlTemplateFileExtension differs from the plain YAML extension to prevent clashes with config files.
+	yamlTemplateFileExtension = ".template.yaml"
+)
+
 type serializerContext struct {
 	*WriterContext
 	configFolder string
@@ -585,10 +594,16 @@ func toConfigDefinition(context *serializerContext, cfg config.Config) (persiste
 		Template:       filepath.ToSlash(configTemplatePath),
 		Skip:           cfg.Skip,
 		OriginObjectId: cfg.OriginObjectId,
+		RenderMode:     string(cfg.RenderMode),
 	}, templ, nil
 }
 
 func extractTemplate(context *detailedSerializerContext, cfg config.Config) (string, configTemplate, error) {
+	content, err := cfg.Template.Content()
+	if err != nil {
+		return "", configTemplate{}, newDetailedConfigWriterError(context.serializerContext, err)
+	}
+
 	var name, path string
 	switch t := cfg.Template.(type) {
 	case *template.InMemoryTemplate:
@@ -600,24 +615,36 @@ func extractTemplate(context *detailedSerializerContext, cfg config.Config) (str
 			}
 			name = n
 		} else {
-			name = prepareFileName(t.ID(), ".json")
+			var fileExtension string
+			content, fileExtension = toTemplateFormat(context, content)
+			name = prepareFileName(t.ID(), fileExtension)
 			path = filepath.Join(context.configFolder, name)
 		}
 	default:
 		return "", configTemplate{}, newDetailedConfigWriterError(context.serializerContext, fmt.Errorf("can not persist unexpected template type %q", t))
 	}
 
-	content, err := cfg.Template.Content()
-	if err != nil {
-		return "", configTemplate{}, newDetailedConfigWriterError(context.serializerContext, err)
-	}
-
 	return name, configTemplate{
 		templatePath: path,
 		content:      content,
 	}, nil
 }
 
+// toTemplateFormat converts the given JSON template content to the template format of the WriterContext and returns it
+// together with the file extension to use. If the content can not be converted, it is kept as JSON.
+func toTemplateFormat(context *detailedSerializerContext, content string) (string, string) {
+	if context.TemplateFormat != template.FormatYAML {
+		return content, jsonTemplateFileExtension
+	}
+
+	yamlContent, err := template.JSONToYAML(content)
+	if err != nil {
+		log.With(log.CoordinateAttr(context.config), log.ErrorAttr(err)).Warn("Failed to convert template of %s to YAML, writing it as JSON: %s", context.config, err)
+		return content, jsonTemplateFileExtension
+	}
+	return yamlContent, yamlTemplateFileExtension
+}
+
 func convertParameters(context *detailedSerializerContext, parameters config.Parameters) (map[string]persistence.ConfigParameter, []error) {
 	var errs []error
 	result := make(map[string]persistence.ConfigParameter)
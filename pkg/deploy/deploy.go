@@ -120,17 +120,21 @@ func DeployForAllEnvironments(ctx context.Context, projects []project.Project, e
 		if !ok {
 			return fmt.Errorf("failed to get independently sorted configs for environment %q", env.Name)
 		}
-		ctx = newContextWithEnvironment(ctx, env)
+		envCtx := newContextWithEnvironment(ctx, env)
+		if env.ConcurrentDeployments > 0 {
+			slog.InfoContext(envCtx, "Limiting concurrent deployments for environment", slog.Int("maxConcurrentDeployments", env.ConcurrentDeployments))
+			envCtx = newContextWithDeploymentLimiter(envCtx, rest.NewConcurrentRequestLimiter(env.ConcurrentDeployments))
+		}
 
-		if depErr := deploy(ctx, clientSet, projects, sortedConfigs, env.Name); depErr != nil {
-			slog.ErrorContext(ctx, "Deployment failed for environment", log.ErrorAttr(depErr))
+		if depErr := deploy(envCtx, clientSet, projects, sortedConfigs, env.Name); depErr != nil {
+			slog.ErrorContext(envCtx, "Deployment failed for environment", log.ErrorAttr(depErr))
 			deploymentErrs = deploymentErrs.Append(env.Name, depErr)
 
 			if !opts.ContinueOnErr && !opts.DryRun {
 				return deploymentErrs
 			}
 		} else {
-			slog.InfoContext(ctx, "Deployment successful for environment")
+			slog.InfoContext(envCtx, "Deployment successful for environment")
 		}
 	}
 
@@ -349,7 +353,8 @@ func deployConfig(ctx context.Context, c *config.Config, deployables resource.De
 		return entities.ResolvedEntity{}, errSkip // fake resolved entity that "old" deploy creates is never needed, as we don't even try to deploy dependencies of skipped configs (so no reference will ever be attempted to resolve)
 	}
 
-	properties, errs := c.ResolveParameterValues(resolvedEntities)
+	env := getEnvironmentPropertiesFromContext(ctx, c)
+	properties, errs := c.ResolveParameterValuesForEnvironment(resolvedEntities, env)
 	if len(errs) > 0 {
 		err := multierror.New(errs...)
 		slog.ErrorContext(ctx, "Failed to resolve parameter values", log.ErrorAttr(err), statusDeploymentFailedAttr())
@@ -357,7 +362,7 @@ func deployConfig(ctx context.Context, c *config.Config, deployables resource.De
 		return entities.ResolvedEntity{}, err
 	}
 
-	renderedConfig, err := c.Render(properties)
+	renderedConfig, err := c.RenderForEnvironment(properties, env)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to render JSON template", log.ErrorAttr(err), statusDeploymentFailedAttr())
 		report.GetDetailerFromContextOrDiscard(ctx).Add(report.Detail{Type: report.DetailTypeError, Message: fmt.Sprintf("Failed to render JSON template: %v", err)})
@@ -403,9 +408,27 @@ func logResponseError(ctx context.Context, responseErr coreapi.APIError) {
 }
 
 func newContextWithEnvironment(ctx context.Context, env dynatrace.EnvironmentInfo) context.Context {
+	ctx = context.WithValue(ctx, ctxEnvironmentPropertiesKey{}, config.EnvironmentProperties{
+		Name:        env.Name,
+		Group:       env.Group,
+		URL:         env.URL,
+		PlatformURL: env.PlatformURL,
+		ClassicURL:  env.ClassicURL,
+	})
 	return context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
 }
 
+type ctxEnvironmentPropertiesKey struct{}
+
+// getEnvironmentPropertiesFromContext returns the properties of the environment that is deployed to. If the context
+// does not hold any, the environment is derived from the config.
+func getEnvironmentPropertiesFromContext(ctx context.Context, c *config.Config) config.EnvironmentProperties {
+	if env, ok := ctx.Value(ctxEnvironmentPropertiesKey{}).(config.EnvironmentProperties); ok {
+		return env
+	}
+	return c.EnvironmentProperties()
+}
+
 const deploymentStatus = "deploymentStatus"
 
 // statusDeploying returns an attribute with deploymentStatus set to 'deploying'.
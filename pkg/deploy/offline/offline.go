@@ -0,0 +1,192 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package offline checks loaded projects for every error that can be found without connecting to an environment.
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/json"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/entities"
+	envParam "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/environment"
+	deployErrors "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/errors"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/deploy/internal/validate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/graph"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// Validate runs all checks a deployment runs before sending the first request, for each of the given environments:
+// the static validators, cycle detection of the dependency graph, reference resolution, and rendering of all templates
+// into valid JSON.
+//
+// As nothing is deployed, references resolve to placeholder IDs, and environment variables that are not set resolve
+// to placeholder values. Placeholders satisfy the schema declared for their parameter where possible, and templates
+// of configs using placeholders are not checked to be valid JSON, as their actual values are not known. All errors
+// found are returned, instead of stopping at the first failing environment.
+func Validate(ctx context.Context, projects []project.Project, environments manifest.EnvironmentDefinitionsByName) []error {
+	var errs []error
+
+	if err := validate.Validate(projects); err != nil {
+		var envErrs deployErrors.EnvironmentDeploymentErrors
+		if errors.As(err, &envErrs) {
+			for _, env := range slices.Sorted(maps.Keys(envErrs)) {
+				errs = append(errs, envErrs[env]...)
+			}
+		} else {
+			errs = append(errs, err)
+		}
+	}
+
+	envNames := slices.Sorted(maps.Keys(environments))
+	graphs := graph.New(projects, envNames)
+
+	for _, envName := range envNames {
+		env := environments[envName]
+		ctx := context.WithValue(ctx, log.CtxKeyEnv{}, log.CtxValEnv{Name: env.Name, Group: env.Group})
+
+		sortedConfigs, err := graphs.SortConfigs(envName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to sort configs for environment %q: %w", envName, err))
+			continue
+		}
+
+		errs = append(errs, renderAll(ctx, sortedConfigs, config.NewEnvironmentProperties(env.Name, env.Group, env.URL.Value))...)
+	}
+
+	return errs
+}
+
+// renderAll resolves the parameters of the given configs and renders their templates in the given order.
+// Configs that depend on a skipped or failed config are not rendered, as a deployment would skip them as well.
+func renderAll(ctx context.Context, sortedConfigs []config.Config, env config.EnvironmentProperties) []error {
+	var errs []error
+	resolvedEntities := entities.New()
+	notRendered := map[coordinate.Coordinate]struct{}{}
+
+	for _, c := range sortedConfigs {
+		ctx := context.WithValue(ctx, log.CtxKeyCoord{}, c.Coordinate)
+
+		if c.Skip || dependsOnAny(c, notRendered) {
+			slog.DebugContext(ctx, "Not rendering config, as it or one of its dependencies is skipped or invalid")
+			notRendered[c.Coordinate] = struct{}{}
+			continue
+		}
+
+		if err := render(ctx, resolvedEntities, c, env); err != nil {
+			errs = append(errs, err)
+			notRendered[c.Coordinate] = struct{}{}
+		}
+	}
+
+	return errs
+}
+
+func render(ctx context.Context, resolvedEntities *entities.EntityMap, c config.Config, env config.EnvironmentProperties) error {
+	var unsetVariables []string
+	c.Parameters, c.ParameterSchemas, unsetVariables = withPlaceholderEnvironmentVariables(c.Parameters, c.ParameterSchemas)
+
+	properties, errs := c.ResolveParameterValuesForEnvironment(resolvedEntities, env)
+	if len(errs) > 0 {
+		return deployErrors.NewConfigDeployErr(&c, "failed to resolve parameter values").WithError(errors.Join(errs...))
+	}
+
+	if _, err := c.RenderForEnvironment(properties, env); err != nil {
+		var jsonErr json.JsonValidationError
+		if len(unsetVariables) == 0 || !errors.As(err, &jsonErr) {
+			return err
+		}
+		slog.WarnContext(ctx, "Rendered template is not valid JSON, which is not reported as it depends on unset environment variables", slog.Any("environmentVariables", unsetVariables), log.ErrorAttr(err))
+	}
+
+	if _, found := properties[config.IdParameter]; !found {
+		properties[config.IdParameter] = idutils.GenerateUUIDFromCoordinate(c.Coordinate)
+	}
+	resolvedEntities.Put(entities.ResolvedEntity{Coordinate: c.Coordinate, Properties: properties})
+
+	return nil
+}
+
+func dependsOnAny(c config.Config, coordinates map[coordinate.Coordinate]struct{}) bool {
+	for _, ref := range c.References() {
+		if _, found := coordinates[ref]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// withPlaceholderEnvironmentVariables returns copies of the given parameters and schemas in which all environment
+// variable parameters that are not set and have no default value resolve to a placeholder. Placeholders satisfy the
+// schema of their parameter if possible, otherwise the schema is removed, as the actual value can not be checked.
+// The names of the environment variables replaced by placeholders are returned as well.
+func withPlaceholderEnvironmentVariables(parameters config.Parameters, schemas config.ParameterSchemas) (config.Parameters, config.ParameterSchemas, []string) {
+	resultParameters := make(config.Parameters, len(parameters))
+	resultSchemas := maps.Clone(schemas)
+	var unsetVariables []string
+
+	for _, name := range slices.Sorted(maps.Keys(parameters)) {
+		p := parameters[name]
+		if e, ok := p.(*envParam.EnvironmentVariableParameter); ok && !e.HasDefaultValue {
+			if _, set := os.LookupEnv(e.Name); !set {
+				placeholder, satisfiesSchema := placeholderFor(e.Name, schemas[name])
+				if !satisfiesSchema {
+					delete(resultSchemas, name)
+				}
+				p = envParam.NewWithDefault(e.Name, placeholder)
+				unsetVariables = append(unsetVariables, e.Name)
+			}
+		}
+		resultParameters[name] = p
+	}
+
+	return resultParameters, resultSchemas, unsetVariables
+}
+
+// placeholderFor returns the placeholder of the given unset environment variable and whether it satisfies the given
+// schema. Parameters without a schema are satisfied by any placeholder.
+func placeholderFor(envVar string, schema config.ParameterSchema) (string, bool) {
+	switch schema.Type {
+	case config.IntParameterSchemaType:
+		switch {
+		case schema.Min != nil:
+			return strconv.Itoa(*schema.Min), true
+		case schema.Max != nil:
+			return strconv.Itoa(*schema.Max), true
+		default:
+			return "0", true
+		}
+	case config.BoolParameterSchemaType:
+		return "false", true
+	case config.EnumParameterSchemaType:
+		return schema.Values[0], true
+	}
+
+	placeholder := fmt.Sprintf("PLACEHOLDER OF ENV_VAR %s", envVar)
+	return placeholder, schema.Pattern == ""
+}
@@ -27,17 +27,20 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/timeutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/writer"
 )
 
 type WriterContext struct {
-	EnvironmentUrl  manifest.URLDefinition
-	ProjectToWrite  project.Project
-	Auth            manifest.Auth
-	OutputFolder    string
-	ForceOverwrite  bool
+	EnvironmentUrl manifest.URLDefinition
+	ProjectToWrite project.Project
+	Auth           manifest.Auth
+	OutputFolder   string
+	ForceOverwrite bool
+	// TemplateFormat defines the format downloaded templates are written in. If not set, templates are written as JSON.
+	TemplateFormat  template.Format
 	timestampString string
 }
 
@@ -96,6 +99,7 @@ func writeToDisk(fs afero.Fs, writerContext WriterContext) error {
 		OutputDir:       outputFolder,
 		ManifestName:    manifestFileName,
 		ParametersSerde: config.DefaultParameterParsers,
+		TemplateFormat:  writerContext.TemplateFormat,
 	}, manifest, []project.Project{writerContext.ProjectToWrite})
 
 	if len(errs) > 0 {
@@ -18,10 +18,10 @@ package id_extraction
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	ref "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
@@ -29,12 +29,6 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
 
-// meIDRegexPattern matching a Dynatrace Monitored Entity ID which consists of a type containing characters and
-// underscores, a dash separator '-' and 16 hex numbers
-var meIDRegexPattern = regexp.MustCompile(`[a-zA-Z_]+-[A-Fa-f0-9]{16}`)
-
-var uuidRegexPattern = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
-
 const baseParamID = "extractedIDs"
 
 // ExtractIDsIntoYAML searches for Dynatrace ID patterns in each given config and extracts them from the config's
@@ -47,7 +41,7 @@ func ExtractIDsIntoYAML(configsPerType project.ConfigsPerType) (project.ConfigsP
 				return nil, fmt.Errorf("failed to extract IDs from %s: %w", c.Coordinate, err)
 			}
 
-			ids := findAllIds(content)
+			ids := idutils.FindIDs(content)
 
 			idMap := map[string]string{}
 
@@ -95,26 +89,6 @@ func ExtractIDsIntoYAML(configsPerType project.ConfigsPerType) (project.ConfigsP
 	return configsPerType, nil
 }
 
-// invalidMeId finds ME IDs in the form of `nABC`, `rABC`, and `tABC`. They are most commonly mistakenly created by `\nABC`.
-var invalidMeId = regexp.MustCompile("[nrt][A-Z]+")
-
-func findAllIds(content string) []string {
-	ids := meIDRegexPattern.FindAllString(content, -1)
-
-	// Super simple, hacky, sanity check to not accidentally include matches for ME IDs in the form of `nABC-123` which result from `\nABC-123`.
-	// Alternatives to the check is validating the content of matches for `\`, but a `\` can also be escaped and would require recursive checking.
-	// To not do that, we simply check if the id starts with `n`, `r`, `t` (from `\n`, `\r`, `\t`), and then only uppercase characters. This is a good check for potential issues.
-	for i, id := range ids {
-		if invalidMeId.MatchString(id) {
-			ids[i] = id[1:]
-		}
-	}
-
-	ids = append(ids, uuidRegexPattern.FindAllString(content, -1)...)
-
-	return ids
-}
-
 func createParameterKey(id string) string {
 	idKey := strings.ReplaceAll(id, "-", "_")   // golang template keys must not contain hyphens
 	idKey = strings.ReplaceAll(idKey, ".", "_") // monaco templating would treat any dot as referencing a nested sub-key in value parameters, but we're just building simple key:val parameters
@@ -466,59 +466,3 @@ func TestExtractedTemplatesRenderCorrectly(t *testing.T) {
 		}
 	}
 }
-
-func TestFindAllIds(t *testing.T) {
-
-	tc := []struct {
-		in          string
-		expectedIds []string
-	}{
-		{"", nil},
-		{
-			"HOST-0123456789ABCDEF",
-			[]string{"HOST-0123456789ABCDEF"},
-		},
-		{
-			"f1614cf1-4f6e-4187-b303-af4beb42268c",
-			[]string{"f1614cf1-4f6e-4187-b303-af4beb42268c"},
-		},
-		{
-			`{"HOST": "HOST-0123456789ABCDEF", "id": "f1614cf1-4f6e-4187-b303-af4beb42268c"}`,
-			[]string{"f1614cf1-4f6e-4187-b303-af4beb42268c", "HOST-0123456789ABCDEF"},
-		},
-		{
-			"HELLO-Imnotanentityidbutstilliwasdetectedassuch",
-			nil,
-		},
-		{
-			`\nABC-0000000000000000`,
-			[]string{"ABC-0000000000000000"},
-		},
-		{
-			`\rABC-0000000000000000`,
-			[]string{"ABC-0000000000000000"},
-		},
-		{
-			`\tABC-0000000000000000`,
-			[]string{"ABC-0000000000000000"},
-		},
-		{
-			`\\tABC-0000000000000000`,
-			[]string{"ABC-0000000000000000"},
-		},
-		{
-			`\nf1614cf1-4f6e-4187-b303-af4beb42268c`,
-			[]string{"f1614cf1-4f6e-4187-b303-af4beb42268c"},
-		},
-	}
-
-	for _, tt := range tc {
-		t.Run(tt.in, func(t *testing.T) {
-			t.Parallel()
-
-			foundIds := findAllIds(tt.in)
-			assert.ElementsMatch(t, tt.expectedIds, foundIds)
-		})
-	}
-
-}
@@ -0,0 +1,175 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lint checks projects for issues that do not fail a deployment, but make projects harder to maintain or tie
+// them to a single environment.
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+// DefaultMaxTemplateSize is the size in bytes above which templates are reported by the template-size rule, if no
+// other size is configured.
+const DefaultMaxTemplateSize = 256 * 1024
+
+// Options configure which rules are run, and how.
+type Options struct {
+	// Rules are the IDs of the rules to run. If empty, all rules are run.
+	Rules []string
+	// DisabledRules are the IDs of the rules not to run.
+	DisabledRules []string
+	// MaxTemplateSize is the size in bytes above which templates are reported. If 0, DefaultMaxTemplateSize is used.
+	MaxTemplateSize int
+	// NamingPattern is the pattern all config IDs must match. If nil, the IDs of each project are expected to follow
+	// the naming style used by most configs of the project.
+	NamingPattern *regexp.Regexp
+}
+
+// Rule is a single check run on all configs.
+type Rule struct {
+	ID          string
+	Description string
+	check       func(configs []instances, opts Options) []Finding
+}
+
+// Finding is a single issue found by a rule.
+type Finding struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	// Coordinate of the config the finding belongs to
+	Coordinate coordinate.Coordinate `json:"coordinate"`
+	// File is the template of the config - omitted if the template is not loaded from a file
+	File string `json:"file,omitempty"`
+}
+
+// instances are the configs of a single coordinate, one for each environment the config is loaded for.
+type instances struct {
+	coordinate coordinate.Coordinate
+	configs    []config.Config
+}
+
+// Rules returns all available rules.
+func Rules() []Rule {
+	return []Rule{
+		{ID: "unused-parameter", Description: "Parameters that are neither used by the template, nor referenced by any parameter", check: unusedParameters},
+		{ID: "undefined-template-variable", Description: "Template variables that are not set by any parameter", check: undefinedTemplateVariables},
+		{ID: "hardcoded-id", Description: "Dynatrace IDs in templates that should be references or parameters", check: hardcodedIDs},
+		{ID: "deprecated-api", Description: "Configs of deprecated APIs", check: deprecatedAPIs},
+		{ID: "not-deployed", Description: "Configs that are skipped in all environments", check: notDeployed},
+		{ID: "naming-convention", Description: "Config IDs that do not follow the naming convention", check: namingConvention},
+		{ID: "template-size", Description: "Templates exceeding the maximum size", check: templateSize},
+	}
+}
+
+// Lint runs the rules selected by the given options on all configs of the given projects. Findings are sorted by
+// coordinate.
+func Lint(projects []project.Project, opts Options) ([]Finding, error) {
+	rules, err := selectRules(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.MaxTemplateSize == 0 {
+		opts.MaxTemplateSize = DefaultMaxTemplateSize
+	}
+
+	configs := groupByCoordinate(projects)
+
+	var findings []Finding
+	for _, r := range rules {
+		for _, f := range r.check(configs, opts) {
+			f.Rule = r.ID
+			findings = append(findings, f)
+		}
+	}
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Or(
+			strings.Compare(a.Coordinate.String(), b.Coordinate.String()),
+			strings.Compare(a.Rule, b.Rule),
+			strings.Compare(a.Message, b.Message),
+			strings.Compare(a.File, b.File),
+		)
+	})
+	return slices.Compact(findings), nil
+}
+
+func selectRules(opts Options) ([]Rule, error) {
+	all := Rules()
+	ids := make([]string, 0, len(all))
+	for _, r := range all {
+		ids = append(ids, r.ID)
+	}
+	for _, id := range append(slices.Clone(opts.Rules), opts.DisabledRules...) {
+		if !slices.Contains(ids, id) {
+			return nil, fmt.Errorf("unknown rule %q - must be one of [%s]", id, strings.Join(ids, ", "))
+		}
+	}
+
+	var result []Rule
+	for _, r := range all {
+		if (len(opts.Rules) == 0 || slices.Contains(opts.Rules, r.ID)) && !slices.Contains(opts.DisabledRules, r.ID) {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// groupByCoordinate returns the configs of all projects grouped by their coordinate, sorted by coordinate and
+// environment.
+func groupByCoordinate(projects []project.Project) []instances {
+	byCoordinate := map[coordinate.Coordinate]*instances{}
+	for _, p := range projects {
+		for _, env := range slices.Sorted(maps.Keys(p.Configs)) {
+			for c := range p.Configs[env].AllConfigs {
+				i, found := byCoordinate[c.Coordinate]
+				if !found {
+					i = &instances{coordinate: c.Coordinate}
+					byCoordinate[c.Coordinate] = i
+				}
+				i.configs = append(i.configs, c)
+			}
+		}
+	}
+
+	result := make([]instances, 0, len(byCoordinate))
+	for _, i := range byCoordinate {
+		result = append(result, *i)
+	}
+	slices.SortFunc(result, func(a, b instances) int {
+		return strings.Compare(a.coordinate.String(), b.coordinate.String())
+	})
+	return result
+}
+
+// newFinding returns a finding for the given config, located at its template if the template is loaded from a file.
+func newFinding(c config.Config, format string, args ...any) Finding {
+	f := Finding{Coordinate: c.Coordinate, Message: fmt.Sprintf(format, args...)}
+	if t, ok := c.Template.(*template.FileBasedTemplate); ok {
+		f.File = t.FilePath()
+	}
+	return f
+}
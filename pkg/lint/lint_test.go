@@ -0,0 +1,203 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
+)
+
+func newConfig(typ config.Type, id string, content string, parameters config.Parameters) config.Config {
+	return config.Config{
+		Coordinate:         coordinate.Coordinate{Project: "project", Type: typeName(typ), ConfigId: id},
+		Type:               typ,
+		Template:           template.NewInMemoryTemplate(id, content),
+		Parameters:         parameters,
+		DeclaredParameters: slices.Sorted(maps.Keys(parameters)),
+	}
+}
+
+func typeName(typ config.Type) string {
+	switch t := typ.(type) {
+	case config.ClassicApiType:
+		return t.Api
+	case config.SettingsType:
+		return t.SchemaId
+	}
+	return string(typ.ID())
+}
+
+// newProject returns a project holding the given configs for each of the given environments.
+func newProject(environments []string, configs ...config.Config) project.Project {
+	p := project.Project{Id: "project", Configs: project.ConfigsPerTypePerEnvironments{}}
+	for _, env := range environments {
+		p.Configs[env] = project.ConfigsPerType{}
+		for _, c := range configs {
+			c.Environment = env
+			p.Configs[env][c.Coordinate.Type] = append(p.Configs[env][c.Coordinate.Type], c)
+		}
+	}
+	return p
+}
+
+func messagesOf(findings []Finding) []string {
+	var result []string
+	for _, f := range findings {
+		result = append(result, f.Rule+": "+f.Message)
+	}
+	return result
+}
+
+var settingsType = config.SettingsType{SchemaId: "builtin:alerting.profile"}
+
+func TestLint_Parameters(t *testing.T) {
+	zone := newConfig(settingsType, "zone", `{"name": "{{ .name }}", "rules": {{ .rules }}, "owner": "{{ .owner }}", "region": "{{ .environment.name }}"}`, config.Parameters{
+		config.NameParameter: value.New("zone"),
+		"rules":              value.New("[]"),
+		"unused":             value.New("x"),
+		"referencedByOthers": value.New("y"),
+	})
+	profile := newConfig(settingsType, "profile", `{"zone": "{{ .zone }}"}`, config.Parameters{
+		"zone": reference.New("project", "builtin:alerting.profile", "zone", "referencedByOthers"),
+	})
+
+	findings, err := Lint([]project.Project{newProject([]string{"env"}, zone, profile)}, Options{Rules: []string{"unused-parameter", "undefined-template-variable"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`undefined-template-variable: template variable "owner" is not set by any parameter`,
+		`unused-parameter: parameter "unused" is neither used by the template, nor referenced by any parameter`,
+	}, messagesOf(findings))
+	assert.Equal(t, zone.Coordinate, findings[0].Coordinate)
+}
+
+func TestLint_UndefinedTemplateVariableInSomeEnvironments(t *testing.T) {
+	p := newProject([]string{"a", "b", "c"}, newConfig(settingsType, "zone", `{"owner": "{{ .owner }}"}`, config.Parameters{}))
+	p.Configs["b"]["builtin:alerting.profile"][0].Parameters = config.Parameters{"owner": value.New("me")}
+
+	findings, err := Lint([]project.Project{p}, Options{Rules: []string{"undefined-template-variable"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`undefined-template-variable: template variable "owner" is not set in environments [a, c]`}, messagesOf(findings))
+}
+
+func TestLint_OptionalTemplateVariablesNeedNotBeSet(t *testing.T) {
+	p := newProject([]string{"env"}, newConfig(settingsType, "zone", `{"owner": "{{ .owner | default "me" }}", "team": "{{ required "team is missing" .team }}", "region": "{{ .region }}"}`, config.Parameters{}))
+
+	findings, err := Lint([]project.Project{p}, Options{Rules: []string{"undefined-template-variable"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`undefined-template-variable: template variable "region" is not set by any parameter`}, messagesOf(findings))
+}
+
+func TestLint_HardcodedIDs(t *testing.T) {
+	c := newConfig(settingsType, "profile", `{"entity": "HOST-0123456789ABCDEF", "managementZoneId": "-1234567890123", "id": "f1614cf1-4f6e-4187-b303-af4beb42268c", "other": "{{ .name }}"}`, config.Parameters{})
+
+	findings, err := Lint([]project.Project{newProject([]string{"a", "b"}, c)}, Options{Rules: []string{"hardcoded-id"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`hardcoded-id: template contains the hard-coded Dynatrace ID "-1234567890123" - use a reference or parameter instead`,
+		`hardcoded-id: template contains the hard-coded Dynatrace ID "HOST-0123456789ABCDEF" - use a reference or parameter instead`,
+		`hardcoded-id: template contains the hard-coded Dynatrace ID "f1614cf1-4f6e-4187-b303-af4beb42268c" - use a reference or parameter instead`,
+	}, messagesOf(findings))
+}
+
+func TestLint_DeprecatedAPIsAndNotDeployedConfigs(t *testing.T) {
+	zone := newConfig(config.ClassicApiType{Api: "management-zone"}, "zone", `{}`, config.Parameters{})
+	skipped := newConfig(settingsType, "skipped", `{}`, config.Parameters{})
+	skipped.Skip = true
+
+	partiallySkipped := newConfig(settingsType, "partially-skipped", `{}`, config.Parameters{})
+
+	p := newProject([]string{"a", "b"}, zone, skipped, partiallySkipped)
+	p.Configs["a"]["builtin:alerting.profile"][1].Skip = true
+
+	findings, err := Lint([]project.Project{p}, Options{DisabledRules: []string{"naming-convention", "unused-parameter", "undefined-template-variable", "hardcoded-id", "template-size"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`not-deployed: config is skipped in all environments`,
+		`deprecated-api: API "management-zone" is deprecated - migrate the config to "builtin:management-zones"`,
+	}, messagesOf(findings))
+}
+
+func TestLint_NamingConvention(t *testing.T) {
+	p := newProject([]string{"env"},
+		newConfig(settingsType, "team-a-profile", `{}`, nil),
+		newConfig(settingsType, "team-b-profile", `{}`, nil),
+		newConfig(settingsType, "profile", `{}`, nil),
+		newConfig(settingsType, "team_c_profile", `{}`, nil),
+		newConfig(settingsType, "TeamD", `{}`, nil),
+	)
+
+	t.Run("IDs are expected to follow the style used by most configs", func(t *testing.T) {
+		findings, err := Lint([]project.Project{p}, Options{Rules: []string{"naming-convention"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			`naming-convention: config ID "TeamD" does not follow the kebab-case naming used by most configs of project "project"`,
+			`naming-convention: config ID "team_c_profile" does not follow the kebab-case naming used by most configs of project "project"`,
+		}, messagesOf(findings))
+	})
+
+	t.Run("IDs are checked against the configured pattern", func(t *testing.T) {
+		findings, err := Lint([]project.Project{p}, Options{Rules: []string{"naming-convention"}, NamingPattern: regexp.MustCompile(`^team-`)})
+		require.NoError(t, err)
+		assert.Len(t, findings, 3)
+	})
+}
+
+func TestLint_TemplateSize(t *testing.T) {
+	c := newConfig(settingsType, "profile", `{"a": "`+strings.Repeat("x", 100)+`"}`, config.Parameters{})
+
+	findings, err := Lint([]project.Project{newProject([]string{"env"}, c)}, Options{Rules: []string{"template-size"}, MaxTemplateSize: 50})
+	require.NoError(t, err)
+	assert.Equal(t, []string{`template-size: template is 109 bytes large, exceeding the maximum of 50 bytes - consider splitting it into partials`}, messagesOf(findings))
+
+	findings, err = Lint([]project.Project{newProject([]string{"env"}, c)}, Options{Rules: []string{"template-size"}})
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestLint_UnknownRule(t *testing.T) {
+	_, err := Lint(nil, Options{DisabledRules: []string{"unknown"}})
+	assert.ErrorContains(t, err, `unknown rule "unknown"`)
+}
+
+func TestToSARIF(t *testing.T) {
+	report := ToSARIF([]Finding{
+		{Rule: "hardcoded-id", Message: "message", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "c"}, File: "p/template.json"},
+		{Rule: "not-deployed", Message: "other", Coordinate: coordinate.Coordinate{Project: "p", Type: "t", ConfigId: "d"}},
+	})
+
+	require.Len(t, report.Runs, 1)
+	assert.Len(t, report.Runs[0].Tool.Driver.Rules, len(Rules()))
+	require.Len(t, report.Runs[0].Results, 2)
+	assert.Equal(t, "p:t:c: message", report.Runs[0].Results[0].Message.Text)
+	assert.Equal(t, "p/template.json", report.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Empty(t, report.Runs[0].Results[1].Locations)
+}
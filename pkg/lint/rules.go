@@ -0,0 +1,288 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
+)
+
+// specialParameters are used by monaco itself, so they need not be used by the template.
+var specialParameters = []string{
+	config.IdParameter,
+	config.NameParameter,
+	config.ScopeParameter,
+	config.InsertAfterParameter,
+	config.SkipParameter,
+	config.NonUniqueNameConfigDuplicationParameter,
+	loader.ForEachParameter,
+}
+
+// builtinProperties are available to every template without being defined as parameter.
+var builtinProperties = []string{config.EnvironmentProperty, config.ProjectProperty}
+
+// unusedParameters reports parameters that are not used in any environment.
+func unusedParameters(configs []instances, _ Options) []Finding {
+	referenced := referencedProperties(configs)
+
+	var findings []Finding
+	for _, i := range configs {
+		used := maps.Clone(referenced[i.coordinate])
+		if used == nil {
+			used = map[string]struct{}{}
+		}
+		defined := map[string]struct{}{}
+		parsed := true
+
+		for _, c := range i.configs {
+			if c.Template == nil {
+				continue
+			}
+			fields, err := template.Fields(c.Template)
+			if err != nil {
+				// templates that can not be parsed fail to deploy and are reported by 'monaco validate'
+				parsed = false
+				break
+			}
+			for _, f := range fields {
+				used[f] = struct{}{}
+			}
+			// parameters of project defaults and values files are shared by many configs, so need not be used by each
+			for _, name := range c.DeclaredParameters {
+				defined[name] = struct{}{}
+			}
+		}
+
+		if !parsed {
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(defined)) {
+			if _, found := used[name]; !found && !slices.Contains(specialParameters, name) {
+				findings = append(findings, newFinding(i.configs[0], "parameter %q is neither used by the template, nor referenced by any parameter", name))
+			}
+		}
+	}
+	return findings
+}
+
+// referencedProperties returns the names of the properties of each config that are referenced by any parameter.
+func referencedProperties(configs []instances) map[coordinate.Coordinate]map[string]struct{} {
+	result := map[coordinate.Coordinate]map[string]struct{}{}
+	for _, i := range configs {
+		for _, c := range i.configs {
+			for _, p := range c.Parameters {
+				for _, ref := range p.GetReferences() {
+					if result[ref.Config] == nil {
+						result[ref.Config] = map[string]struct{}{}
+					}
+					name, _, _ := strings.Cut(ref.Property, ".")
+					result[ref.Config][name] = struct{}{}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// undefinedTemplateVariables reports template variables that are not set by a parameter in at least one environment.
+func undefinedTemplateVariables(configs []instances, _ Options) []Finding {
+	var findings []Finding
+	for _, i := range configs {
+		missingIn := map[string][]string{}
+		for _, c := range i.configs {
+			if c.Template == nil {
+				continue
+			}
+			fields, err := template.Fields(c.Template)
+			if err != nil {
+				continue
+			}
+			// optional fields are passed to 'default' or 'required', which handle them being undefined
+			optional, err := template.OptionalFields(c.Template)
+			if err != nil {
+				continue
+			}
+			for _, f := range fields {
+				if slices.Contains(optional, f) {
+					continue
+				}
+				if _, found := c.Parameters[f]; !found && !slices.Contains(builtinProperties, f) {
+					missingIn[f] = append(missingIn[f], c.Environment)
+				}
+			}
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(missingIn)) {
+			if len(missingIn[name]) == len(i.configs) {
+				findings = append(findings, newFinding(i.configs[0], "template variable %q is not set by any parameter", name))
+			} else {
+				findings = append(findings, newFinding(i.configs[0], "template variable %q is not set in environments [%s]", name, strings.Join(missingIn[name], ", ")))
+			}
+		}
+	}
+	return findings
+}
+
+// managementZoneIDPattern matches numeric management zone IDs, which can not be told apart from other numbers but by
+// the key they are assigned to.
+var managementZoneIDPattern = regexp.MustCompile(`(?i)"(?:managementZoneIds?|mzIds?|managementZone)"\s*:\s*\[?\s*"?(-?[0-9]{6,})`)
+
+// hardcodedIDs reports Dynatrace IDs in templates. IDs differ between environments, so they should be references to
+// other configs, or parameters.
+func hardcodedIDs(configs []instances, _ Options) []Finding {
+	var findings []Finding
+	for _, i := range configs {
+		for _, c := range i.configs {
+			if c.Template == nil {
+				continue
+			}
+			content, err := c.Template.Content()
+			if err != nil {
+				continue
+			}
+
+			ids := idutils.FindIDs(content)
+			for _, match := range managementZoneIDPattern.FindAllStringSubmatch(content, -1) {
+				ids = append(ids, match[1])
+			}
+
+			for _, id := range ids {
+				findings = append(findings, newFinding(c, "template contains the hard-coded Dynatrace ID %q - use a reference or parameter instead", id))
+			}
+		}
+	}
+	return findings
+}
+
+// deprecatedAPIs reports configs of classic APIs that are deprecated by a Settings 2.0 schema.
+func deprecatedAPIs(configs []instances, _ Options) []Finding {
+	apis := api.NewAPIs()
+
+	var findings []Finding
+	for _, i := range configs {
+		t, ok := i.configs[0].Type.(config.ClassicApiType)
+		if !ok {
+			continue
+		}
+		if a, found := apis[t.Api]; found && a.DeprecatedBy != "" {
+			findings = append(findings, newFinding(i.configs[0], "API %q is deprecated - migrate the config to %q", t.Api, a.DeprecatedBy))
+		}
+	}
+	return findings
+}
+
+// notDeployed reports configs that are skipped in all environments.
+func notDeployed(configs []instances, _ Options) []Finding {
+	var findings []Finding
+	for _, i := range configs {
+		if !slices.ContainsFunc(i.configs, func(c config.Config) bool { return !c.Skip }) {
+			findings = append(findings, newFinding(i.configs[0], "config is skipped in all environments"))
+		}
+	}
+	return findings
+}
+
+// singleWordPattern matches IDs that consist of a single word, which follow any naming style.
+var singleWordPattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+var namingStyles = []struct {
+	name    string
+	pattern *regexp.Regexp
+}{
+	{"kebab-case", regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)+$`)},
+	{"snake_case", regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)+$`)},
+	{"camelCase", regexp.MustCompile(`^[a-z][a-z0-9]*([A-Z][a-z0-9]*)+$`)},
+}
+
+// namingConvention reports config IDs that do not match the configured naming pattern. If no pattern is configured,
+// the IDs of each project are expected to follow the naming style used by most configs of the project.
+func namingConvention(configs []instances, opts Options) []Finding {
+	var findings []Finding
+
+	if opts.NamingPattern != nil {
+		for _, i := range configs {
+			if !opts.NamingPattern.MatchString(i.coordinate.ConfigId) {
+				findings = append(findings, newFinding(i.configs[0], "config ID %q does not match the naming pattern %q", i.coordinate.ConfigId, opts.NamingPattern))
+			}
+		}
+		return findings
+	}
+
+	byProject := map[string][]instances{}
+	for _, i := range configs {
+		byProject[i.coordinate.Project] = append(byProject[i.coordinate.Project], i)
+	}
+
+	for _, project := range slices.Sorted(maps.Keys(byProject)) {
+		counts := make([]int, len(namingStyles))
+		for _, i := range byProject[project] {
+			for s, style := range namingStyles {
+				if style.pattern.MatchString(i.coordinate.ConfigId) {
+					counts[s]++
+				}
+			}
+		}
+
+		dominant := 0
+		for s := range counts {
+			if counts[s] > counts[dominant] {
+				dominant = s
+			}
+		}
+		if counts[dominant] == 0 {
+			continue
+		}
+
+		style := namingStyles[dominant]
+		for _, i := range byProject[project] {
+			id := i.coordinate.ConfigId
+			if !style.pattern.MatchString(id) && !singleWordPattern.MatchString(id) {
+				findings = append(findings, newFinding(i.configs[0], "config ID %q does not follow the %s naming used by most configs of project %q", id, style.name, project))
+			}
+		}
+	}
+	return findings
+}
+
+// templateSize reports templates that exceed the configured maximum size.
+func templateSize(configs []instances, opts Options) []Finding {
+	var findings []Finding
+	for _, i := range configs {
+		for _, c := range i.configs {
+			if c.Template == nil {
+				continue
+			}
+			content, err := c.Template.Content()
+			if err != nil {
+				continue
+			}
+			if len(content) > opts.MaxTemplateSize {
+				findings = append(findings, newFinding(c, "template is %d bytes large, exceeding the maximum of %d bytes - consider splitting it into partials", len(content), opts.MaxTemplateSize))
+			}
+		}
+	}
+	return findings
+}
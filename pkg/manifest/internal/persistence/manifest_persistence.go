@@ -36,6 +36,8 @@ type Type string
 const (
 	TypeEnvironment Type = "environment"
 	TypeValue       Type = "value"
+	TypeFile        Type = "file"
+	TypeExec        Type = "exec"
 )
 
 // TypedValue represents a value with a Type - currently these are variables that can be either:
@@ -66,17 +68,23 @@ func (c *TypedValue) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-// AuthSecret represents a user-defined client id or client secret. It has a [Type] which is [TypeEnvironment] (default).
-// Secrets must never be provided as plain text, but always loaded from somewhere else. Currently, loading is only allowed from environment variables.
-//
-// [Name] contains the environment-variable to resolve the authSecret.
+// AuthSecret represents a user-defined client id or client secret. It has a [Type] which is either
+// [TypeEnvironment] (default), [TypeFile] or [TypeExec].
+// Secrets must never be provided as plain text, but always loaded from somewhere else:
+//   - [TypeEnvironment]: [Name] contains the environment-variable to resolve the authSecret.
+//   - [TypeFile]: [Path] contains the file to read the authSecret from, e.g. a mounted Kubernetes secret.
+//   - [TypeExec]: [Command] contains a credential-helper command printing the authSecret to stdout.
 //
 // This struct is meant to be reused for fields that require the same behavior.
 type AuthSecret struct {
-	// Type exists for future compatibility - AuthSecrets are always read from 'environment' variables
+	// Type defines from where the secret is read - 'environment' (default), 'file' or 'exec'
 	Type Type `yaml:"type" json:"type,omitempty"`
 	//Name of the environment variable to read the secret from.
-	Name string `yaml:"name" json:"name"`
+	Name string `yaml:"name,omitempty" json:"name,omitempty"`
+	// Path of the file to read the secret from. Relative paths are resolved relative to the manifest.
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+	// Command to execute to retrieve the secret. The first element is the executable, the others are its arguments.
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
 }
 
 // OAuth defines the required information to request oAuth bearer tokens for authenticated API calls
@@ -105,12 +113,55 @@ type Environment struct {
 	URL  TypedValue `yaml:"url" json:"url"`
 
 	Auth Auth `yaml:"auth,omitempty" json:"auth"`
+
+	Labels map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	// Variables are arbitrary values made available to the configs deployed to the environment.
+	// They take precedence over variables of the same name defined by the Group.
+	Variables map[string]string `yaml:"variables,omitempty" json:"variables,omitempty"`
+
+	// Connection defines optional settings for the HTTP connection to the environment.
+	Connection *Connection `yaml:"connection,omitempty" json:"connection,omitempty"`
+
+	// Limits defines optional limits for the requests sent to the environment.
+	// Each limit takes precedence over the same limit defined by the Group.
+	Limits *Limits `yaml:"limits,omitempty" json:"limits,omitempty"`
+}
+
+// Limits defines optional limits for the requests sent to an Environment.
+// Limits that are not defined fall back to the values of the respective environment variables.
+type Limits struct {
+	// ConcurrentRequests is the maximum number of concurrent requests.
+	ConcurrentRequests *int `yaml:"concurrentRequests,omitempty" json:"concurrentRequests,omitempty"`
+	// RequestsPerSecond is the maximum number of requests started per second.
+	RequestsPerSecond *float64 `yaml:"requestsPerSecond,omitempty" json:"requestsPerSecond,omitempty"`
+	// ConcurrentDeployments is the maximum number of configurations deployed concurrently.
+	ConcurrentDeployments *int `yaml:"concurrentDeployments,omitempty" json:"concurrentDeployments,omitempty"`
+}
+
+// Connection defines optional settings for the HTTP connection to an Environment.
+// File paths are resolved relative to the manifest.
+type Connection struct {
+	// CABundle is the path of a PEM file holding certificates trusted in addition to the system's certificates.
+	CABundle string `yaml:"caBundle,omitempty" json:"caBundle,omitempty"`
+	// ClientCertificate is the path of a PEM file holding the client certificate used for mutual TLS.
+	ClientCertificate string `yaml:"clientCertificate,omitempty" json:"clientCertificate,omitempty"`
+	// ClientKey is the path of a PEM file holding the private key of the ClientCertificate.
+	ClientKey string `yaml:"clientKey,omitempty" json:"clientKey,omitempty"`
+	// Proxy is the URL of the proxy to connect through.
+	Proxy string `yaml:"proxy,omitempty" json:"proxy,omitempty"`
+	// Headers are additional HTTP headers sent with every request.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
 }
 
 // Group defines a group of Environment
 type Group struct {
 	Name         string        `yaml:"name" json:"name"`
 	Environments []Environment `yaml:"environments" json:"environments"`
+	// Variables are arbitrary values made available to the configs deployed to any environment of the group.
+	Variables map[string]string `yaml:"variables,omitempty" json:"variables,omitempty"`
+	// Limits defines optional limits for the requests sent to any environment of the group.
+	Limits *Limits `yaml:"limits,omitempty" json:"limits,omitempty"`
 }
 
 type Manifest struct {
@@ -121,6 +172,9 @@ type Manifest struct {
 	EnvironmentGroups []Group `yaml:"environmentGroups" json:"environmentGroups"`
 	// Accounts is a list of accounts that account resources in Projects will be deployed to
 	Accounts []Account `yaml:"accounts,omitempty" json:"accounts"`
+	// Includes are paths or glob patterns of further manifests whose projects, environment groups and accounts are
+	// merged into this manifest. They are relative to the including manifest.
+	Includes []string `yaml:"includes,omitempty" json:"includes,omitempty"`
 }
 
 type Account struct {
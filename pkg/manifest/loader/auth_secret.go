@@ -0,0 +1,163 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+// parseFileAuthSecret reads the secret from the file at the given path. Leading and trailing whitespace, like the
+// trailing newline of mounted secrets, is removed. Relative paths are resolved relative to the manifest.
+func parseFileAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
+	if s.Path == "" {
+		return manifest.AuthSecret{}, errors.New("no path given or empty")
+	}
+
+	result := manifest.AuthSecret{Type: manifest.FileAuthSecretType, Path: s.Path}
+
+	if context.Opts.DoNotResolveEnvVars {
+		log.Debug("Skipped reading secret file %s based on loader options", s.Path)
+		result.Value = secret.MaskedString(fmt.Sprintf("SKIPPED RESOLUTION OF FILE: %s", s.Path))
+		return result, nil
+	}
+
+	content, err := afero.ReadFile(context.Fs, resolveManifestRelativePath(context, s.Path))
+	if err != nil {
+		return manifest.AuthSecret{}, fmt.Errorf("failed to read secret file %q: %w", s.Path, err)
+	}
+
+	v := strings.TrimSpace(string(content))
+	if v == "" {
+		return manifest.AuthSecret{}, fmt.Errorf("secret file %q is empty", s.Path)
+	}
+
+	result.Value = secret.MaskedString(v)
+	return result, nil
+}
+
+// parseExecAuthSecret runs the given credential-helper command and uses what it prints to stdout as the secret.
+// The output of each command is cached for the rest of the run, so helpers shared by several secrets are only run once.
+func parseExecAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
+	if len(s.Command) == 0 || s.Command[0] == "" {
+		return manifest.AuthSecret{}, errors.New("no command given or empty")
+	}
+
+	result := manifest.AuthSecret{Type: manifest.ExecAuthSecretType, Command: s.Command}
+
+	if context.Opts.DoNotResolveEnvVars {
+		log.Debug("Skipped running credential helper %s based on loader options", s.Command[0])
+		result.Value = secret.MaskedString(fmt.Sprintf("SKIPPED RESOLUTION OF COMMAND: %s", s.Command[0]))
+		return result, nil
+	}
+
+	v, err := credentialHelpers.run(s.Command)
+	if err != nil {
+		return manifest.AuthSecret{}, err
+	}
+
+	result.Value = secret.MaskedString(v)
+	return result, nil
+}
+
+// credentialHelperCache holds the secrets printed by credential-helper commands, keyed by the command.
+type credentialHelperCache struct {
+	mutex   sync.Mutex
+	secrets map[string]string
+}
+
+var credentialHelpers = &credentialHelperCache{secrets: map[string]string{}}
+
+// credentialHelperTimeout is the time a credential-helper command may run before it is terminated, so that a hanging
+// helper does not block loading the manifest forever.
+var credentialHelperTimeout = 30 * time.Second
+
+// stderrTailLength is the number of bytes at the end of the error output of a failed credential helper that are
+// reported in the error.
+const stderrTailLength = 512
+
+func (c *credentialHelperCache) run(command []string) (string, error) {
+	key := strings.Join(command, "\x00")
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if v, found := c.secrets[key]; found {
+		return v, nil
+	}
+
+	log.Debug("Running credential helper %s", command[0])
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialHelperTimeout)
+	defer cancel()
+
+	// stdout holds the secret and is never logged, stderr is passed through so that helpers can report problems
+	var stdout bytes.Buffer
+	stderr := &tailBuffer{limit: stderrTailLength}
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
+	// processes started by the helper may keep its output open after it was terminated
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("did not finish within %s", credentialHelperTimeout)
+		}
+		if output := strings.TrimSpace(string(stderr.data)); output != "" {
+			return "", fmt.Errorf("credential helper %q failed: %w: %s", command[0], err, output)
+		}
+		return "", fmt.Errorf("credential helper %q failed: %w", command[0], err)
+	}
+
+	v := strings.TrimSpace(stdout.String())
+	if v == "" {
+		return "", fmt.Errorf("credential helper %q did not print a secret", command[0])
+	}
+
+	c.secrets[key] = v
+	return v, nil
+}
+
+// tailBuffer keeps the last limit bytes written to it.
+type tailBuffer struct {
+	limit int
+	data  []byte
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.data = b.data[len(b.data)-b.limit:]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,124 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+func TestParseAuthSecret_File(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "config/secrets/token", []byte("dt0c01.token\n"), 0400))
+	require.NoError(t, afero.WriteFile(fs, "/var/run/secrets/token", []byte("  dt0c01.mounted  "), 0400))
+	require.NoError(t, afero.WriteFile(fs, "config/secrets/empty", []byte("\n"), 0400))
+
+	context := &Context{Fs: fs, ManifestPath: "config/manifest.yaml"}
+
+	t.Run("relative paths are resolved relative to the manifest", func(t *testing.T) {
+		got, err := parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/token"})
+		require.NoError(t, err)
+		assert.Equal(t, manifest.AuthSecret{Type: manifest.FileAuthSecretType, Path: "secrets/token", Value: "dt0c01.token"}, got)
+	})
+
+	t.Run("absolute paths are read as is", func(t *testing.T) {
+		got, err := parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "/var/run/secrets/token"})
+		require.NoError(t, err)
+		assert.Equal(t, "dt0c01.mounted", got.Value.Value())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile})
+		assert.ErrorContains(t, err, "no path given or empty")
+
+		_, err = parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/missing"})
+		assert.ErrorContains(t, err, `failed to read secret file "secrets/missing"`)
+
+		_, err = parseAuthSecret(context, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/empty"})
+		assert.ErrorContains(t, err, `secret file "secrets/empty" is empty`)
+	})
+
+	t.Run("file is not read if resolution is deactivated", func(t *testing.T) {
+		got, err := parseAuthSecret(&Context{Fs: fs, Opts: Options{DoNotResolveEnvVars: true}}, &persistence.AuthSecret{Type: persistence.TypeFile, Path: "secrets/missing"})
+		require.NoError(t, err)
+		assert.Equal(t, "secrets/missing", got.Path)
+	})
+}
+
+func TestParseAuthSecret_Exec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("credential helper test requires a POSIX shell")
+	}
+
+	calls := filepath.Join(t.TempDir(), "calls")
+	command := []string{"sh", "-c", "echo called >> " + calls + "; echo ' dt0c01.helper '"}
+
+	for i := 0; i < 2; i++ {
+		got, err := parseAuthSecret(&Context{}, &persistence.AuthSecret{Type: persistence.TypeExec, Command: command})
+		require.NoError(t, err)
+		assert.Equal(t, manifest.AuthSecret{Type: manifest.ExecAuthSecretType, Command: command, Value: "dt0c01.helper"}, got)
+	}
+
+	content, err := afero.ReadFile(afero.NewOsFs(), calls)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(content), "called"), "credential helper output must be cached")
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := parseAuthSecret(&Context{}, &persistence.AuthSecret{Type: persistence.TypeExec})
+		assert.ErrorContains(t, err, "no command given or empty")
+
+		_, err = parseAuthSecret(&Context{}, &persistence.AuthSecret{Type: persistence.TypeExec, Command: []string{"sh", "-c", "exit 3"}})
+		assert.ErrorContains(t, err, `credential helper "sh" failed`)
+
+		_, err = parseAuthSecret(&Context{}, &persistence.AuthSecret{Type: persistence.TypeExec, Command: []string{"sh", "-c", "echo 'ignored' >&2; echo 'not logged in' >&2; exit 1"}})
+		assert.ErrorContains(t, err, "ignored\nnot logged in", "the error output of the helper must be reported")
+
+		_, err = parseAuthSecret(&Context{}, &persistence.AuthSecret{Type: persistence.TypeExec, Command: []string{"sh", "-c", "echo"}})
+		assert.ErrorContains(t, err, `credential helper "sh" did not print a secret`)
+	})
+
+	t.Run("hanging command is terminated", func(t *testing.T) {
+		previousTimeout := credentialHelperTimeout
+		credentialHelperTimeout = 100 * time.Millisecond
+		t.Cleanup(func() { credentialHelperTimeout = previousTimeout })
+
+		_, err := parseAuthSecret(&Context{}, &persistence.AuthSecret{Type: persistence.TypeExec, Command: []string{"sh", "-c", "echo waiting >&2; sleep 10"}})
+		assert.ErrorContains(t, err, `credential helper "sh" failed: did not finish within 100ms: waiting`)
+	})
+
+	t.Run("command is not run if resolution is deactivated", func(t *testing.T) {
+		_, err := parseAuthSecret(&Context{Opts: Options{DoNotResolveEnvVars: true}}, &persistence.AuthSecret{Type: persistence.TypeExec, Command: []string{"sh", "-c", "exit 3"}})
+		assert.NoError(t, err)
+	})
+}
+
+func TestParseAuthSecret_RejectsUnknownType(t *testing.T) {
+	_, err := parseAuthSecret(&Context{}, &persistence.AuthSecret{Type: "vault", Name: "TOKEN"})
+	assert.ErrorContains(t, err, "type must be one of 'environment', 'file' or 'exec'")
+}
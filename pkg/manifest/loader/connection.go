@@ -0,0 +1,117 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+var allowedProxySchemes = []string{"http", "https", "socks5"}
+
+// parseConnection parses the connection settings of an environment. The referenced files are read and validated, so
+// that invalid certificates are reported while loading the manifest instead of when connecting to the environment.
+func parseConnection(context *Context, c *persistence.Connection) (*manifest.Connection, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	if (c.ClientCertificate == "") != (c.ClientKey == "") {
+		return nil, errors.New("'clientCertificate' and 'clientKey' must be defined together")
+	}
+
+	if c.Proxy != "" {
+		u, err := url.Parse(c.Proxy)
+		if err != nil || u.Host == "" || !slices.Contains(allowedProxySchemes, u.Scheme) {
+			return nil, fmt.Errorf("proxy %q is not a valid URL - it must start with one of %v followed by '://' and a host", c.Proxy, allowedProxySchemes)
+		}
+	}
+
+	for name := range c.Headers {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.New("header names must not be empty")
+		}
+	}
+
+	result := manifest.Connection{Proxy: c.Proxy, Headers: c.Headers}
+
+	var err error
+	if result.CABundle, err = readPEMFile(context, c.CABundle); err != nil {
+		return nil, fmt.Errorf("failed to read 'caBundle': %w", err)
+	}
+	if result.ClientCertificate, err = readPEMFile(context, c.ClientCertificate); err != nil {
+		return nil, fmt.Errorf("failed to read 'clientCertificate': %w", err)
+	}
+	if result.ClientKey, err = readPEMFile(context, c.ClientKey); err != nil {
+		return nil, fmt.Errorf("failed to read 'clientKey': %w", err)
+	}
+
+	if context.Opts.DoNotResolveEnvVars {
+		return &result, nil
+	}
+
+	if result.CABundle != nil && !x509.NewCertPool().AppendCertsFromPEM([]byte(result.CABundle.Content.Value())) {
+		return nil, fmt.Errorf("'caBundle' %q does not contain any PEM encoded certificate", c.CABundle)
+	}
+
+	if result.ClientCertificate != nil {
+		if _, err := tls.X509KeyPair([]byte(result.ClientCertificate.Content.Value()), []byte(result.ClientKey.Content.Value())); err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+	}
+
+	return &result, nil
+}
+
+// readPEMFile reads the file at the given path. If the path is empty, nil is returned.
+// If the loader is configured not to resolve values, the file is not read.
+func readPEMFile(context *Context, path string) (*manifest.PEMFile, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	if context.Opts.DoNotResolveEnvVars {
+		return &manifest.PEMFile{Path: path}, nil
+	}
+
+	content, err := afero.ReadFile(context.Fs, resolveManifestRelativePath(context, path))
+	if err != nil {
+		return nil, err
+	}
+
+	return &manifest.PEMFile{Path: path, Content: secret.MaskedString(content)}, nil
+}
+
+// resolveManifestRelativePath resolves relative paths relative to the directory of the manifest.
+func resolveManifestRelativePath(context *Context, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(context.ManifestPath), path)
+}
@@ -0,0 +1,123 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/secret"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+// generateCertificate returns a PEM encoded self-signed certificate and its private key.
+func generateCertificate(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "monaco"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestParseConnection(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "config/certs/ca.pem", certPEM, 0400))
+	require.NoError(t, afero.WriteFile(fs, "config/certs/client.pem", certPEM, 0400))
+	require.NoError(t, afero.WriteFile(fs, "config/certs/client.key", keyPEM, 0400))
+	require.NoError(t, afero.WriteFile(fs, "config/certs/empty.pem", []byte("no certificate"), 0400))
+
+	context := &Context{Fs: fs, ManifestPath: "config/manifest.yaml"}
+
+	t.Run("no connection", func(t *testing.T) {
+		got, err := parseConnection(context, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("all settings", func(t *testing.T) {
+		got, err := parseConnection(context, &persistence.Connection{
+			CABundle:          "certs/ca.pem",
+			ClientCertificate: "certs/client.pem",
+			ClientKey:         "certs/client.key",
+			Proxy:             "http://proxy.internal:3128",
+			Headers:           map[string]string{"X-Tenant": "managed"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &manifest.Connection{
+			CABundle:          &manifest.PEMFile{Path: "certs/ca.pem", Content: secret.MaskedString(certPEM)},
+			ClientCertificate: &manifest.PEMFile{Path: "certs/client.pem", Content: secret.MaskedString(certPEM)},
+			ClientKey:         &manifest.PEMFile{Path: "certs/client.key", Content: secret.MaskedString(keyPEM)},
+			Proxy:             "http://proxy.internal:3128",
+			Headers:           map[string]string{"X-Tenant": "managed"},
+		}, got)
+	})
+
+	t.Run("files are not read if resolution is deactivated", func(t *testing.T) {
+		got, err := parseConnection(&Context{Fs: fs, Opts: Options{DoNotResolveEnvVars: true}}, &persistence.Connection{CABundle: "missing.pem"})
+		require.NoError(t, err)
+		assert.Equal(t, &manifest.Connection{CABundle: &manifest.PEMFile{Path: "missing.pem"}}, got)
+	})
+
+	tests := []struct {
+		name              string
+		connection        persistence.Connection
+		wantErrorContains string
+	}{
+		{"certificate without key", persistence.Connection{ClientCertificate: "certs/client.pem"}, "'clientCertificate' and 'clientKey' must be defined together"},
+		{"key without certificate", persistence.Connection{ClientKey: "certs/client.key"}, "'clientCertificate' and 'clientKey' must be defined together"},
+		{"proxy without scheme", persistence.Connection{Proxy: "proxy.internal:3128"}, `proxy "proxy.internal:3128" is not a valid URL`},
+		{"proxy with unknown scheme", persistence.Connection{Proxy: "ftp://proxy.internal"}, `proxy "ftp://proxy.internal" is not a valid URL`},
+		{"empty header name", persistence.Connection{Headers: map[string]string{" ": "value"}}, "header names must not be empty"},
+		{"missing CA bundle", persistence.Connection{CABundle: "certs/missing.pem"}, "failed to read 'caBundle'"},
+		{"CA bundle without certificates", persistence.Connection{CABundle: "certs/empty.pem"}, `'caBundle' "certs/empty.pem" does not contain any PEM encoded certificate`},
+		{"key not matching certificate", persistence.Connection{ClientCertificate: "certs/client.pem", ClientKey: "certs/empty.pem"}, "invalid client certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseConnection(context, &tt.connection)
+			assert.ErrorContains(t, err, tt.wantErrorContains)
+		})
+	}
+}
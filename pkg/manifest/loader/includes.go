@@ -0,0 +1,233 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/files"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+// includedManifests merges the manifests included by the root manifest into it. Definitions are tracked per name, to
+// report conflicting definitions together with the files they are defined in.
+type includedManifests struct {
+	fs       afero.Fs
+	rootDir  string
+	result   persistence.Manifest
+	loaded   map[string]struct{}
+	projects map[string]string
+	groups   map[string]int
+	envs     map[string]string
+	accounts map[string]string
+}
+
+// resolveIncludes loads all manifests included by the given root manifest, recursively, and merges their projects,
+// environment groups and accounts into it. Paths of includes may be glob patterns and are relative to the including
+// manifest, as are the paths of projects defined in included manifests.
+//
+// Projects, environments and accounts must be defined only once. Environment groups may be defined in multiple
+// manifests, their environments are merged.
+func resolveIncludes(fs afero.Fs, rootPath string, root persistence.Manifest) (persistence.Manifest, error) {
+	if len(root.Includes) == 0 {
+		return root, nil
+	}
+
+	m := newIncludedManifests(fs, rootPath, root)
+	if err := m.add(filepath.Clean(rootPath), root); err != nil {
+		return persistence.Manifest{}, err
+	}
+
+	return m.result, nil
+}
+
+func newIncludedManifests(fs afero.Fs, rootPath string, root persistence.Manifest) *includedManifests {
+	return &includedManifests{
+		fs:       fs,
+		rootDir:  filepath.Dir(filepath.Clean(rootPath)),
+		result:   persistence.Manifest{ManifestVersion: root.ManifestVersion},
+		loaded:   make(map[string]struct{}),
+		projects: make(map[string]string),
+		groups:   make(map[string]int),
+		envs:     make(map[string]string),
+		accounts: make(map[string]string),
+	}
+}
+
+func (m *includedManifests) add(path string, definition persistence.Manifest) error {
+	m.loaded[path] = struct{}{}
+
+	relDir, err := filepath.Rel(m.rootDir, filepath.Dir(path))
+	if err != nil {
+		return newManifestLoaderError(path, fmt.Sprintf("failed to resolve path relative to the root manifest: %s", err))
+	}
+
+	for _, p := range definition.Projects {
+		if other, found := m.projects[p.Name]; found {
+			return newManifestLoaderError(path, fmt.Sprintf("project `%s` is already defined in %q", p.Name, other))
+		}
+		m.projects[p.Name] = path
+
+		if relDir != "." {
+			p.Path = rebaseProjectPath(p, relDir)
+		}
+		m.result.Projects = append(m.result.Projects, p)
+	}
+
+	for _, g := range definition.EnvironmentGroups {
+		for _, e := range g.Environments {
+			if other, found := m.envs[e.Name]; found {
+				return newManifestLoaderError(path, fmt.Sprintf("environment `%s` is already defined in %q", e.Name, other))
+			}
+			m.envs[e.Name] = path
+		}
+
+		if i, found := m.groups[g.Name]; found {
+			existing := &m.result.EnvironmentGroups[i]
+			for name, value := range g.Variables {
+				if other, defined := existing.Variables[name]; defined && other != value {
+					return newManifestLoaderError(path, fmt.Sprintf("variable `%s` of group `%s` is already defined with a different value", name, g.Name))
+				}
+				if existing.Variables == nil {
+					existing.Variables = make(map[string]string, len(g.Variables))
+				}
+				existing.Variables[name] = value
+			}
+			if g.Limits != nil {
+				if existing.Limits != nil && !reflect.DeepEqual(existing.Limits, g.Limits) {
+					return newManifestLoaderError(path, fmt.Sprintf("limits of group `%s` are already defined with different values", g.Name))
+				}
+				existing.Limits = g.Limits
+			}
+			existing.Environments = append(existing.Environments, g.Environments...)
+			continue
+		}
+		m.groups[g.Name] = len(m.result.EnvironmentGroups)
+		m.result.EnvironmentGroups = append(m.result.EnvironmentGroups, g)
+	}
+
+	for _, a := range definition.Accounts {
+		if other, found := m.accounts[a.Name]; found {
+			return newManifestLoaderError(path, fmt.Sprintf("account `%s` is already defined in %q", a.Name, other))
+		}
+		m.accounts[a.Name] = path
+		m.result.Accounts = append(m.result.Accounts, a)
+	}
+
+	for _, include := range definition.Includes {
+		includedPaths, err := m.resolveIncludePaths(path, include)
+		if err != nil {
+			return err
+		}
+
+		for _, includedPath := range includedPaths {
+			if _, found := m.loaded[includedPath]; found {
+				// patterns may match manifests that are already loaded, such as the including one
+				if isIncludePattern(include) {
+					continue
+				}
+				return newManifestLoaderError(path, fmt.Sprintf("manifest %q is included more than once", includedPath))
+			}
+
+			included, err := readIncludedManifest(m.fs, includedPath)
+			if err != nil {
+				return err
+			}
+
+			if err := m.add(includedPath, included); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveIncludePaths returns the paths of all files matching the given include of the manifest at the given path.
+func (m *includedManifests) resolveIncludePaths(path string, include string) ([]string, error) {
+	pattern := filepath.Join(filepath.Dir(path), filepath.FromSlash(include))
+
+	if !isIncludePattern(include) {
+		if exists, err := files.DoesFileExist(m.fs, pattern); err != nil {
+			return nil, newManifestLoaderError(path, fmt.Sprintf("failed to read included manifest %q: %s", include, err))
+		} else if !exists {
+			return nil, newManifestLoaderError(path, fmt.Sprintf("included manifest %q does not exist", include))
+		}
+		return []string{pattern}, nil
+	}
+
+	matches, err := afero.Glob(m.fs, pattern)
+	if err != nil {
+		return nil, newManifestLoaderError(path, fmt.Sprintf("invalid include pattern %q: %s", include, err))
+	}
+	if len(matches) == 0 {
+		return nil, newManifestLoaderError(path, fmt.Sprintf("include pattern %q does not match any file", include))
+	}
+
+	var result []string
+	for _, match := range matches {
+		if files.IsYamlFileExtension(match) {
+			result = append(result, filepath.Clean(match))
+		}
+	}
+	return result, nil
+}
+
+func isIncludePattern(include string) bool {
+	return strings.ContainsAny(include, "*?[")
+}
+
+// readIncludedManifest reads a manifest included by another one. Unlike the root manifest, included manifests do not
+// need to define a manifestVersion.
+func readIncludedManifest(fs afero.Fs, path string) (persistence.Manifest, error) {
+	if !files.IsYamlFileExtension(path) {
+		return persistence.Manifest{}, newManifestLoaderError(path, "included manifest file is not a yaml")
+	}
+
+	rawData, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return persistence.Manifest{}, newManifestLoaderError(path, fmt.Sprintf("error while reading the manifest: %s", err))
+	}
+
+	var m persistence.Manifest
+	if err := yaml.UnmarshalStrict(rawData, &m); err != nil {
+		return persistence.Manifest{}, newManifestLoaderError(path, fmt.Sprintf("error during parsing the manifest: %s", err))
+	}
+
+	if m.ManifestVersion != "" {
+		if err := validateVersion(m); err != nil {
+			return persistence.Manifest{}, newManifestLoaderError(path, fmt.Sprintf("invalid manifest definition: %s", err))
+		}
+	}
+
+	return m, nil
+}
+
+// rebaseProjectPath returns the path of the project defined in an included manifest relative to the root manifest.
+func rebaseProjectPath(p persistence.Project, relDir string) string {
+	path := p.Path
+	if path == "" && (p.Type == "" || p.Type == persistence.SimpleProjectType) {
+		path = p.Name
+	}
+	return filepath.ToSlash(filepath.Join(relDir, filepath.FromSlash(path)))
+}
@@ -0,0 +1,58 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"fmt"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+// parseLimits returns the limits of the group, overridden by each limit the environment defines.
+func parseLimits(group, environment *persistence.Limits) (manifest.Limits, error) {
+	var result manifest.Limits
+
+	for _, l := range []*persistence.Limits{group, environment} {
+		if l == nil {
+			continue
+		}
+
+		if l.ConcurrentRequests != nil {
+			if *l.ConcurrentRequests <= 0 {
+				return manifest.Limits{}, fmt.Errorf("'concurrentRequests' must be greater than 0, but is %d", *l.ConcurrentRequests)
+			}
+			result.ConcurrentRequests = *l.ConcurrentRequests
+		}
+
+		if l.RequestsPerSecond != nil {
+			if *l.RequestsPerSecond <= 0 {
+				return manifest.Limits{}, fmt.Errorf("'requestsPerSecond' must be greater than 0, but is %v", *l.RequestsPerSecond)
+			}
+			result.RequestsPerSecond = *l.RequestsPerSecond
+		}
+
+		if l.ConcurrentDeployments != nil {
+			if *l.ConcurrentDeployments <= 0 {
+				return manifest.Limits{}, fmt.Errorf("'concurrentDeployments' must be greater than 0, but is %d", *l.ConcurrentDeployments)
+			}
+			result.ConcurrentDeployments = *l.ConcurrentDeployments
+		}
+	}
+
+	return result, nil
+}
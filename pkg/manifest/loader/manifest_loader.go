@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -160,6 +161,10 @@ func Load(context *Context) (manifest.Manifest, []error) {
 		return manifest.Manifest{}, []error{newManifestLoaderError(context.ManifestPath, fmt.Sprintf("invalid manifest definition: %s", err))}
 	}
 
+	if manifestYAML, err = resolveIncludes(context.Fs, context.ManifestPath, manifestYAML); err != nil {
+		return manifest.Manifest{}, []error{err}
+	}
+
 	var errs []error
 	var environments manifest.Environments
 
@@ -266,10 +271,19 @@ func validateAuthInput(a persistence.Auth) error {
 }
 
 func parseAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
-	if s.Type != persistence.TypeEnvironment && s.Type != "" {
-		return manifest.AuthSecret{}, errors.New("type must be 'environment'")
+	switch s.Type {
+	case persistence.TypeEnvironment, "":
+		return parseEnvironmentAuthSecret(context, s)
+	case persistence.TypeFile:
+		return parseFileAuthSecret(context, s)
+	case persistence.TypeExec:
+		return parseExecAuthSecret(context, s)
+	default:
+		return manifest.AuthSecret{}, errors.New("type must be one of 'environment', 'file' or 'exec'")
 	}
+}
 
+func parseEnvironmentAuthSecret(context *Context, s *persistence.AuthSecret) (manifest.AuthSecret, error) {
 	if s.Name == "" {
 		return manifest.AuthSecret{}, errors.New("no name given or empty")
 	}
@@ -422,7 +436,7 @@ func parseEnvironments(context *Context, groups []persistence.Group) (manifest.E
 				continue
 			}
 
-			parsedEnv, configErrors := parseSingleEnvironment(context, env, group.Name)
+			parsedEnv, configErrors := parseSingleEnvironment(context, env, group)
 
 			if configErrors != nil {
 				errors = append(errors, configErrors...)
@@ -474,8 +488,9 @@ func shouldSkipEnv(context *Context, group persistence.Group, env persistence.En
 	return true
 }
 
-func parseSingleEnvironment(context *Context, config persistence.Environment, group string) (manifest.EnvironmentDefinition, []error) {
+func parseSingleEnvironment(context *Context, config persistence.Environment, g persistence.Group) (manifest.EnvironmentDefinition, []error) {
 	var errs []error
+	group := g.Name
 
 	a, err := parseAuth(context, config.Auth)
 	if err != nil {
@@ -487,18 +502,45 @@ func parseSingleEnvironment(context *Context, config persistence.Environment, gr
 		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, err.Error()))
 	}
 
+	connection, err := parseConnection(context, config.Connection)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("failed to parse connection section: %s", err)))
+	}
+
+	limits, err := parseLimits(g.Limits, config.Limits)
+	if err != nil {
+		errs = append(errs, newManifestEnvironmentLoaderError(context.ManifestPath, group, config.Name, fmt.Sprintf("failed to parse limits: %s", err)))
+	}
+
 	if len(errs) > 0 {
 		return manifest.EnvironmentDefinition{}, errs
 	}
 
 	return manifest.EnvironmentDefinition{
-		Name:  config.Name,
-		URL:   urlDef,
-		Auth:  a,
-		Group: group,
+		Name:       config.Name,
+		URL:        urlDef,
+		Auth:       a,
+		Group:      group,
+		Labels:     config.Labels,
+		Variables:  mergeVariables(g.Variables, config.Variables),
+		Connection: connection,
+		Limits:     limits,
 	}, nil
 }
 
+// mergeVariables returns the variables of the group overridden by the variables of the environment.
+// If neither defines any variables, nil is returned.
+func mergeVariables(group, environment map[string]string) map[string]string {
+	if len(group) == 0 && len(environment) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(group)+len(environment))
+	maps.Copy(result, group)
+	maps.Copy(result, environment)
+	return result
+}
+
 func parseURLDefinition(context *Context, u persistence.TypedValue) (manifest.URLDefinition, error) {
 
 	// Depending on the type, the url.value either contains the env var name or the direct value of the url
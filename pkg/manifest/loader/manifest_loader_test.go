@@ -19,6 +19,7 @@
 package loader
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -838,6 +839,113 @@ environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {t
 				Accounts: map[string]manifest.Account{},
 			},
 		},
+		{
+			name: "Group and environment variables are merged",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups:
+- name: b
+  variables: {region: eu-west, slackChannel: C000}
+  environments:
+  - {name: c, url: {value: d}, auth: {token: {name: e}}, variables: {slackChannel: C123}}
+  - {name: f, url: {value: g}, auth: {token: {name: e}}}
+`,
+			errsContain: []string{},
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {
+						Name: "a",
+						Path: "p",
+					},
+				},
+				Environments: manifest.Environments{
+					SelectedEnvironments: map[string]manifest.EnvironmentDefinition{
+						"c": {
+							Name: "c",
+							URL: manifest.URLDefinition{
+								Type:  manifest.ValueURLType,
+								Value: "d",
+							},
+							Group: "b",
+							Auth: manifest.Auth{
+								AccessToken: &manifest.AuthSecret{
+									Name:  "e",
+									Value: "mock token",
+								},
+							},
+							Variables: map[string]string{"region": "eu-west", "slackChannel": "C123"},
+						},
+						"f": {
+							Name: "f",
+							URL: manifest.URLDefinition{
+								Type:  manifest.ValueURLType,
+								Value: "g",
+							},
+							Group: "b",
+							Auth: manifest.Auth{
+								AccessToken: &manifest.AuthSecret{
+									Name:  "e",
+									Value: "mock token",
+								},
+							},
+							Variables: map[string]string{"region": "eu-west", "slackChannel": "C000"},
+						},
+					},
+					AllEnvironmentNames: map[string]struct{}{
+						"c": {},
+						"f": {},
+					},
+					AllGroupNames: map[string]struct{}{
+						"b": {},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+			},
+		},
+		{
+			name: "Environment labels are loaded",
+			manifestContent: `
+manifestVersion: 1.0
+projects: [{name: a, path: p}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e}}, labels: {region: eu, tier: prod}}]}]
+`,
+			errsContain: []string{},
+			expectedManifest: manifest.Manifest{
+				Projects: map[string]manifest.ProjectDefinition{
+					"a": {
+						Name: "a",
+						Path: "p",
+					},
+				},
+				Environments: manifest.Environments{
+					SelectedEnvironments: map[string]manifest.EnvironmentDefinition{
+						"c": {
+							Name: "c",
+							URL: manifest.URLDefinition{
+								Type:  manifest.ValueURLType,
+								Value: "d",
+							},
+							Group: "b",
+							Auth: manifest.Auth{
+								AccessToken: &manifest.AuthSecret{
+									Name:  "e",
+									Value: "mock token",
+								},
+							},
+							Labels: map[string]string{"region": "eu", "tier": "prod"},
+						},
+					},
+					AllEnvironmentNames: map[string]struct{}{
+						"c": {},
+					},
+					AllGroupNames: map[string]struct{}{
+						"b": {},
+					},
+				},
+				Accounts: map[string]manifest.Account{},
+			},
+		},
 		{
 			name: "Everything good with multiple environments in multiple groups",
 			manifestContent: `
@@ -1493,7 +1601,7 @@ manifestVersion: 1.0
 projects: [{name: a}]
 environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {name: e, type: f}}} ]}]
 `,
-			errsContain: []string{"type must be 'environment'"},
+			errsContain: []string{"type must be one of 'environment', 'file' or 'exec'"},
 		},
 		{
 			name: "Empty token and no oauth",
@@ -1853,7 +1961,7 @@ manifestVersion: 1.0
 projects: [{name: a, path: p}]
 environmentGroups: [{name: b, environments: [{name: c, url: {value: d}, auth: {token: {type: x}}}]}]
 `,
-			errsContain: []string{"type must be 'environment'"},
+			errsContain: []string{"type must be one of 'environment', 'file' or 'exec'"},
 		},
 		{
 			name: "load url from env var",
@@ -2399,3 +2507,182 @@ projects: [{name: a, path: p}]
 		})
 	}
 }
+
+func TestLoadManifest_Includes(t *testing.T) {
+	t.Setenv("TOKEN", "token")
+
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: shared}]
+environmentGroups:
+- name: dev
+  environments:
+  - name: dev-1
+    url: {value: "https://dev-1.dynatrace.com"}
+    auth: {token: {name: TOKEN}}
+includes:
+- teams/*.yaml
+`), 0400))
+	assert.NoError(t, afero.WriteFile(fs, "teams/team-a.yaml", []byte(`
+projects: [{name: team-a}, {name: team-a-alerting, path: alerting}]
+environmentGroups:
+- name: dev
+  environments:
+  - name: dev-2
+    url: {value: "https://dev-2.dynatrace.com"}
+    auth: {token: {name: TOKEN}}
+includes: [prod/environments.yaml]
+`), 0400))
+	assert.NoError(t, afero.WriteFile(fs, "teams/prod/environments.yaml", []byte(`
+environmentGroups:
+- name: prod
+  environments:
+  - name: prod-1
+    url: {value: "https://prod-1.dynatrace.com"}
+    auth: {token: {name: TOKEN}}
+`), 0400))
+	assert.NoError(t, afero.WriteFile(fs, "teams/README.md", []byte("not a manifest"), 0400))
+
+	got, errs := Load(&Context{
+		Fs:           fs,
+		ManifestPath: "manifest.yaml",
+		Opts:         Options{RequireEnvironmentGroups: true},
+	})
+	assert.Empty(t, errs)
+
+	assert.Equal(t, manifest.ProjectDefinitionByProjectID{
+		"shared":          {Name: "shared", Path: "shared"},
+		"team-a":          {Name: "team-a", Path: "teams/team-a"},
+		"team-a-alerting": {Name: "team-a-alerting", Path: "teams/alerting"},
+	}, got.Projects, "project paths must be relative to the included manifest")
+
+	assert.Len(t, got.Environments.SelectedEnvironments, 3)
+	assert.Equal(t, "dev", got.Environments.SelectedEnvironments["dev-2"].Group)
+	assert.Equal(t, "prod", got.Environments.SelectedEnvironments["prod-1"].Group)
+
+	referenced, err := LoadReferencedFiles(fs, "manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"teams/prod/environments.yaml", "teams/team-a.yaml"}, referenced.Manifests)
+}
+
+func TestLoadManifest_Limits(t *testing.T) {
+	t.Setenv("TOKEN", "token")
+
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups:
+- name: managed
+  limits: {concurrentRequests: 2, concurrentDeployments: 1}
+  environments:
+  - {name: small, url: {value: 'https://a.dynatrace.com'}, auth: {token: {name: TOKEN}}, limits: {concurrentRequests: 1, requestsPerSecond: 0.5}}
+  - {name: medium, url: {value: 'https://b.dynatrace.com'}, auth: {token: {name: TOKEN}}}
+- name: saas
+  environments:
+  - {name: large, url: {value: 'https://c.dynatrace.com'}, auth: {token: {name: TOKEN}}}
+`), 0400))
+
+	m, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Opts: Options{RequireEnvironmentGroups: true}})
+	require.Empty(t, errs)
+
+	assert.Equal(t, manifest.Limits{ConcurrentRequests: 1, RequestsPerSecond: 0.5, ConcurrentDeployments: 1}, m.Environments.SelectedEnvironments["small"].Limits)
+	assert.Equal(t, manifest.Limits{ConcurrentRequests: 2, ConcurrentDeployments: 1}, m.Environments.SelectedEnvironments["medium"].Limits)
+	assert.Equal(t, manifest.Limits{}, m.Environments.SelectedEnvironments["large"].Limits)
+}
+
+func TestLoadManifest_InvalidLimits(t *testing.T) {
+	t.Setenv("TOKEN", "token")
+
+	tests := []struct {
+		name              string
+		limits            string
+		wantErrorContains string
+	}{
+		{"concurrent requests", "{concurrentRequests: 0}", "'concurrentRequests' must be greater than 0, but is 0"},
+		{"requests per second", "{requestsPerSecond: -1}", "'requestsPerSecond' must be greater than 0, but is -1"},
+		{"concurrent deployments", "{concurrentDeployments: -2}", "'concurrentDeployments' must be greater than 0, but is -2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := afero.NewMemMapFs()
+			require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(`
+manifestVersion: 1.0
+projects: [{name: a}]
+environmentGroups: [{name: b, environments: [{name: c, url: {value: 'https://a.dynatrace.com'}, auth: {token: {name: TOKEN}}, limits: `+tt.limits+`}]}]
+`), 0400))
+
+			_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml", Opts: Options{RequireEnvironmentGroups: true}})
+			assert.ErrorContains(t, errors.Join(errs...), tt.wantErrorContains)
+		})
+	}
+}
+
+func TestLoadManifest_IncludeErrors(t *testing.T) {
+	tests := []struct {
+		name              string
+		manifest          string
+		included          string
+		wantErrorContains string
+	}{
+		{
+			name:              "duplicate project",
+			manifest:          "projects: [{name: a}]",
+			included:          "projects: [{name: a}]",
+			wantErrorContains: "project `a` is already defined in \"manifest.yaml\"",
+		},
+		{
+			name:              "duplicate environment",
+			manifest:          "environmentGroups: [{name: dev, environments: [{name: env, url: {value: 'https://a.dynatrace.com'}, auth: {token: {name: TOKEN}}}]}]",
+			included:          "environmentGroups: [{name: prod, environments: [{name: env, url: {value: 'https://b.dynatrace.com'}, auth: {token: {name: TOKEN}}}]}]",
+			wantErrorContains: "environment `env` is already defined in \"manifest.yaml\"",
+		},
+		{
+			name:              "conflicting group variable",
+			manifest:          "environmentGroups: [{name: dev, variables: {region: eu}, environments: [{name: a, url: {value: 'https://a.dynatrace.com'}, auth: {token: {name: TOKEN}}}]}]",
+			included:          "environmentGroups: [{name: dev, variables: {region: us}, environments: [{name: b, url: {value: 'https://b.dynatrace.com'}, auth: {token: {name: TOKEN}}}]}]",
+			wantErrorContains: "variable `region` of group `dev` is already defined with a different value",
+		},
+		{
+			name:              "conflicting group limits",
+			manifest:          "environmentGroups: [{name: dev, limits: {concurrentRequests: 1}, environments: [{name: a, url: {value: 'https://a.dynatrace.com'}, auth: {token: {name: TOKEN}}}]}]",
+			included:          "environmentGroups: [{name: dev, limits: {concurrentRequests: 2}, environments: [{name: b, url: {value: 'https://b.dynatrace.com'}, auth: {token: {name: TOKEN}}}]}]",
+			wantErrorContains: "limits of group `dev` are already defined with different values",
+		},
+		{
+			name:              "include cycle",
+			manifest:          "projects: [{name: a}]",
+			included:          "includes: [manifest.yaml]",
+			wantErrorContains: "manifest \"manifest.yaml\" is included more than once",
+		},
+		{
+			name:              "unknown fields",
+			manifest:          "projects: [{name: a}]",
+			included:          "project: [{name: b}]",
+			wantErrorContains: "field project not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TOKEN", "token")
+
+			fs := afero.NewMemMapFs()
+			assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte("manifestVersion: 1.0\nincludes: [included.yaml]\n"+tt.manifest), 0400))
+			assert.NoError(t, afero.WriteFile(fs, "included.yaml", []byte(tt.included), 0400))
+
+			_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+			assert.ErrorContains(t, errors.Join(errs...), tt.wantErrorContains)
+		})
+	}
+
+	t.Run("missing included manifest", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		assert.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte("manifestVersion: 1.0\nincludes: [missing.yaml]"), 0400))
+
+		_, errs := Load(&Context{Fs: fs, ManifestPath: "manifest.yaml"})
+		assert.ErrorContains(t, errors.Join(errs...), "included manifest \"missing.yaml\" does not exist")
+	})
+}
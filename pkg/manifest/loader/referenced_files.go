@@ -0,0 +1,91 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loader
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/internal/persistence"
+)
+
+// ReferencedFiles are the files a manifest refers to, besides the files of its projects.
+type ReferencedFiles struct {
+	// Manifests are the paths of all manifests included by the manifest, recursively.
+	Manifests []string
+	// Certificates are the paths of the CA bundles and client certificates of all connections, as defined in the
+	// manifests. Relative paths are relative to the including manifest.
+	Certificates []string
+	// Secrets are the paths of all secret files and the client keys of all connections, as defined in the manifests.
+	// Relative paths are relative to the including manifest.
+	Secrets []string
+}
+
+// LoadReferencedFiles returns the files referenced by the manifest at the given path and the manifests it includes.
+// Files are not read, so they do not need to exist.
+func LoadReferencedFiles(fs afero.Fs, manifestPath string) (ReferencedFiles, error) {
+	rootPath := filepath.Clean(manifestPath)
+	root, err := readIncludedManifest(fs, rootPath)
+	if err != nil {
+		return ReferencedFiles{}, err
+	}
+
+	m := newIncludedManifests(fs, rootPath, root)
+	if err := m.add(rootPath, root); err != nil {
+		return ReferencedFiles{}, err
+	}
+	delete(m.loaded, rootPath)
+
+	result := ReferencedFiles{Manifests: slices.Sorted(maps.Keys(m.loaded))}
+	add := func(files []string, path string) []string {
+		if path == "" || slices.Contains(files, path) {
+			return files
+		}
+		return append(files, path)
+	}
+	addSecret := func(s *persistence.AuthSecret) {
+		if s != nil && s.Type == persistence.TypeFile {
+			result.Secrets = add(result.Secrets, s.Path)
+		}
+	}
+
+	for _, g := range m.result.EnvironmentGroups {
+		for _, e := range g.Environments {
+			addSecret(e.Auth.AccessToken)
+			addSecret(e.Auth.PlatformToken)
+			if e.Auth.OAuth != nil {
+				addSecret(&e.Auth.OAuth.ClientID)
+				addSecret(&e.Auth.OAuth.ClientSecret)
+			}
+
+			if c := e.Connection; c != nil {
+				result.Certificates = add(result.Certificates, c.CABundle)
+				result.Certificates = add(result.Certificates, c.ClientCertificate)
+				result.Secrets = add(result.Secrets, c.ClientKey)
+			}
+		}
+	}
+	for _, a := range m.result.Accounts {
+		addSecret(&a.OAuth.ClientID)
+		addSecret(&a.OAuth.ClientSecret)
+	}
+
+	return result, nil
+}
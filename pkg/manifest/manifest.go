@@ -70,6 +70,49 @@ type EnvironmentDefinition struct {
 	Group string
 	URL   URLDefinition
 	Auth  Auth
+	// Labels are arbitrary key-value pairs describing the environment, which can be used to match overrides
+	Labels map[string]string
+	// Variables are arbitrary values made available to configs deployed to the environment.
+	// They contain the variables of the environment's group, overridden by the ones of the environment itself.
+	Variables map[string]string
+	// Connection holds optional settings for the HTTP connection to the environment. It is nil if none are defined.
+	Connection *Connection
+	// Limits holds the limits for the requests sent to the environment. They contain the limits of the environment's
+	// group, overridden by the ones of the environment itself.
+	Limits Limits
+}
+
+// Limits holds limits for the requests sent to an environment.
+// A value of 0 means that the limit is not defined in the manifest and the respective environment variable applies.
+type Limits struct {
+	// ConcurrentRequests is the maximum number of concurrent requests.
+	ConcurrentRequests int
+	// RequestsPerSecond is the maximum number of requests started per second.
+	RequestsPerSecond float64
+	// ConcurrentDeployments is the maximum number of configurations deployed concurrently.
+	ConcurrentDeployments int
+}
+
+// Connection holds optional settings for the HTTP connection to an environment.
+type Connection struct {
+	// CABundle holds certificates trusted in addition to the system's certificates.
+	CABundle *PEMFile
+	// ClientCertificate holds the certificate used for mutual TLS. It is only set together with ClientKey.
+	ClientCertificate *PEMFile
+	// ClientKey holds the private key of the ClientCertificate.
+	ClientKey *PEMFile
+	// Proxy is the URL of the proxy to connect through. If empty, the proxy environment variables are used.
+	Proxy string
+	// Headers are additional HTTP headers sent with every request to the environment.
+	Headers map[string]string
+}
+
+// PEMFile is a file holding PEM encoded certificates or keys.
+type PEMFile struct {
+	// Path is the path of the file as defined in the manifest.
+	Path string
+	// Content is the content of the file, read during manifest loading.
+	Content secret.MaskedString
 }
 
 func (e EnvironmentDefinition) HasPlatformCredentials() bool {
@@ -102,13 +145,38 @@ type URLDefinition struct {
 	Value string
 }
 
+// AuthSecretType describes from where an [AuthSecret] is loaded.
+// Possible values are [EnvironmentAuthSecretType], [FileAuthSecretType] and [ExecAuthSecretType].
+// [EnvironmentAuthSecretType] is the default value.
+type AuthSecretType int
+
+const (
+	// EnvironmentAuthSecretType describes that the secret has been loaded from an environment variable
+	EnvironmentAuthSecretType AuthSecretType = iota
+
+	// FileAuthSecretType describes that the secret has been read from a file
+	FileAuthSecretType
+
+	// ExecAuthSecretType describes that the secret has been printed by a credential-helper command
+	ExecAuthSecretType
+)
+
 // AuthSecret contains a resolved secret value. It is used for the access token, ClientID, and ClientSecret.
 type AuthSecret struct {
-	// Name is the name of the environment-variable of the token.
+	// Type defines from where the [AuthSecret.Value] has been loaded.
+	Type AuthSecretType
+
+	// Name is the name of the environment-variable of the token. It only has a value if [AuthSecret.Type] is "[EnvironmentAuthSecretType]".
 	// It is used in download to store the name of the OAuth token in the new created manifest.
 	Name string
 
-	// Value holds the actual token value for the given [AuthSecret.Name].
+	// Path is the file the token is read from. It only has a value if [AuthSecret.Type] is "[FileAuthSecretType]".
+	Path string
+
+	// Command is the credential-helper command printing the token. It only has a value if [AuthSecret.Type] is "[ExecAuthSecretType]".
+	Command []string
+
+	// Value holds the actual token value.
 	Value secret.MaskedString
 }
 
@@ -142,9 +142,13 @@ func toWriteableEnvironmentGroups(environments manifest.Environments) (result []
 
 	for name, env := range environments.SelectedEnvironments {
 		e := persistence.Environment{
-			Name: name,
-			URL:  toWriteableURL(env.URL),
-			Auth: getAuth(env),
+			Name:       name,
+			URL:        toWriteableURL(env.URL),
+			Auth:       getAuth(env),
+			Labels:     env.Labels,
+			Variables:  env.Variables,
+			Connection: toWriteableConnection(env.Connection),
+			Limits:     toWriteableLimits(env.Limits),
 		}
 
 		environmentPerGroup[env.Group] = append(environmentPerGroup[env.Group], e)
@@ -157,6 +161,45 @@ func toWriteableEnvironmentGroups(environments manifest.Environments) (result []
 	return result
 }
 
+func toWriteableConnection(c *manifest.Connection) *persistence.Connection {
+	if c == nil {
+		return nil
+	}
+
+	path := func(f *manifest.PEMFile) string {
+		if f == nil {
+			return ""
+		}
+		return f.Path
+	}
+
+	return &persistence.Connection{
+		CABundle:          path(c.CABundle),
+		ClientCertificate: path(c.ClientCertificate),
+		ClientKey:         path(c.ClientKey),
+		Proxy:             c.Proxy,
+		Headers:           c.Headers,
+	}
+}
+
+func toWriteableLimits(l manifest.Limits) *persistence.Limits {
+	if l == (manifest.Limits{}) {
+		return nil
+	}
+
+	var result persistence.Limits
+	if l.ConcurrentRequests > 0 {
+		result.ConcurrentRequests = &l.ConcurrentRequests
+	}
+	if l.RequestsPerSecond > 0 {
+		result.RequestsPerSecond = &l.RequestsPerSecond
+	}
+	if l.ConcurrentDeployments > 0 {
+		result.ConcurrentDeployments = &l.ConcurrentDeployments
+	}
+	return &result
+}
+
 func getAuth(env manifest.EnvironmentDefinition) persistence.Auth {
 	return persistence.Auth{
 		AccessToken:   getAuthSecret(env.Auth.AccessToken),
@@ -183,9 +226,18 @@ func getAuthSecret(secret *manifest.AuthSecret) *persistence.AuthSecret {
 		return nil
 	}
 
-	return &persistence.AuthSecret{
-		Type: persistence.TypeEnvironment,
-		Name: secret.Name,
+	s := toWriteableAuthSecret(*secret)
+	return &s
+}
+
+func toWriteableAuthSecret(secret manifest.AuthSecret) persistence.AuthSecret {
+	switch secret.Type {
+	case manifest.FileAuthSecretType:
+		return persistence.AuthSecret{Type: persistence.TypeFile, Path: secret.Path}
+	case manifest.ExecAuthSecretType:
+		return persistence.AuthSecret{Type: persistence.TypeExec, Command: secret.Command}
+	default:
+		return persistence.AuthSecret{Type: persistence.TypeEnvironment, Name: secret.Name}
 	}
 }
 
@@ -201,14 +253,8 @@ func getOAuthCredentials(a *manifest.OAuth) *persistence.OAuth {
 	}
 
 	return &persistence.OAuth{
-		ClientID: persistence.AuthSecret{
-			Type: persistence.TypeEnvironment,
-			Name: a.ClientID.Name,
-		},
-		ClientSecret: persistence.AuthSecret{
-			Type: persistence.TypeEnvironment,
-			Name: a.ClientSecret.Name,
-		},
+		ClientID:      toWriteableAuthSecret(a.ClientID),
+		ClientSecret:  toWriteableAuthSecret(a.ClientSecret),
 		TokenEndpoint: te,
 	}
 }
@@ -224,14 +270,8 @@ func toWriteableAccounts(accounts map[string]manifest.Account) []persistence.Acc
 		}
 
 		oauth := persistence.OAuth{
-			ClientID: persistence.AuthSecret{
-				Type: persistence.TypeEnvironment,
-				Name: account.OAuth.ClientID.Name,
-			},
-			ClientSecret: persistence.AuthSecret{
-				Type: persistence.TypeEnvironment,
-				Name: account.OAuth.ClientSecret.Name,
-			},
+			ClientID:     toWriteableAuthSecret(account.OAuth.ClientID),
+			ClientSecret: toWriteableAuthSecret(account.OAuth.ClientSecret),
 		}
 		if account.OAuth.TokenEndpoint != nil {
 			url := toWriteableURL(*account.OAuth.TokenEndpoint)
@@ -385,6 +385,33 @@ func Test_toWriteableEnvironmentGroups(t *testing.T) {
 	}
 }
 
+func Test_toWriteableConnection(t *testing.T) {
+	assert.Nil(t, toWriteableConnection(nil))
+
+	got := toWriteableConnection(&manifest.Connection{
+		CABundle:          &manifest.PEMFile{Path: "certs/ca.pem", Content: "ca"},
+		ClientCertificate: &manifest.PEMFile{Path: "certs/client.pem", Content: "cert"},
+		ClientKey:         &manifest.PEMFile{Path: "certs/client.key", Content: "key"},
+		Proxy:             "http://proxy:3128",
+		Headers:           map[string]string{"X-Tenant": "managed"},
+	})
+	assert.Equal(t, &persistence.Connection{
+		CABundle:          "certs/ca.pem",
+		ClientCertificate: "certs/client.pem",
+		ClientKey:         "certs/client.key",
+		Proxy:             "http://proxy:3128",
+		Headers:           map[string]string{"X-Tenant": "managed"},
+	}, got)
+}
+
+func Test_toWriteableLimits(t *testing.T) {
+	assert.Nil(t, toWriteableLimits(manifest.Limits{}))
+
+	concurrentRequests, requestsPerSecond := 2, 0.5
+	assert.Equal(t, &persistence.Limits{ConcurrentRequests: &concurrentRequests, RequestsPerSecond: &requestsPerSecond},
+		toWriteableLimits(manifest.Limits{ConcurrentRequests: 2, RequestsPerSecond: 0.5}))
+}
+
 func Test_toWriteableUrl(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -441,6 +468,14 @@ func Test_toWritableToken(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func Test_toWritableToken_FileAndExec(t *testing.T) {
+	got := getAuthSecret(&manifest.AuthSecret{Type: manifest.FileAuthSecretType, Path: "/var/run/secrets/token", Value: "secret"})
+	assert.Equal(t, &persistence.AuthSecret{Type: "file", Path: "/var/run/secrets/token"}, got)
+
+	got = getAuthSecret(&manifest.AuthSecret{Type: manifest.ExecAuthSecretType, Command: []string{"broker", "token"}, Value: "secret"})
+	assert.Equal(t, &persistence.AuthSecret{Type: "exec", Command: []string{"broker", "token"}}, got)
+}
+
 func Test_toWritableNilToken(t *testing.T) {
 	got := getAuthSecret(nil)
 	assert.Nil(t, got)
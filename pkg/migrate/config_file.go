@@ -0,0 +1,223 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/configfile"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+)
+
+// shorthandTypes are the types whose shorthand definition is the latest format.
+var shorthandTypes = []string{"bucket", "segment", "slo-v2"}
+
+// configFile is a config file of a project that is migrated.
+type configFile struct {
+	*configfile.File
+}
+
+// migrateTypes replaces shorthand type definitions of classic APIs by full ones, and converts configs of deprecated
+// classic APIs to settings. The coordinates of converted configs are added to converted, mapped to their new type.
+// Templates shared by several converted configs are only converted once, convertedTemplates holds their paths.
+func (f *configFile) migrateTypes(changes *changeSet, report *Report, converted map[coordinate.Coordinate]string, convertedTemplates map[string]struct{}) {
+	apis := api.NewAPIs()
+
+	for _, c := range f.Configs() {
+		id, _ := configfile.Get(c, "id")
+		t, _ := configfile.Get(c, "type")
+
+		var apiID string
+		switch v := t.(type) {
+		case string:
+			if slices.Contains(shorthandTypes, v) {
+				continue
+			}
+			apiID = v
+			configfile.Set(c, "type", yaml.MapSlice{{Key: "api", Value: apiID}})
+			f.Changed = true
+			report.migrated(f.Path, f.coordinate(apiID, id), "replaced shorthand type definition %q by 'api: %s'", apiID, apiID)
+		case yaml.MapSlice:
+			a, _ := configfile.Get(v, "api")
+			apiID, _ = a.(string)
+		}
+
+		theAPI, found := apis[apiID]
+		if !found || theAPI.DeprecatedBy == "" {
+			continue
+		}
+
+		convert, found := settingsConverters[apiID]
+		if !found {
+			report.notMigrated(f.Path, f.coordinate(apiID, id), "API %q is deprecated by %q, but can not be migrated automatically - please migrate the config manually", apiID, theAPI.DeprecatedBy)
+			continue
+		}
+
+		if err := convertTemplates(changes, filepath.Dir(f.Path), configfile.TemplatesOf(c), convert, convertedTemplates); err != nil {
+			report.notMigrated(f.Path, f.coordinate(apiID, id), "failed to migrate config of deprecated API %q to %q: %s", apiID, theAPI.DeprecatedBy, err)
+			continue
+		}
+
+		configfile.Set(c, "type", yaml.MapSlice{{Key: "settings", Value: yaml.MapSlice{
+			{Key: "schema", Value: theAPI.DeprecatedBy},
+			{Key: "scope", Value: "environment"},
+		}}})
+		f.Changed = true
+		converted[coordinate.Coordinate{Project: f.Project, Type: apiID, ConfigId: fmt.Sprint(id)}] = theAPI.DeprecatedBy
+		report.migrated(f.Path, f.coordinate(apiID, id), "migrated config of deprecated API %q to settings schema %q", apiID, theAPI.DeprecatedBy)
+	}
+}
+
+// migrateReferences updates all references to converted configs to the new type of the config, see
+// configfile.ForEachReferenceParameter for the parameters that are checked. References that omit the type of the
+// referenced config refer to configs of the type of the config they are defined in. If that config was converted, they
+// are updated to keep referring to configs of its previous type, unless the referenced config was converted as well.
+func (f *configFile) migrateReferences(converted map[coordinate.Coordinate]string, report *Report) {
+	if len(converted) == 0 {
+		return
+	}
+
+	for _, c := range f.Configs() {
+		id, _ := configfile.Get(c, "id")
+		newOwner := coordinate.Coordinate{Project: f.Project, Type: declaredType(c), ConfigId: fmt.Sprint(id)}
+		owner := previousCoordinate(newOwner, converted)
+
+		configfile.ForEachReferenceParameter(c, func(parent yaml.MapSlice, key string) {
+			for i := range parent {
+				if parent[i].Key != key {
+					continue
+				}
+
+				ref, ok := configfile.ReferencedCoordinate(parent[i].Value, owner)
+				if !ok {
+					return
+				}
+				newRef := ref
+				if newType, found := converted[ref]; found {
+					newRef.Type = newType
+				}
+
+				updated := configfile.RewriteReference(parent[i].Value, newRef, newOwner)
+				if reflect.DeepEqual(updated, parent[i].Value) {
+					return
+				}
+				parent[i].Value = updated
+				f.Changed = true
+				if newRef != ref {
+					report.migrated(f.Path, newOwner.String(), "updated reference to %s to type %q", ref, newRef.Type)
+				} else {
+					report.migrated(f.Path, newOwner.String(), "added type %q to reference to %s, as the type of the config changed", ref.Type, ref)
+				}
+				return
+			}
+		})
+	}
+}
+
+// declaredType returns the type the given config declares for classic APIs and settings, as used in its coordinate.
+// For all other configs, and configs inheriting their type, it is empty.
+func declaredType(c yaml.MapSlice) string {
+	switch t, _ := configfile.Get(c, "type"); v := t.(type) {
+	case string:
+		return v
+	case yaml.MapSlice:
+		if a, found := configfile.Get(v, "api"); found {
+			return fmt.Sprint(a)
+		}
+		if settings, ok := configfile.GetMapSlice(v, "settings"); ok {
+			if schema, found := configfile.Get(settings, "schema"); found {
+				return fmt.Sprint(schema)
+			}
+		}
+	}
+	return ""
+}
+
+// previousCoordinate returns the coordinate a config with the given coordinate had before it was converted. For
+// configs that were not converted, the coordinate is returned as is.
+func previousCoordinate(c coordinate.Coordinate, converted map[coordinate.Coordinate]string) coordinate.Coordinate {
+	for previous, newType := range converted {
+		if previous.Project == c.Project && previous.ConfigId == c.ConfigId && newType == c.Type {
+			return previous
+		}
+	}
+	return c
+}
+
+func (f *configFile) coordinate(apiID string, id any) string {
+	return coordinate.Coordinate{Project: f.Project, Type: apiID, ConfigId: fmt.Sprint(id)}.String()
+}
+
+func (f *configFile) write(changes *changeSet) error {
+	content, err := f.Marshal()
+	if err != nil {
+		return err
+	}
+
+	if err := changes.writeFile(f.Path, content); err != nil {
+		return fmt.Errorf("failed to write config file %q: %w", f.Path, err)
+	}
+	return nil
+}
+
+// convertTemplates converts all given templates that have not been converted yet. Templates are only written if all of
+// them can be converted.
+func convertTemplates(changes *changeSet, dir string, templates []string, convert settingsConverter, convertedTemplates map[string]struct{}) error {
+	results := make(map[string][]byte, len(templates))
+
+	for _, t := range templates {
+		path := filepath.Join(dir, filepath.FromSlash(t))
+		if _, found := convertedTemplates[path]; found {
+			continue
+		}
+
+		content, err := afero.ReadFile(changes.fs, path)
+		if err != nil {
+			return fmt.Errorf("failed to read template %q: %w", t, err)
+		}
+
+		var payload map[string]any
+		if err := json.Unmarshal(content, &payload); err != nil {
+			return fmt.Errorf("template %q is not a JSON object before rendering: %w", t, err)
+		}
+
+		value, err := convert(payload)
+		if err != nil {
+			return fmt.Errorf("template %q: %w", t, err)
+		}
+
+		if results[path], err = json.MarshalIndent(value, "", "  "); err != nil {
+			return fmt.Errorf("template %q: %w", t, err)
+		}
+	}
+
+	for path, content := range results {
+		if err := changes.writeFile(path, content); err != nil {
+			return fmt.Errorf("failed to write template %q: %w", path, err)
+		}
+		convertedTemplates[path] = struct{}{}
+	}
+	return nil
+}
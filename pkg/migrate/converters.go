@@ -0,0 +1,67 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
+)
+
+// settingsConverter converts the payload of a config of a deprecated classic API into the value of a settings object
+// of the schema the API is deprecated by (see api.API.DeprecatedBy).
+type settingsConverter func(payload map[string]any) (map[string]any, error)
+
+// settingsConverters holds the converters of all deprecated classic APIs that can be migrated automatically, keyed by
+// the ID of the API. Only APIs whose payload maps one-to-one to the settings schema are converted, as the settings
+// schemas of most deprecated APIs restructure the payload in ways that need a manual decision. Support for further
+// APIs is added by registering a converter here, e.g. using renameProperties. Configs of deprecated APIs without
+// converter are listed in the migration report, together with the schema they have to be migrated to.
+var settingsConverters = map[string]settingsConverter{
+	api.FrequentIssueDetection: renameProperties(map[string]string{
+		"frequentIssueDetectionApplicationEnabled":    "detectFrequentIssuesInApplications",
+		"frequentIssueDetectionServiceEnabled":        "detectFrequentIssuesInTransactionsAndServices",
+		"frequentIssueDetectionInfrastructureEnabled": "detectFrequentIssuesInInfrastructure",
+	}),
+}
+
+// renameProperties returns a converter for payloads whose properties map one-to-one to the properties of the settings
+// object. The read-only 'metadata' of downloaded payloads is dropped, any other unknown property fails the conversion.
+func renameProperties(names map[string]string) settingsConverter {
+	return func(payload map[string]any) (map[string]any, error) {
+		result := make(map[string]any, len(payload))
+		for k, v := range payload {
+			if k == "metadata" {
+				continue
+			}
+
+			name, found := names[k]
+			if !found {
+				return nil, fmt.Errorf("property %q has no Settings 2.0 equivalent", k)
+			}
+			result[name] = v
+		}
+		return result, nil
+	}
+}
+
+// ConvertibleAPIs returns the IDs of the deprecated classic APIs whose configs are migrated to settings automatically.
+func ConvertibleAPIs() []string {
+	return slices.Sorted(maps.Keys(settingsConverters))
+}
@@ -0,0 +1,204 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package migrate rewrites manifests and projects to the latest format.
+//
+// Only files that need to be changed are rewritten, and only once the whole migration succeeded. Config files are
+// rewritten key by key, so their structure is kept, but comments in rewritten files are lost.
+//
+// Configs of deprecated classic APIs are converted to settings only for APIs with a converter registered in
+// settingsConverters. All other configs of deprecated APIs are listed in the report, to be migrated manually.
+// References to converted configs are updated in config files, but not in the defaults files of projects.
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/configfile"
+	internalVersion "github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/version"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+)
+
+// Options define how a migration is run.
+type Options struct {
+	// DryRun creates the report of the migration without writing any file.
+	DryRun bool
+}
+
+// Report lists the changes of a migration, and everything that could not be migrated automatically.
+type Report struct {
+	Migrated    []Entry `yaml:"migrated"`
+	NotMigrated []Entry `yaml:"notMigrated"`
+}
+
+// Entry is a single change, or a single thing that needs to be migrated manually.
+type Entry struct {
+	File string `yaml:"file"`
+	// Config is the coordinate of the config the entry belongs to - omitted if the entry is not specific to a config
+	Config  string `yaml:"config,omitempty"`
+	Message string `yaml:"message"`
+}
+
+func (r *Report) migrated(file string, config string, format string, args ...any) {
+	r.Migrated = append(r.Migrated, Entry{File: file, Config: config, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *Report) notMigrated(file string, config string, format string, args ...any) {
+	r.NotMigrated = append(r.NotMigrated, Entry{File: file, Config: config, Message: fmt.Sprintf(format, args...)})
+}
+
+// Migrate migrates the manifest at the given path and all of its projects to the latest format. No file is changed if
+// any part of the migration fails.
+func Migrate(fs afero.Fs, manifestPath string, opts Options) (Report, error) {
+	changes := newChangeSet(fs)
+
+	report, err := migrate(changes, manifestPath)
+	if err != nil {
+		return Report{}, err
+	}
+
+	if !opts.DryRun {
+		if err := changes.commit(fs); err != nil {
+			return Report{}, err
+		}
+	}
+	return report, nil
+}
+
+func migrate(changes *changeSet, manifestPath string) (Report, error) {
+	var report Report
+	fs := changes.fs
+
+	if err := migrateManifestVersion(changes, manifestPath, &report); err != nil {
+		return Report{}, err
+	}
+
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts:         manifestloader.Options{DoNotResolveEnvVars: true},
+	})
+	if len(errs) > 0 {
+		return Report{}, fmt.Errorf("failed to load manifest %q: %w", manifestPath, errors.Join(errs...))
+	}
+
+	var configFiles []*configFile
+	seen := map[string]struct{}{}
+	for _, name := range slices.Sorted(maps.Keys(m.Projects)) {
+		projectFiles, err := configfile.ReadProject(fs, filepath.Join(filepath.Dir(manifestPath), m.Projects[name].Path), name, seen)
+		if err != nil {
+			return Report{}, err
+		}
+		for _, f := range projectFiles {
+			configFiles = append(configFiles, &configFile{File: f})
+		}
+	}
+
+	// references can point to configs of any project, so all configs are migrated before rewriting references
+	converted := map[coordinate.Coordinate]string{}
+	convertedTemplates := map[string]struct{}{}
+	for _, f := range configFiles {
+		f.migrateTypes(changes, &report, converted, convertedTemplates)
+	}
+
+	for _, f := range configFiles {
+		f.migrateReferences(converted, &report)
+
+		if f.Changed {
+			if err := f.write(changes); err != nil {
+				return Report{}, err
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// changeSet holds the files changed by a migration in memory until they are committed. Reading from its fs returns the
+// changed content, so that migrated files can be read during the migration.
+type changeSet struct {
+	fs    afero.Fs
+	paths []string
+}
+
+func newChangeSet(fs afero.Fs) *changeSet {
+	return &changeSet{fs: afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(fs), afero.NewMemMapFs())}
+}
+
+func (c *changeSet) writeFile(path string, content []byte) error {
+	if err := afero.WriteFile(c.fs, path, content, 0644); err != nil {
+		return err
+	}
+	if !slices.Contains(c.paths, path) {
+		c.paths = append(c.paths, path)
+	}
+	return nil
+}
+
+// commit writes all changed files to the given file system.
+func (c *changeSet) commit(fs afero.Fs) error {
+	for _, path := range c.paths {
+		content, err := afero.ReadFile(c.fs, path)
+		if err != nil {
+			return fmt.Errorf("failed to read migrated file %q: %w", path, err)
+		}
+		if err := afero.WriteFile(fs, path, content, 0644); err != nil {
+			return fmt.Errorf("failed to write file %q: %w", path, err)
+		}
+	}
+	return nil
+}
+
+var manifestVersionPattern = regexp.MustCompile(`(?m)^manifestVersion:[ \t]*(.*?)[ \t]*$`)
+
+// migrateManifestVersion sets the manifestVersion to the latest version, if it is older or invalid.
+// The manifest is changed in place to keep its formatting and comments.
+func migrateManifestVersion(changes *changeSet, manifestPath string, report *Report) error {
+	content, err := afero.ReadFile(changes.fs, manifestPath)
+	if err != nil {
+		return fmt.Errorf("failed to read manifest %q: %w", manifestPath, err)
+	}
+
+	match := manifestVersionPattern.FindSubmatchIndex(content)
+	if match == nil {
+		report.notMigrated(manifestPath, "", "'manifestVersion' is not defined at the top level of the manifest")
+		return nil
+	}
+
+	current := strings.Trim(string(content[match[2]:match[3]]), `"'`)
+	latest, _ := internalVersion.ParseVersion(version.ManifestVersion)
+	if v, err := internalVersion.ParseVersion(current); err == nil && !v.SmallerThan(latest) {
+		return nil
+	}
+
+	migrated := slices.Concat(content[:match[0]], []byte(fmt.Sprintf("manifestVersion: %q", version.ManifestVersion)), content[match[1]:])
+	if err := changes.writeFile(manifestPath, migrated); err != nil {
+		return fmt.Errorf("failed to write manifest %q: %w", manifestPath, err)
+	}
+
+	report.migrated(manifestPath, "", "updated 'manifestVersion' from %q to %q", current, version.ManifestVersion)
+	return nil
+}
@@ -0,0 +1,308 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `# keep this comment
+manifestVersion: 0.9
+projects: [{name: project}, {name: other}]
+`
+
+const testConfigs = `configs:
+- id: dashboard
+  type: dashboard
+  config:
+    name: Dashboard
+    template: dashboard.json
+- id: issues
+  type:
+    api: frequent-issue-detection
+  config:
+    name: Issues
+    template: issues.json
+- id: zone
+  type:
+    api: management-zone
+  config:
+    name: Zone
+    template: zone.json
+- id: bucket
+  type: bucket
+  config:
+    name: Bucket
+    template: bucket.json
+`
+
+const testOtherConfigs = `configs:
+- id: overview
+  type:
+    api: dashboard
+  config:
+    name: [project, frequent-issue-detection, issues, name]
+    parameters:
+      issues:
+        type: reference
+        project: project
+        configType: frequent-issue-detection
+        configId: issues
+        property: id
+      zone:
+        type: reference
+        project: project
+        configType: management-zone
+        configId: zone
+        property: id
+    template: overview.json
+`
+
+func newTestFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		"manifest.yaml":       testManifest,
+		"project/config.yaml": testConfigs,
+		"project/issues.json": `{"frequentIssueDetectionApplicationEnabled": true, "frequentIssueDetectionServiceEnabled": false, "frequentIssueDetectionInfrastructureEnabled": true, "metadata": {"clusterVersion": "1.0"}}`,
+		"other/config.yaml":   testOtherConfigs,
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+	return fs
+}
+
+func TestMigrate(t *testing.T) {
+	fs := newTestFs(t)
+
+	report, err := Migrate(fs, "manifest.yaml", Options{})
+	require.NoError(t, err)
+
+	t.Run("manifestVersion is updated in place", func(t *testing.T) {
+		content, err := afero.ReadFile(fs, "manifest.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "# keep this comment\nmanifestVersion: \"1.0\"\nprojects: [{name: project}, {name: other}]\n", string(content))
+	})
+
+	t.Run("types are migrated", func(t *testing.T) {
+		content, err := afero.ReadFile(fs, "project/config.yaml")
+		require.NoError(t, err)
+		assert.Equal(t, `configs:
+- id: dashboard
+  type:
+    api: dashboard
+  config:
+    name: Dashboard
+    template: dashboard.json
+- id: issues
+  type:
+    settings:
+      schema: builtin:anomaly-detection.frequent-issues
+      scope: environment
+  config:
+    name: Issues
+    template: issues.json
+- id: zone
+  type:
+    api: management-zone
+  config:
+    name: Zone
+    template: zone.json
+- id: bucket
+  type: bucket
+  config:
+    name: Bucket
+    template: bucket.json
+`, string(content))
+	})
+
+	t.Run("templates of migrated configs are converted", func(t *testing.T) {
+		content, err := afero.ReadFile(fs, "project/issues.json")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"detectFrequentIssuesInApplications": true, "detectFrequentIssuesInTransactionsAndServices": false, "detectFrequentIssuesInInfrastructure": true}`, string(content))
+	})
+
+	t.Run("references to migrated configs are updated", func(t *testing.T) {
+		content, err := afero.ReadFile(fs, "other/config.yaml")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "- builtin:anomaly-detection.frequent-issues\n")
+		assert.Contains(t, string(content), "configType: builtin:anomaly-detection.frequent-issues\n")
+		assert.Contains(t, string(content), "configType: management-zone\n")
+	})
+
+	t.Run("report lists changes and what needs manual migration", func(t *testing.T) {
+		assert.Len(t, report.Migrated, 5)
+		assert.Equal(t, []Entry{{
+			File:    "project/config.yaml",
+			Config:  "project:management-zone:zone",
+			Message: `API "management-zone" is deprecated by "builtin:management-zones", but can not be migrated automatically - please migrate the config manually`,
+		}}, report.NotMigrated)
+	})
+
+	t.Run("migrating again changes nothing", func(t *testing.T) {
+		report, err := Migrate(fs, "manifest.yaml", Options{})
+		require.NoError(t, err)
+		assert.Empty(t, report.Migrated)
+		assert.Len(t, report.NotMigrated, 1)
+	})
+}
+
+func TestMigrate_UpdatesReferencesOfSettingsTypesAndConvertedConfigs(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		"manifest.yaml": "manifestVersion: \"1.0\"\nprojects: [{name: project}]\n",
+		"project/config.yaml": `configs:
+- id: issues
+  type:
+    api: frequent-issue-detection
+  config:
+    name: Issues
+    parameters:
+      unconverted: [unconverted, id]
+    template: issues.json
+- id: unconverted
+  type:
+    api: frequent-issue-detection
+  config:
+    name: Unconverted
+    template: unconverted.json
+- id: profile
+  type:
+    settings:
+      schema: builtin:alerting.profile
+      scope: [frequent-issue-detection, issues, id]
+      insertAfter: {type: reference, configType: frequent-issue-detection, configId: issues, property: id}
+  config:
+    name: Profile
+    template: profile.json
+`,
+		"project/issues.json":      `{"frequentIssueDetectionApplicationEnabled": true}`,
+		"project/unconverted.json": `{"unknown": true}`,
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+
+	_, err := Migrate(fs, "manifest.yaml", Options{})
+	require.NoError(t, err)
+
+	content, err := afero.ReadFile(fs, "project/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, `configs:
+- id: issues
+  type:
+    settings:
+      schema: builtin:anomaly-detection.frequent-issues
+      scope: environment
+  config:
+    name: Issues
+    parameters:
+      unconverted:
+      - frequent-issue-detection
+      - unconverted
+      - id
+    template: issues.json
+- id: unconverted
+  type:
+    api: frequent-issue-detection
+  config:
+    name: Unconverted
+    template: unconverted.json
+- id: profile
+  type:
+    settings:
+      schema: builtin:alerting.profile
+      scope:
+      - builtin:anomaly-detection.frequent-issues
+      - issues
+      - id
+      insertAfter:
+        type: reference
+        configType: builtin:anomaly-detection.frequent-issues
+        configId: issues
+        property: id
+  config:
+    name: Profile
+    template: profile.json
+`, string(content))
+}
+
+func TestMigrate_DryRunDoesNotChangeFiles(t *testing.T) {
+	fs := newTestFs(t)
+
+	report, err := Migrate(fs, "manifest.yaml", Options{DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, report.Migrated, 5)
+
+	content, err := afero.ReadFile(fs, "manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, testManifest, string(content))
+
+	content, err = afero.ReadFile(fs, "project/config.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, testConfigs, string(content))
+}
+
+func TestMigrate_FailedMigrationDoesNotChangeFiles(t *testing.T) {
+	fs := newTestFs(t)
+	invalidManifest := "manifestVersion: 0.9\nprojects: [{path: project}]\n"
+	require.NoError(t, afero.WriteFile(fs, "manifest.yaml", []byte(invalidManifest), 0644))
+
+	_, err := Migrate(fs, "manifest.yaml", Options{})
+	require.ErrorContains(t, err, `failed to load manifest "manifest.yaml"`)
+
+	content, err := afero.ReadFile(fs, "manifest.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, invalidManifest, string(content), "manifestVersion must not be updated if the migration fails")
+}
+
+func TestConvertibleAPIs(t *testing.T) {
+	assert.Equal(t, []string{"frequent-issue-detection"}, ConvertibleAPIs())
+}
+
+func TestMigrate_ConfigIsNotMigratedIfTemplateCanNotBeConverted(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "project/issues.json", []byte(`{"frequentIssueDetectionApplicationEnabled": {{.enabled}}}`), 0644))
+
+	report, err := Migrate(fs, "manifest.yaml", Options{})
+	require.NoError(t, err)
+
+	require.Len(t, report.NotMigrated, 2)
+	assert.Equal(t, "project:frequent-issue-detection:issues", report.NotMigrated[0].Config)
+	assert.Contains(t, report.NotMigrated[0].Message, `template "issues.json" is not a JSON object before rendering`)
+
+	content, err := afero.ReadFile(fs, "other/config.yaml")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "configType: frequent-issue-detection\n")
+}
+
+func Test_renameProperties(t *testing.T) {
+	convert := renameProperties(map[string]string{"a": "b"})
+
+	result, err := convert(map[string]any{"a": 1, "metadata": map[string]any{}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"b": 1}, result)
+
+	_, err = convert(map[string]any{"c": 1})
+	assert.ErrorContains(t, err, `property "c" has no Settings 2.0 equivalent`)
+}
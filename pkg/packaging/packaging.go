@@ -0,0 +1,330 @@
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package packaging creates versioned, checksummed archives of a manifest and its projects, and opens them for
+// deployment.
+//
+// An archive holds the manifest, the manifests it includes, the certificate files of its connections, all files of its
+// projects and a lock file. Secret files are never packaged. The lock defines the version of the
+// package, the monaco version and feature flags it was created with, and the checksum of each file. Deploying an
+// archive is only possible with the same monaco version and feature flags, so that every stage an archive is promoted
+// to is deployed the same way.
+package packaging
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+	"go.yaml.in/yaml/v2"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/zip"
+	manifestloader "github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest/loader"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+)
+
+const (
+	// LockFileName is the name of the lock file within an archive.
+	LockFileName = "monaco-package.yaml"
+	// ArchiveExtension is the file extension of archives.
+	ArchiveExtension = ".zip"
+	// ChecksumFileExtension is appended to the path of an archive to get the path of the file holding its checksum.
+	ChecksumFileExtension = ".sha256"
+)
+
+// Lock describes the content of an archive, and how it was created.
+type Lock struct {
+	// Version is the version of the package, as given when creating it
+	Version string `yaml:"version"`
+	// MonacoVersion is the version of monaco the package was created with
+	MonacoVersion string `yaml:"monacoVersion"`
+	// Manifest is the path of the manifest within the archive
+	Manifest string `yaml:"manifest"`
+	// FeatureFlags are the values of all feature flags the package was created with
+	FeatureFlags map[string]bool `yaml:"featureFlags"`
+	// Files are the SHA-256 checksums of all files of the archive, but the lock itself
+	Files map[string]string `yaml:"files"`
+}
+
+// Package is an opened and verified archive.
+type Package struct {
+	// Fs holds the files of the archive, located at the path of the archive, on top of the file system the archive was
+	// read from
+	Fs afero.Fs
+	// ManifestPath is the path of the manifest within Fs
+	ManifestPath string
+	Lock         Lock
+}
+
+// IsArchive returns whether the given path is the path of an archive, rather than of a manifest.
+func IsArchive(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ArchiveExtension)
+}
+
+// Create writes an archive of the manifest at manifestPath, the manifests it includes, the CA bundles and client
+// certificates referenced by relative paths, and all files of its projects to archivePath, and its SHA-256 checksum to
+// archivePath + ChecksumFileExtension, in the format of sha256sum. Hidden files and directories of projects are not
+// part of the archive. Secret files, like auth secrets and client keys, must be referenced by absolute paths, as they
+// are not packaged. The archive only depends on the packaged files and the given version, so packaging
+// the same files again results in the same archive.
+func Create(fs afero.Fs, manifestPath string, packageVersion string, archivePath string) (Lock, error) {
+	m, errs := manifestloader.Load(&manifestloader.Context{
+		Fs:           fs,
+		ManifestPath: manifestPath,
+		Opts:         manifestloader.Options{DoNotResolveEnvVars: true},
+	})
+	if len(errs) > 0 {
+		return Lock{}, fmt.Errorf("failed to load manifest %q: %w", manifestPath, errors.Join(errs...))
+	}
+
+	manifestDir := filepath.Dir(manifestPath)
+	excluded := []string{filepath.Clean(archivePath), filepath.Clean(archivePath + ChecksumFileExtension)}
+	files := map[string][]byte{}
+
+	add := func(path string) error {
+		rel, err := filepath.Rel(manifestDir, path)
+		if err != nil || !filepath.IsLocal(rel) {
+			return fmt.Errorf("%q is located outside of the directory of the manifest", path)
+		}
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", path, err)
+		}
+		files[filepath.ToSlash(rel)] = content
+		return nil
+	}
+
+	if err := add(manifestPath); err != nil {
+		return Lock{}, err
+	}
+
+	referenced, err := manifestloader.LoadReferencedFiles(fs, manifestPath)
+	if err != nil {
+		return Lock{}, fmt.Errorf("failed to resolve files referenced by manifest %q: %w", manifestPath, err)
+	}
+	if secrets := slices.DeleteFunc(referenced.Secrets, filepath.IsAbs); len(secrets) > 0 {
+		return Lock{}, fmt.Errorf("secret files are not packaged and must be referenced by absolute paths, but %q are relative", secrets)
+	}
+	for _, path := range referenced.Manifests {
+		if err := add(path); err != nil {
+			return Lock{}, err
+		}
+	}
+	for _, path := range referenced.Certificates {
+		if filepath.IsAbs(path) {
+			continue
+		}
+		if err := add(filepath.Join(manifestDir, path)); err != nil {
+			return Lock{}, err
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(m.Projects)) {
+		projectPath := filepath.Join(manifestDir, m.Projects[name].Path)
+		err := afero.Walk(fs, projectPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(info.Name(), ".") && path != projectPath {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if info.IsDir() || slices.Contains(excluded, filepath.Clean(path)) {
+				return nil
+			}
+			return add(path)
+		})
+		if err != nil {
+			return Lock{}, fmt.Errorf("failed to package project %q: %w", name, err)
+		}
+	}
+
+	if _, found := files[LockFileName]; found {
+		return Lock{}, fmt.Errorf("projects must not contain a file named %q next to the manifest", LockFileName)
+	}
+
+	lock := Lock{
+		Version:       packageVersion,
+		MonacoVersion: version.MonitoringAsCode,
+		Manifest:      filepath.Base(manifestPath),
+		FeatureFlags:  featureflags.States(),
+		Files:         make(map[string]string, len(files)),
+	}
+	for name, content := range files {
+		lock.Files[name] = checksum(content)
+	}
+
+	lockContent, err := yaml.Marshal(lock)
+	if err != nil {
+		return Lock{}, fmt.Errorf("failed to marshal lock: %w", err)
+	}
+	files[LockFileName] = lockContent
+
+	if err := zip.CreateReproducible(fs, archivePath, files); err != nil {
+		return Lock{}, fmt.Errorf("failed to write archive %q: %w", archivePath, err)
+	}
+
+	archive, err := afero.ReadFile(fs, archivePath)
+	if err != nil {
+		return Lock{}, fmt.Errorf("failed to read archive %q: %w", archivePath, err)
+	}
+	checksumLine := fmt.Sprintf("%s  %s\n", checksum(archive), filepath.Base(archivePath))
+	if err := afero.WriteFile(fs, archivePath+ChecksumFileExtension, []byte(checksumLine), 0644); err != nil {
+		return Lock{}, fmt.Errorf("failed to write checksum of archive %q: %w", archivePath, err)
+	}
+
+	return lock, nil
+}
+
+// Open verifies the archive at the given path and returns its content. The archive must match the given SHA-256
+// checksum, or the checksum file of the archive if no checksum is given. The files of the archive must match the
+// checksums of the lock, and the current monaco version and feature flags must match the ones the archive was created
+// with.
+func Open(fs afero.Fs, archivePath string, expectedChecksum string) (Package, error) {
+	if err := verifyArchiveChecksum(fs, archivePath, expectedChecksum); err != nil {
+		return Package{}, err
+	}
+
+	files, err := zip.ReadAll(fs, archivePath)
+	if err != nil {
+		return Package{}, err
+	}
+
+	lockContent, found := files[LockFileName]
+	if !found {
+		return Package{}, fmt.Errorf("archive %q is not a monaco package - %q is missing", archivePath, LockFileName)
+	}
+	delete(files, LockFileName)
+
+	var lock Lock
+	if err := yaml.UnmarshalStrict(lockContent, &lock); err != nil {
+		return Package{}, fmt.Errorf("failed to parse %q of archive %q: %w", LockFileName, archivePath, err)
+	}
+
+	if err := errors.Join(verifyFiles(files, lock), verifyEnvironment(lock)); err != nil {
+		return Package{}, fmt.Errorf("package %q can not be deployed: %w", archivePath, err)
+	}
+
+	root, err := filepath.Abs(archivePath)
+	if err != nil {
+		return Package{}, fmt.Errorf("failed to resolve absolute path of %q: %w", archivePath, err)
+	}
+
+	layer := afero.NewMemMapFs()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := layer.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return Package{}, err
+		}
+		if err := afero.WriteFile(layer, path, content, 0644); err != nil {
+			return Package{}, err
+		}
+	}
+
+	return Package{
+		Fs:           afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(fs), layer),
+		ManifestPath: filepath.Join(root, filepath.FromSlash(lock.Manifest)),
+		Lock:         lock,
+	}, nil
+}
+
+// verifyArchiveChecksum verifies the archive against the expected checksum, or against its checksum file if no
+// checksum is given. An archive without any checksum to verify it against is rejected.
+func verifyArchiveChecksum(fs afero.Fs, archivePath string, expected string) error {
+	source := "the given checksum"
+	if expected == "" {
+		checksumFile := archivePath + ChecksumFileExtension
+		content, err := afero.ReadFile(fs, checksumFile)
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("archive %q can not be verified: checksum file %q does not exist and no checksum is given", archivePath, checksumFile)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %q: %w", checksumFile, err)
+		}
+
+		expected, _, _ = strings.Cut(strings.TrimSpace(string(content)), " ")
+		source = fmt.Sprintf("%q", checksumFile)
+	}
+	expected = strings.ToLower(strings.TrimSpace(expected))
+
+	archive, err := afero.ReadFile(fs, archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to read archive %q: %w", archivePath, err)
+	}
+	if actual := checksum(archive); actual != expected {
+		return fmt.Errorf("checksum %s of archive %q does not match the checksum %s of %s", actual, archivePath, expected, source)
+	}
+	return nil
+}
+
+func verifyFiles(files map[string][]byte, lock Lock) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		expected, found := lock.Files[name]
+		if !found {
+			errs = append(errs, fmt.Errorf("file %q is not part of the lock", name))
+		} else if actual := checksum(files[name]); actual != expected {
+			errs = append(errs, fmt.Errorf("checksum %s of file %q does not match the checksum %s of the lock", actual, name, expected))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(lock.Files)) {
+		if _, found := files[name]; !found {
+			errs = append(errs, fmt.Errorf("file %q of the lock is missing", name))
+		}
+	}
+	if _, found := files[lock.Manifest]; !found {
+		errs = append(errs, fmt.Errorf("manifest %q is missing", lock.Manifest))
+	}
+	return errors.Join(errs...)
+}
+
+// verifyEnvironment checks that the current monaco version and feature flags match the ones of the lock.
+func verifyEnvironment(lock Lock) error {
+	var errs []error
+	if lock.MonacoVersion != version.MonitoringAsCode {
+		errs = append(errs, fmt.Errorf("package was created with monaco version %s, but this is version %s", lock.MonacoVersion, version.MonitoringAsCode))
+	}
+
+	current := featureflags.States()
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		if expected, found := lock.FeatureFlags[name]; !found || expected != current[name] {
+			errs = append(errs, fmt.Errorf("feature flag %s is %v, but the package was created with %s", name, current[name], lockedValue(expected, found)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func lockedValue(value bool, found bool) string {
+	if !found {
+		return "no value"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
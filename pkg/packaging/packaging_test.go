@@ -0,0 +1,258 @@
+//go:build unit
+
+/*
+ * @license
+ * Copyright 2026 Dynatrace LLC
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package packaging
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/featureflags"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/zip"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/version"
+)
+
+const testManifest = `manifestVersion: 1.0
+projects: [{name: project}]
+`
+
+func newTestFs(t *testing.T) afero.Fs {
+	t.Helper()
+
+	fs := afero.NewMemMapFs()
+	for path, content := range map[string]string{
+		"monaco/manifest.yaml":            testManifest,
+		"monaco/project/config.yaml":      "configs: []",
+		"monaco/project/_partials/a.json": "{}",
+		"monaco/project/.cache/ignored":   "x",
+		"monaco/other/not-packaged.yaml":  "x",
+		"values.yaml":                     "project: {name: value}",
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+	return fs
+}
+
+func TestCreate(t *testing.T) {
+	fs := newTestFs(t)
+
+	lock, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", lock.Version)
+	assert.Equal(t, version.MonitoringAsCode, lock.MonacoVersion)
+	assert.Equal(t, "manifest.yaml", lock.Manifest)
+	assert.Equal(t, featureflags.States(), lock.FeatureFlags)
+	assert.ElementsMatch(t, []string{"manifest.yaml", "project/config.yaml", "project/_partials/a.json"}, keys(lock.Files))
+
+	files, err := zip.ReadAll(fs, "release.zip")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"manifest.yaml", "project/config.yaml", "project/_partials/a.json", LockFileName}, keys(files))
+
+	t.Run("archive is reproducible", func(t *testing.T) {
+		first, err := afero.ReadFile(fs, "release.zip.sha256")
+		require.NoError(t, err)
+
+		_, err = Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+		require.NoError(t, err)
+
+		second, err := afero.ReadFile(fs, "release.zip.sha256")
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+		assert.Regexp(t, `^[0-9a-f]{64}  release.zip\n$`, string(first))
+	})
+}
+
+func TestOpen(t *testing.T) {
+	fs := newTestFs(t)
+	_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+	require.NoError(t, err)
+
+	p, err := Open(fs, "release.zip", "")
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", p.Lock.Version)
+
+	root, err := filepath.Abs("release.zip")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "manifest.yaml"), p.ManifestPath)
+
+	content, err := afero.ReadFile(p.Fs, filepath.Join(root, "project", "config.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "configs: []", string(content))
+
+	content, err = afero.ReadFile(p.Fs, "values.yaml")
+	require.NoError(t, err, "files outside of the archive are still available")
+	assert.Equal(t, "project: {name: value}", string(content))
+}
+
+func TestOpen_WithGivenChecksum(t *testing.T) {
+	fs := newTestFs(t)
+	_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+	require.NoError(t, err)
+
+	sidecar, err := afero.ReadFile(fs, "release.zip.sha256")
+	require.NoError(t, err)
+	require.NoError(t, fs.Remove("release.zip.sha256"))
+
+	_, err = Open(fs, "release.zip", strings.ToUpper(string(sidecar[:64])))
+	assert.NoError(t, err)
+}
+
+func TestCreate_PackagesIncludedManifestsAndCertificates(t *testing.T) {
+	fs := newTestFs(t)
+	for path, content := range map[string]string{
+		"monaco/manifest.yaml": testManifest + "includes: [teams/*.yaml]\n",
+		"monaco/teams/team-a.yaml": `projects: [{name: team-a}]
+environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url: {value: https://example.com}
+    auth: {token: {name: TOKEN}}
+    connection: {caBundle: certs/ca.pem}
+`,
+		"monaco/teams/team-a/config.yaml": "configs: []",
+		"monaco/certs/ca.pem":             "certificate",
+		"monaco/certs/unused.pem":         "x",
+	} {
+		require.NoError(t, afero.WriteFile(fs, path, []byte(content), 0644))
+	}
+
+	lock, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"manifest.yaml", "teams/team-a.yaml", "certs/ca.pem", "project/config.yaml", "project/_partials/a.json", "teams/team-a/config.yaml"}, keys(lock.Files))
+
+	p, err := Open(fs, "release.zip", "")
+	require.NoError(t, err)
+	root, err := filepath.Abs("release.zip")
+	require.NoError(t, err)
+	content, err := afero.ReadFile(p.Fs, filepath.Join(root, "certs", "ca.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, "certificate", string(content))
+}
+
+func TestCreate_RejectsRelativeSecretFiles(t *testing.T) {
+	fs := newTestFs(t)
+	require.NoError(t, afero.WriteFile(fs, "monaco/manifest.yaml", []byte(testManifest+`environmentGroups:
+- name: default
+  environments:
+  - name: env
+    url: {value: https://example.com}
+    auth: {token: {type: file, path: secrets/token}}
+`), 0644))
+
+	_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+	assert.ErrorContains(t, err, `secret files are not packaged and must be referenced by absolute paths, but ["secrets/token"] are relative`)
+
+	exists, err := afero.Exists(fs, "release.zip")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestOpen_RejectsModifiedArchives(t *testing.T) {
+	t.Run("archive does not match its checksum", func(t *testing.T) {
+		fs := newTestFs(t)
+		_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fs, "release.zip.sha256", []byte("0000  release.zip\n"), 0644))
+
+		_, err = Open(fs, "release.zip", "")
+		assert.ErrorContains(t, err, `does not match the checksum 0000 of "release.zip.sha256"`)
+	})
+
+	t.Run("file does not match the lock", func(t *testing.T) {
+		fs := newTestFs(t)
+		_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+		require.NoError(t, err)
+
+		files, err := zip.ReadAll(fs, "release.zip")
+		require.NoError(t, err)
+		files["project/config.yaml"] = []byte("configs: [changed]")
+		files["project/added.json"] = []byte("{}")
+		require.NoError(t, zip.CreateReproducible(fs, "release.zip", files))
+		archive, err := afero.ReadFile(fs, "release.zip")
+		require.NoError(t, err)
+
+		_, err = Open(fs, "release.zip", checksum(archive))
+		assert.ErrorContains(t, err, `file "project/added.json" is not part of the lock`)
+		assert.ErrorContains(t, err, `of file "project/config.yaml" does not match the checksum`)
+	})
+
+	t.Run("archive does not match the given checksum", func(t *testing.T) {
+		fs := newTestFs(t)
+		_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+		require.NoError(t, err)
+
+		_, err = Open(fs, "release.zip", "0000")
+		assert.ErrorContains(t, err, `does not match the checksum 0000 of the given checksum`)
+	})
+
+	t.Run("archive without checksum", func(t *testing.T) {
+		fs := newTestFs(t)
+		_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+		require.NoError(t, err)
+		require.NoError(t, fs.Remove("release.zip.sha256"))
+
+		_, err = Open(fs, "release.zip", "")
+		assert.ErrorContains(t, err, `checksum file "release.zip.sha256" does not exist and no checksum is given`)
+	})
+
+	t.Run("archive without lock", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, zip.CreateReproducible(fs, "release.zip", map[string][]byte{"manifest.yaml": []byte(testManifest)}))
+		archive, err := afero.ReadFile(fs, "release.zip")
+		require.NoError(t, err)
+
+		_, err = Open(fs, "release.zip", checksum(archive))
+		assert.ErrorContains(t, err, `is not a monaco package`)
+	})
+}
+
+func TestOpen_RequiresSameMonacoVersionAndFeatureFlags(t *testing.T) {
+	fs := newTestFs(t)
+	_, err := Create(fs, "monaco/manifest.yaml", "1.2.3", "release.zip")
+	require.NoError(t, err)
+
+	previousVersion := version.MonitoringAsCode
+	version.MonitoringAsCode = "2.99.0"
+	t.Cleanup(func() { version.MonitoringAsCode = previousVersion })
+	t.Setenv(featureflags.DangerousCommands.EnvName(), "true")
+
+	_, err = Open(fs, "release.zip", "")
+	assert.ErrorContains(t, err, "package was created with monaco version "+previousVersion+", but this is version 2.99.0")
+	assert.ErrorContains(t, err, "feature flag "+featureflags.DangerousCommands.EnvName()+" is true, but the package was created with false")
+}
+
+func TestIsArchive(t *testing.T) {
+	assert.True(t, IsArchive("release-1.2.3.zip"))
+	assert.True(t, IsArchive("RELEASE.ZIP"))
+	assert.False(t, IsArchive("manifest.yaml"))
+}
+
+func keys[V any](m map[string]V) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
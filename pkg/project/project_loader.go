@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"path/filepath"
 	"slices"
 
 	"github.com/spf13/afero"
@@ -41,6 +42,13 @@ type ProjectLoaderContext struct {
 	WorkingDir      string
 	Manifest        manifest.Manifest
 	ParametersSerde map[string]parameter.ParameterSerDe
+	// Values are parameter values defined in values files, which take precedence over the ones defined in projects.
+	// If nil, no values files are used.
+	Values *loader.Values
+
+	// configDefinitions holds the definitions of all configs of all projects in the manifest, so that configs can
+	// extend configs of projects that are not loaded.
+	configDefinitions *loader.ConfigDefinitions
 }
 
 // DuplicateConfigIdentifierError occurs if configuration IDs are found more than once
@@ -88,6 +96,7 @@ func LoadProjects(ctx context.Context, fs afero.Fs, loaderContext ProjectLoaderC
 	}
 
 	projectNamesToLoad, errs := getProjectNamesToLoad(loaderContext.Manifest.Projects, specificProjectNames)
+	loaderContext.configDefinitions = loadConfigDefinitions(workingDirFs, loaderContext.Manifest.Projects)
 
 	seenProjectNames := make(map[string]struct{}, len(projectNamesToLoad))
 	var loadedProjects []Project
@@ -122,6 +131,14 @@ func LoadProjects(ctx context.Context, fs afero.Fs, loaderContext ProjectLoaderC
 		}
 	}
 
+	if len(errs) == 0 {
+		loadedProjectNames := make([]string, len(loadedProjects))
+		for i, p := range loadedProjects {
+			loadedProjectNames[i] = p.Id
+		}
+		errs = loaderContext.Values.UnmatchedKeys(loaderContext.Manifest.Projects, loadedProjectNames)
+	}
+
 	if len(errs) > 0 {
 		return nil, errs
 	}
@@ -129,6 +146,22 @@ func LoadProjects(ctx context.Context, fs afero.Fs, loaderContext ProjectLoaderC
 	return loadedProjects, nil
 }
 
+// loadConfigDefinitions indexes the config definitions of all given projects. Projects that can not be read are
+// skipped, as errors are reported once the projects are actually loaded.
+func loadConfigDefinitions(fs afero.Fs, projects manifest.ProjectDefinitionByProjectID) *loader.ConfigDefinitions {
+	definitions := loader.NewConfigDefinitions()
+	for _, projectDefinition := range projects {
+		configFiles, err := files.FindYamlFiles(fs, projectDefinition.Path)
+		if err != nil {
+			continue
+		}
+		for _, file := range configFiles {
+			definitions.AddFile(fs, projectDefinition.Name, file)
+		}
+	}
+	return definitions
+}
+
 // Gets full project names to load specified by project or grouping project names. If none are specified, all project names are returned. Errors are returned for any project names that do not exist.
 func getProjectNamesToLoad(allProjectsDefinitions manifest.ProjectDefinitionByProjectID, specificProjectNames []string) ([]string, []error) {
 	projectNamesToLoad := make([]string, 0, len(specificProjectNames))
@@ -308,12 +341,35 @@ func loadConfigsOfProject(ctx context.Context, fs afero.Fs, loadingContext Proje
 		return nil, []error{fmt.Errorf("failed to walk files: %w", err)}
 	}
 
+	defaults, err := loader.LoadProjectDefaults(fs, projectDefinition.Path)
+	if err != nil {
+		return nil, []error{err}
+	}
+	defaultsFile := filepath.Join(projectDefinition.Path, loader.DefaultsFileName)
+
+	yamlTemplates := make(map[string]struct{})
+	for _, file := range configFiles {
+		for _, t := range loader.ReferencedYAMLTemplates(fs, file) {
+			yamlTemplates[filepath.Clean(t)] = struct{}{}
+		}
+	}
+
 	var configs []config.Config
 	var errs []error
 
 	loaderContext := newLoaderContext(loadingContext, projectDefinition, environments)
+	loaderContext.Defaults = defaults
 
 	for _, file := range configFiles {
+		if filepath.Clean(file) == defaultsFile {
+			continue
+		}
+
+		if _, isTemplate := yamlTemplates[filepath.Clean(file)]; isTemplate {
+			log.With(slog.Any("file", file)).DebugContext(ctx, "Skipping YAML template %s", file)
+			continue
+		}
+
 		log.With(slog.Any("file", file)).DebugContext(ctx, "Loading configuration file %s", file)
 		loadedConfigs, configErrs := loader.LoadConfigFile(ctx, fs, loaderContext, file)
 
@@ -327,11 +383,13 @@ func newLoaderContext(loadingContext ProjectLoaderContext, projectDefinition man
 	environments manifest.Environments) *loader.LoaderContext {
 
 	return &loader.LoaderContext{
-		ProjectId:       projectDefinition.Name,
-		Environments:    environments,
-		Path:            projectDefinition.Path,
-		KnownApis:       loadingContext.KnownApis,
-		ParametersSerDe: loadingContext.ParametersSerde,
+		ProjectId:         projectDefinition.Name,
+		Environments:      environments,
+		Path:              projectDefinition.Path,
+		KnownApis:         loadingContext.KnownApis,
+		ParametersSerDe:   loadingContext.ParametersSerde,
+		ConfigDefinitions: loadingContext.configDefinitions,
+		Values:            loadingContext.Values,
 	}
 }
 
@@ -32,6 +32,7 @@ import (
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/coordinate"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/loader"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/reference"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter/value"
@@ -264,6 +265,60 @@ func TestLoadProjects_LoadsProjectInRootDir(t *testing.T) {
 	assert.Len(t, a, 1, "Expected a one config to be loaded for ")
 }
 
+func TestLoadProjects_SkipsYAMLTemplates(t *testing.T) {
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project/dashboard", 0755))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Test Dashboard\n    template: board.template.yaml\n  type:\n    api: dashboard"), 0644))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.template.yaml", []byte("dashboardMetadata:\n  name: \"{{ .name }}\"\ntiles: []\n"), 0644))
+
+	loaderContext := getSimpleProjectLoaderContext([]string{"project"})
+
+	got, gotErrs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+
+	assert.Len(t, gotErrs, 0, "Expected YAML template not to be loaded as config file")
+	require.Len(t, got, 1, "Expected a single loaded project")
+
+	db := findConfigs(t, got[0], "env", "dashboard")
+	assert.Len(t, db, 1, "Expected a one config to be loaded for dashboard")
+}
+
+func TestLoadProjects_AppliesProjectDefaults(t *testing.T) {
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project/dashboard", 0755))
+	require.NoError(t, afero.WriteFile(testFs, "project/_defaults.yaml", []byte("parameters:\n  team: platform"), 0644))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Test Dashboard\n    template: board.json\n  type:\n    api: dashboard"), 0644))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.json", []byte("{}"), 0644))
+
+	loaderContext := getSimpleProjectLoaderContext([]string{"project"})
+
+	got, gotErrs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+
+	assert.Len(t, gotErrs, 0, "Expected defaults file not to be loaded as config file")
+	require.Len(t, got, 1, "Expected a single loaded project")
+
+	db := findConfigs(t, got[0], "env", "dashboard")
+	require.Len(t, db, 1, "Expected a one config to be loaded for dashboard")
+	assert.Contains(t, db[0].Parameters, "team")
+}
+
+func TestLoadProjects_RejectsUnmatchedValues(t *testing.T) {
+	testFs := testutils.TempFs(t)
+	require.NoError(t, testFs.MkdirAll("project/dashboard", 0755))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.yaml", []byte("configs:\n- id: board\n  config:\n    name: Test Dashboard\n    template: board.json\n  type:\n    api: dashboard"), 0644))
+	require.NoError(t, afero.WriteFile(testFs, "project/dashboard/board.json", []byte("{}"), 0644))
+	require.NoError(t, afero.WriteFile(testFs, "values.yaml", []byte("project:dashboard:board:\n  team: platform\nproject:dashboard:bord:\n  team: platform"), 0644))
+
+	values, err := loader.LoadValuesFiles(testFs, []string{"values.yaml"})
+	require.NoError(t, err)
+	loaderContext := getSimpleProjectLoaderContext([]string{"project"})
+	loaderContext.Values = values
+
+	_, gotErrs := LoadProjects(t.Context(), testFs, loaderContext, nil)
+
+	require.Len(t, gotErrs, 1)
+	assert.ErrorContains(t, gotErrs[0], `key "project:dashboard:bord" does not match any config of project "project"`)
+}
+
 func TestLoadProjects_LoadsProjectInManyDirs(t *testing.T) {
 	testFs := testutils.TempFs(t)
 	require.NoError(t, testFs.MkdirAll("project/a/b/c", 0755))
@@ -68,7 +68,12 @@ func (d DeployAPI) Deploy(ctx context.Context, properties parameter.Properties,
 		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to upsert automation object of type %s with id %s", t.Resource, id)).WithError(err)
 	}
 
-	resp, err := d.upsert(ctx, resourceType, id, []byte(renderedConfig))
+	var previousIDs []string
+	for _, previous := range c.PreviousCoordinates {
+		previousIDs = append(previousIDs, idutils.GenerateUUIDFromCoordinate(previous))
+	}
+
+	resp, err := d.upsert(ctx, resourceType, id, previousIDs, []byte(renderedConfig))
 	if err != nil {
 		return entities.ResolvedEntity{}, errors.NewConfigDeployErr(c, fmt.Sprintf("failed to upsert automation object of type %s with id %s", t.Resource, id)).WithError(err)
 	}
@@ -88,17 +93,21 @@ func (d DeployAPI) Deploy(ctx context.Context, properties parameter.Properties,
 
 }
 
-func (d DeployAPI) upsert(ctx context.Context, resourceType automation.ResourceType, id string, data []byte) (api.Response, error) {
-	resp, err := d.source.Update(ctx, resourceType, id, data)
-
-	// return response if there is no error
-	if err == nil {
-		return resp, nil
-	}
-
-	// NotFound would mean that we need to create it, if not, something else is happening
-	if !api.IsNotFoundError(err) {
-		return api.Response{}, err
+// upsert updates the object with the given id, or else the first existing object of the previous IDs. If none of them
+// exists, the object is created with the given id.
+func (d DeployAPI) upsert(ctx context.Context, resourceType automation.ResourceType, id string, previousIDs []string, data []byte) (api.Response, error) {
+	for _, updateID := range append([]string{id}, previousIDs...) {
+		resp, err := d.source.Update(ctx, resourceType, updateID, data)
+
+		// return response if there is no error
+		if err == nil {
+			return resp, nil
+		}
+
+		// NotFound would mean that we need to try the next ID or create it, if not, something else is happening
+		if !api.IsNotFoundError(err) {
+			return api.Response{}, err
+		}
 	}
 
 	// make sure actual "id" field is set in payload
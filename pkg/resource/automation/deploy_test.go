@@ -27,6 +27,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code-core/api"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
@@ -210,6 +211,28 @@ func TestDeploy_WithCreate(t *testing.T) {
 	assert.NoError(t, errs)
 }
 
+func TestDeploy_UpdatesObjectOfPreviousCoordinate(t *testing.T) {
+	previous := coordinate.Coordinate{Project: "old-project", Type: "workflow", ConfigId: "old-id"}
+	current := coordinate.Coordinate{Project: "project", Type: "workflow", ConfigId: "config-id"}
+
+	client := automation.NewMockDeploySource(gomock.NewController(t))
+	gomock.InOrder(
+		client.EXPECT().Update(gomock.Any(), gomock.Any(), idutils.GenerateUUIDFromCoordinate(current), gomock.Any()).Times(1).Return(api.Response{}, api.APIError{StatusCode: 404}),
+		client.EXPECT().Update(gomock.Any(), gomock.Any(), idutils.GenerateUUIDFromCoordinate(previous), gomock.Any()).Times(1).Return(api.Response{StatusCode: 200, Data: idResponseData}, nil),
+	)
+	client.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	conf := &config.Config{
+		Coordinate:          current,
+		PreviousCoordinates: []coordinate.Coordinate{previous},
+		Type: config.AutomationType{
+			Resource: config.Workflow,
+		},
+	}
+	_, errs := automation.NewDeployAPI(client).Deploy(t.Context(), parameter.Properties{}, "{}", conf)
+	assert.NoError(t, errs)
+}
+
 func TestDeploy_FailsIfPayloadIsInvalid(t *testing.T) {
 	client := automation.NewMockDeploySource(gomock.NewController(t))
 	client.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(api.Response{}, api.APIError{StatusCode: 404})
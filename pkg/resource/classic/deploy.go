@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/log"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
@@ -31,6 +32,7 @@ import (
 )
 
 type DeploySource interface {
+	List(ctx context.Context, a api.API) ([]dtclient.Value, error)
 	UpsertByName(ctx context.Context, a api.API, name string, payload []byte) (dtclient.DynatraceEntity, error)
 	UpsertByNonUniqueNameAndId(ctx context.Context, a api.API, entityID string, name string, payload []byte, duplicate bool) (dtclient.DynatraceEntity, error)
 }
@@ -104,11 +106,47 @@ func (d DeployAPI) upsertNonUniqueNameConfig(ctx context.Context, apiToDeploy ap
 
 	if !isUUIDOrMeID {
 		entityUUID = idutils.GenerateUUIDFromConfigId(conf.Coordinate.Project, entityUUID)
+
+		if previousUUID, found, err := d.findEntityOfPreviousCoordinates(ctx, apiToDeploy, conf, entityUUID); err != nil {
+			return dtclient.DynatraceEntity{}, err
+		} else if found {
+			entityUUID = previousUUID
+		}
 	}
 
 	return d.source.UpsertByNonUniqueNameAndId(ctx, apiToDeploy, entityUUID, configName, []byte(renderedConfig), duplicate)
 }
 
+// findEntityOfPreviousCoordinates returns the UUID generated for a previous coordinate of the config, if no entity with
+// the current UUID exists, but one with the UUID of a previous coordinate does.
+func (d DeployAPI) findEntityOfPreviousCoordinates(ctx context.Context, apiToDeploy api.API, conf *config.Config, entityUUID string) (string, bool, error) {
+	if len(conf.PreviousCoordinates) == 0 {
+		return "", false, nil
+	}
+
+	existing, err := d.source.List(ctx, apiToDeploy)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to query existing entities: %w", err)
+	}
+
+	ids := make(map[string]struct{}, len(existing))
+	for _, e := range existing {
+		ids[e.Id] = struct{}{}
+	}
+	if _, found := ids[entityUUID]; found {
+		return "", false, nil
+	}
+
+	for _, previous := range conf.PreviousCoordinates {
+		previousUUID := idutils.GenerateUUIDFromConfigId(previous.Project, previous.ConfigId)
+		if _, found := ids[previousUUID]; found {
+			log.DebugContext(ctx, "Updating existing entity %q of previous coordinate %s", previousUUID, previous)
+			return previousUUID, true, nil
+		}
+	}
+	return "", false, nil
+}
+
 // checkIsDuplicate checks if we are dealing with a non-unique name configuration that appears multiple times
 // in a monaco project. if that's the case, we need to handle it differently, by setting the duplicate parameter accordingly
 func checkIsDuplicate(parameters config.Parameters) (bool, error) {
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/idutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/internal/testutils"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/api"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/client/dtclient"
@@ -45,10 +46,15 @@ var (
 )
 
 type clientStub struct {
+	list                       func(ctx context.Context, a api.API) ([]dtclient.Value, error)
 	upsertByName               func(ctx context.Context, a api.API, name string, payload []byte) (dtclient.DynatraceEntity, error)
 	upsertByNonUniqueNameAndId func(ctx context.Context, a api.API, entityID string, name string, payload []byte, duplicate bool) (dtclient.DynatraceEntity, error)
 }
 
+func (c clientStub) List(ctx context.Context, a api.API) ([]dtclient.Value, error) {
+	return c.list(ctx, a)
+}
+
 func (c clientStub) UpsertByName(ctx context.Context, a api.API, name string, payload []byte) (dtclient.DynatraceEntity, error) {
 	return c.upsertByName(ctx, a, name, payload)
 }
@@ -333,4 +339,50 @@ func TestDeploy_UpdatesByNonUniqueName(t *testing.T) {
 		_, err := classic.NewDeployAPI(c, api.NewAPIs()).Deploy(t.Context(), scopeAndNameParameter, "", &conf)
 		assert.NoError(t, err)
 	})
+
+	t.Run("Updates the entity of a previous coordinate", func(t *testing.T) {
+		previous := coordinate.Coordinate{Project: "old-project", Type: api.Dashboard, ConfigId: "old-id"}
+		previousUUID := idutils.GenerateUUIDFromConfigId(previous.Project, previous.ConfigId)
+		c := clientStub{
+			list: func(ctx context.Context, a api.API) ([]dtclient.Value, error) {
+				return []dtclient.Value{{Id: previousUUID, Name: "name"}}, nil
+			},
+			upsertByNonUniqueNameAndId: func(ctx context.Context, a api.API, entityID string, name string, payload []byte, duplicate bool) (dtclient.DynatraceEntity, error) {
+				require.Equal(t, previousUUID, entityID)
+				return dtclient.DynatraceEntity{}, nil
+			},
+		}
+		conf := config.Config{
+			Type:                config.ClassicApiType{Api: api.Dashboard},
+			Template:            testutils.GenerateDummyTemplate(t),
+			Coordinate:          coordinate.Coordinate{Project: "project", Type: api.Dashboard, ConfigId: "id"},
+			PreviousCoordinates: []coordinate.Coordinate{previous},
+		}
+		_, err := classic.NewDeployAPI(c, api.NewAPIs()).Deploy(t.Context(), nameParameter, "", &conf)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Prefers the entity of the current coordinate over previous ones", func(t *testing.T) {
+		currentUUID := idutils.GenerateUUIDFromConfigId("project", "id")
+		c := clientStub{
+			list: func(ctx context.Context, a api.API) ([]dtclient.Value, error) {
+				return []dtclient.Value{
+					{Id: idutils.GenerateUUIDFromConfigId("project", "old-id"), Name: "name"},
+					{Id: currentUUID, Name: "name"},
+				}, nil
+			},
+			upsertByNonUniqueNameAndId: func(ctx context.Context, a api.API, entityID string, name string, payload []byte, duplicate bool) (dtclient.DynatraceEntity, error) {
+				require.Equal(t, currentUUID, entityID)
+				return dtclient.DynatraceEntity{}, nil
+			},
+		}
+		conf := config.Config{
+			Type:                config.ClassicApiType{Api: api.Dashboard},
+			Template:            testutils.GenerateDummyTemplate(t),
+			Coordinate:          coordinate.Coordinate{Project: "project", Type: api.Dashboard, ConfigId: "id"},
+			PreviousCoordinates: []coordinate.Coordinate{{Project: "project", Type: api.Dashboard, ConfigId: "old-id"}},
+		}
+		_, err := classic.NewDeployAPI(c, api.NewAPIs()).Deploy(t.Context(), nameParameter, "", &conf)
+		assert.NoError(t, err)
+	})
 }
@@ -89,8 +89,14 @@ func (d DeployAPI) Deploy(ctx context.Context, properties parameter.Properties,
 }
 
 func (d DeployAPI) upsertDocument(ctx context.Context, c *config.Config, metadata documents.Metadata, customID string, payload []byte) (api.Response, error) {
-	// We try both IDs in order: originObjectId (UUID) first, then customID
+	// We try the IDs in order: originObjectId (UUID) first, then customID, then the generated external IDs of previous
+	// coordinates of the config, if no custom ID is defined
 	idsToTry := []string{c.OriginObjectId, customID}
+	if documentType, _ := getDocumentType(c.Type); documentType.CustomID == "" {
+		for _, prev := range c.PreviousCoordinates {
+			idsToTry = append(idsToTry, idutils.GenerateExternalID(prev))
+		}
+	}
 	for _, id := range idsToTry {
 		if id == "" {
 			continue
@@ -313,6 +313,41 @@ func TestDeploy_ConfigWithoutOriginObjectId_WithCustomID(t *testing.T) {
 	})
 }
 
+func TestDeploy_ConfigWithPreviousCoordinates(t *testing.T) {
+	previousCoordinate := coordinate.Coordinate{Project: "old-proj", Type: string(config.DashboardKind), ConfigId: "my-dashboard"}
+	documentConfig := &config.Config{
+		Type:                config.DocumentType{Kind: config.DashboardKind},
+		Coordinate:          documentConfigCoordinate,
+		Template:            testutils.GenerateDummyTemplate(t),
+		Parameters:          defaultParameters,
+		PreviousCoordinates: []coordinate.Coordinate{previousCoordinate},
+	}
+
+	expectedExternalId := idutils.GenerateExternalID(documentConfigCoordinate)
+	previousExternalId := idutils.GenerateExternalID(previousCoordinate)
+
+	t.Run("Document of previous coordinate is updated", func(t *testing.T) {
+		client := document.NewMockDeploySource(gomock.NewController(t))
+		client.EXPECT().Update(gomock.Any(), gomock.Eq(dashboardMetadata(expectedExternalId)), gomock.Any()).Times(1).Return(api.Response{}, api.APIError{StatusCode: http.StatusNotFound})
+		client.EXPECT().Update(gomock.Any(), gomock.Eq(dashboardMetadata(previousExternalId)), gomock.Any()).Times(1).Return(api.Response{Data: fmt.Appendf(nil, `{"id":"%s"}`, previousExternalId)}, nil)
+		result, err := runDeployTest(t, client, documentConfig)
+		assert.NoError(t, err)
+		require.NotEmpty(t, result.Properties)
+		assert.Equal(t, previousExternalId, result.Properties[config.IdParameter])
+	})
+
+	t.Run("Document is created with externalId if no document of previous coordinates exists", func(t *testing.T) {
+		client := document.NewMockDeploySource(gomock.NewController(t))
+		client.EXPECT().Update(gomock.Any(), gomock.Eq(dashboardMetadata(expectedExternalId)), gomock.Any()).Times(1).Return(api.Response{}, api.APIError{StatusCode: http.StatusNotFound})
+		client.EXPECT().Update(gomock.Any(), gomock.Eq(dashboardMetadata(previousExternalId)), gomock.Any()).Times(1).Return(api.Response{}, api.APIError{StatusCode: http.StatusNotFound})
+		client.EXPECT().Create(gomock.Any(), gomock.Eq(dashboardMetadata(expectedExternalId)), gomock.Any()).Times(1).Return(api.Response{Data: fmt.Appendf(nil, `{"id":"%s"}`, expectedExternalId)}, nil)
+		result, err := runDeployTest(t, client, documentConfig)
+		assert.NoError(t, err)
+		require.NotEmpty(t, result.Properties)
+		assert.Equal(t, expectedExternalId, result.Properties[config.IdParameter])
+	})
+}
+
 func TestDeploy_WithLabels_PropagatesLabelsToMetadata(t *testing.T) {
 	labels := []string{"team:foo", "env:prod"}
 	documentConfig := &config.Config{
@@ -63,12 +63,13 @@ func (d DeployAPI) Deploy(ctx context.Context, properties parameter.Properties,
 	}
 
 	settingsObj := dtclient.SettingsObject{
-		Coordinate:     c.Coordinate,
-		SchemaId:       t.SchemaId,
-		SchemaVersion:  t.SchemaVersion,
-		Scope:          scope,
-		Content:        []byte(renderedConfig),
-		OriginObjectId: c.OriginObjectId,
+		Coordinate:          c.Coordinate,
+		SchemaId:            t.SchemaId,
+		SchemaVersion:       t.SchemaVersion,
+		Scope:               scope,
+		Content:             []byte(renderedConfig),
+		OriginObjectId:      c.OriginObjectId,
+		PreviousCoordinates: c.PreviousCoordinates,
 	}
 
 	upsertOptions := dtclient.UpsertSettingsOptions{
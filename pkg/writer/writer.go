@@ -25,6 +25,7 @@ import (
 
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/parameter"
+	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/config/template"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/manifest"
 	"github.com/dynatrace/dynatrace-configuration-as-code/v2/pkg/project"
 )
@@ -35,6 +36,8 @@ type WriterContext struct {
 	OutputDir          string
 	ManifestName       string
 	ParametersSerde    map[string]parameter.ParameterSerDe
+	// TemplateFormat defines the format templates are written in. If not set, templates are written as JSON.
+	TemplateFormat template.Format
 }
 
 func WriteToDisk(context *WriterContext, manifestToWrite manifest.Manifest, projects []project.Project) []error {
@@ -80,6 +83,7 @@ func writeProjects(context *WriterContext, projectDefinitions manifest.ProjectDe
 			OutputFolder:    context.OutputDir,
 			ProjectFolder:   definition.Path,
 			ParametersSerde: context.ParametersSerde,
+			TemplateFormat:  context.TemplateFormat,
 		}, configs)
 
 		errors = append(errors, errs...)